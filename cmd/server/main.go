@@ -4,16 +4,23 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/robfig/cron/v3"
+	migrations "github.com/teezzan/candles/db"
+	"github.com/teezzan/candles/internal/cache"
 	"github.com/teezzan/candles/internal/client/s3"
 	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
 	"github.com/teezzan/candles/internal/config"
 	ohlc "github.com/teezzan/candles/internal/controller/ohlc"
 	ohlcRepository "github.com/teezzan/candles/internal/controller/ohlc/repository"
 	"github.com/teezzan/candles/internal/database"
 	"github.com/teezzan/candles/internal/router"
+	"github.com/teezzan/candles/internal/scheduler"
 	"go.uber.org/zap"
 )
 
@@ -27,12 +34,7 @@ func main() {
 	logger, _ := zap.NewProduction()
 	defer logger.Sync()
 	// Database
-	db, err := database.New(
-		conf.Database.Username,
-		conf.Database.Password,
-		conf.Database.Host,
-		conf.Database.Database,
-	)
+	db, err := database.New(conf.Database)
 	if err != nil {
 		panic(err)
 	}
@@ -47,20 +49,70 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	webhookClient := webhook.NewClient(logger, conf.WebhookConfig)
 
 	// Repositories
-	ohlcRepo := ohlcRepository.NewRepository(db.SQL)
+	var ohlcRepo ohlcRepository.Repository
+	switch db.Driver {
+	case database.DriverPostgres:
+		postgresRepo := ohlcRepository.NewPostgresRepository(db.SQL)
+		timescale, err := postgresRepo.SetupTimescale(context.Background())
+		if err != nil {
+			panic(err)
+		}
+		logger.Info("using postgres repository", zap.Bool("timescale", timescale))
+		ohlcRepo = postgresRepo
+	case database.DriverSQLite:
+		err = db.Migrate(context.Background(), migrations.SQLiteMigrations())
+		if err != nil {
+			panic(err)
+		}
+		ohlcRepo = ohlcRepository.NewSQLiteRepository(db.SQL)
+	case database.DriverMemory:
+		ohlcRepo = ohlcRepository.NewMemoryRepository()
+	default:
+		ohlcRepo = ohlcRepository.NewRepository(db.SQL)
+	}
+
+	// Cache
+	var queryCache *cache.Cache
+	if conf.CacheConfig.Enabled {
+		var remote cache.Store
+		if conf.CacheConfig.RedisAddress != "" {
+			redisStore := cache.NewRedisStore(conf.CacheConfig)
+			defer redisStore.Close()
+			remote = redisStore
+		}
+		local := cache.NewMemoryStore(conf.CacheConfig.LocalMaxEntries)
+		queryCache = cache.New(logger, remote, local, time.Duration(conf.CacheConfig.TTLInSeconds)*time.Second)
+		ohlcRepo = ohlcRepository.NewCachedRepository(ohlcRepo, queryCache)
+		logger.Info("caching candle queries", zap.Bool("redis", remote != nil))
+	}
 
 	// Services
-	ohlcService := ohlc.NewService(logger, ohlcRepo, s3Client, sqsClient, conf.OHLCConfig)
+	ohlcService, err := ohlc.NewService(logger, ohlcRepo, s3Client, sqsClient, webhookClient, conf.OHLCConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	// Scheduler
+	jobScheduler := scheduler.New(logger)
+	err = registerJobs(jobScheduler, conf, ohlcService)
+	if err != nil {
+		panic(err)
+	}
 
 	// HTTP Handlers
-	ohlcHTTPHandler := ohlc.NewHTTPHandler(logger, ohlcService)
+	ohlcHTTPHandler := ohlc.NewHTTPHandler(logger, ohlcService, conf.OHLCConfig)
+	schedulerHTTPHandler := scheduler.NewHTTPHandler(logger, jobScheduler)
+	cacheHTTPHandler := cache.NewHTTPHandler(logger, queryCache)
 
 	// Router
 	r := router.New(
 		healthCheckHandlerFunc,
 		ohlcHTTPHandler,
+		schedulerHTTPHandler,
+		cacheHTTPHandler,
 	)
 
 	err = r.SetupRouter(gin.Default())
@@ -68,19 +120,103 @@ func main() {
 		panic(err)
 	}
 
-	c := cron.New()
-	c.AddFunc(fmt.Sprintf("@every %dm", conf.CronJobFrequencyInMinutes), func() {
-		logger.Info("Processing SQS messages")
-		ohlcService.GetAndProcessSQSMessage(context.Background())
-	})
-	c.AddFunc(fmt.Sprintf("@every %dd", conf.CleanupCronJobFrequencyInDays), func() {
-		logger.Info("Cleaning up old data")
-		ohlcService.DeleteStaleProcessingStatus(context.Background(), conf.CleanupCronJobFrequencyInDays)
-	})
-	c.Start()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The partitions of the current month are needed before any data point is inserted.
+	err = ohlcService.MaintainPartitions(context.Background(), conf.PartitionMonthsAhead, conf.PartitionRetentionInMonths)
+	if err != nil {
+		panic(err)
+	}
+
+	// Background processing
+	jobScheduler.Start()
+	consumerDone := make(chan struct{})
+	go func() {
+		defer close(consumerDone)
+		ohlcService.ConsumeSQSMessages(ctx)
+	}()
 
 	// Listen and Serve
-	r.Run(fmt.Sprintf(":%d", conf.Server.Port))
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", conf.Server.Port),
+		Handler: r.GetRouter(),
+	}
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			logger.Error("server stopped", zap.Error(err))
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Info("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.Server.ShutdownTimeoutInSeconds)*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("failed to shut down server", zap.Error(err))
+	}
+	if err := jobScheduler.Stop(shutdownCtx); err != nil {
+		logger.Error("failed to stop scheduler", zap.Error(err))
+	}
+	<-consumerDone
+	if err := ohlcService.WaitForWebhookDeliveries(shutdownCtx); err != nil {
+		logger.Error("webhook deliveries still pending on shutdown", zap.Error(err))
+	}
+}
+
+// registerJobs registers the background jobs on the scheduler.
+func registerJobs(s *scheduler.Scheduler, conf *config.Config, ohlcService ohlc.Service) error {
+	jobs := []struct {
+		name string
+		conf config.JobConfig
+		fn   scheduler.JobFunc
+	}{
+		{
+			name: "stale-status-cleanup",
+			conf: conf.SchedulerConfig.StaleStatusCleanup,
+			fn: func(ctx context.Context) error {
+				return ohlcService.DeleteStaleProcessingStatus(ctx, conf.CleanupCronJobFrequencyInDays)
+			},
+		},
+		{
+			name: "data-quality-check",
+			conf: conf.SchedulerConfig.DataQualityCheck,
+			fn:   ohlcService.RunDataQualityCheck,
+		},
+		{
+			name: "multipart-cleanup",
+			conf: conf.SchedulerConfig.MultipartCleanup,
+			fn: func(ctx context.Context) error {
+				return ohlcService.AbortStaleMultipartUploads(ctx, conf.StaleMultipartUploadAgeInHours)
+			},
+		},
+		{
+			name: "retention",
+			conf: conf.SchedulerConfig.Retention,
+			fn:   ohlcService.ApplyRetentionPolicy,
+		},
+		{
+			name: "rollup-rebuild",
+			conf: conf.SchedulerConfig.RollupRebuild,
+			fn:   ohlcService.RebuildRollups,
+		},
+		{
+			name: "partition-maintenance",
+			conf: conf.SchedulerConfig.PartitionMaintenance,
+			fn: func(ctx context.Context) error {
+				return ohlcService.MaintainPartitions(ctx, conf.PartitionMonthsAhead, conf.PartitionRetentionInMonths)
+			},
+		},
+	}
+	for _, job := range jobs {
+		err := s.Register(job.name, job.conf.Schedule, time.Duration(job.conf.TimeoutInMinutes)*time.Minute, job.fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func healthCheckHandlerFunc(c *gin.Context) {
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `data_quality`;
\ No newline at end of file
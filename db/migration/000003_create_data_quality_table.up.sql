@@ -0,0 +1,16 @@
+CREATE TABLE `data_quality` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `symbol` varchar(50) NOT NULL,
+    `day` date NOT NULL,
+    `interval_seconds` bigint NOT NULL,
+    `expected_count` bigint NOT NULL,
+    `present_count` bigint NOT NULL,
+    `missing_count` bigint NOT NULL,
+    `duplicate_count` bigint NOT NULL,
+    `coverage` DECIMAL(5,2) NOT NULL,
+    `gaps` json NOT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `data_quality_symbol_day_interval` (`symbol`, `day`, `interval_seconds`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE `ohlc_anomaly` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `time` timestamp NOT NULL,
+    `symbol` varchar(50) NOT NULL,
+    `open` DECIMAL(20,10) NOT NULL,
+    `high` DECIMAL(20,10) NOT NULL,
+    `low` DECIMAL(20,10) NOT NULL,
+    `close` DECIMAL(20,10) NOT NULL,
+    `reason` varchar(255) NOT NULL,
+    `status` varchar(50) NOT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `ohlc_anomaly_symbol_status` (`symbol`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
\ No newline at end of file
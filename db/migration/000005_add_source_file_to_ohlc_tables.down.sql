@@ -0,0 +1,7 @@
+ALTER TABLE `ohlc_anomaly`
+    DROP KEY `ohlc_anomaly_source_file`,
+    DROP COLUMN `source_file`;
+
+ALTER TABLE `ohlc_data`
+    DROP KEY `ohlc_data_source_file`,
+    DROP COLUMN `source_file`;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE `ohlc_data`
+    ADD COLUMN `source_file` varchar(1024) NULL,
+    ADD KEY `ohlc_data_source_file` (`source_file`(255));
+
+ALTER TABLE `ohlc_anomaly`
+    ADD COLUMN `source_file` varchar(1024) NULL,
+    ADD KEY `ohlc_anomaly_source_file` (`source_file`(255));
\ No newline at end of file
@@ -0,0 +1,11 @@
+ALTER TABLE `process_status`
+    ADD COLUMN `bytes_total` bigint NOT NULL DEFAULT 0,
+    ADD COLUMN `bytes_read` bigint NOT NULL DEFAULT 0,
+    ADD COLUMN `rows_parsed` bigint NOT NULL DEFAULT 0,
+    ADD COLUMN `rows_inserted` bigint NOT NULL DEFAULT 0,
+    ADD COLUMN `rows_rejected` bigint NOT NULL DEFAULT 0,
+    ADD COLUMN `symbols` json NULL,
+    ADD COLUMN `range_start` timestamp NULL,
+    ADD COLUMN `range_end` timestamp NULL,
+    ADD COLUMN `started_at` timestamp NULL,
+    ADD COLUMN `finished_at` timestamp NULL;
\ No newline at end of file
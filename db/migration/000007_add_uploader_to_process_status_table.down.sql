@@ -0,0 +1,5 @@
+ALTER TABLE `process_status`
+    DROP KEY `process_status_uploader`,
+    DROP KEY `process_status_created_at`,
+    DROP KEY `process_status_file_name`,
+    DROP COLUMN `uploader`;
\ No newline at end of file
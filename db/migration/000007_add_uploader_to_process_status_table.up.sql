@@ -0,0 +1,8 @@
+DELETE `older` FROM `process_status` AS `older`
+    JOIN `process_status` AS `newer` ON `newer`.`file_name` = `older`.`file_name` AND `newer`.`id` > `older`.`id`;
+
+ALTER TABLE `process_status`
+    ADD COLUMN `uploader` varchar(100) NULL,
+    ADD UNIQUE KEY `process_status_file_name` (`file_name`),
+    ADD KEY `process_status_created_at` (`created_at`),
+    ADD KEY `process_status_uploader` (`uploader`);
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE `process_status`
+    ADD COLUMN `callback_url` varchar(2048) NULL;
+
+CREATE TABLE `webhook_delivery` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `file_name` varchar(50) NOT NULL,
+    `url` varchar(2048) NOT NULL,
+    `attempt` int NOT NULL,
+    `status_code` int NULL,
+    `error` text NULL,
+    `delivered` boolean NOT NULL DEFAULT FALSE,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `webhook_delivery_file_name` (`file_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `rejected_message`;
\ No newline at end of file
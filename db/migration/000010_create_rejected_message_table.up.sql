@@ -0,0 +1,8 @@
+CREATE TABLE `rejected_message` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `message_id` varchar(100) NOT NULL,
+    `body` mediumtext NOT NULL,
+    `reason` text NOT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE `ohlc_data` REMOVE PARTITIONING;
+
+ALTER TABLE `ohlc_data`
+    DROP KEY `ohlc_data_symbol_time`,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (`id`);
\ No newline at end of file
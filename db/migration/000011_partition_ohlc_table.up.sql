@@ -0,0 +1,9 @@
+ALTER TABLE `ohlc_data`
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (`id`, `time`),
+    ADD KEY `ohlc_data_symbol_time` (`symbol`, `time`);
+
+ALTER TABLE `ohlc_data`
+    PARTITION BY RANGE (UNIX_TIMESTAMP(`time`)) (
+        PARTITION `p_max` VALUES LESS THAN MAXVALUE
+    );
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE `ohlc_rollup` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `symbol` varchar(50) NOT NULL,
+    `interval_seconds` bigint NOT NULL,
+    `time` timestamp NOT NULL,
+    `open` DECIMAL(20,10) NOT NULL,
+    `high` DECIMAL(20,10) NOT NULL,
+    `low` DECIMAL(20,10) NOT NULL,
+    `close` DECIMAL(20,10) NOT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `ohlc_rollup_symbol_interval_time` (`symbol`, `interval_seconds`, `time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
\ No newline at end of file
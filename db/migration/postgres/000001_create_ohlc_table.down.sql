@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ohlc_data;
\ No newline at end of file
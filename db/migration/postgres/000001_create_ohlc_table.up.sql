@@ -0,0 +1,11 @@
+CREATE TABLE ohlc_data (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    time timestamptz NOT NULL,
+    symbol varchar(50) NOT NULL,
+    open numeric(20,10) NOT NULL,
+    high numeric(20,10) NOT NULL,
+    low numeric(20,10) NOT NULL,
+    close numeric(20,10) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id, time)
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS process_status;
+
+DROP FUNCTION IF EXISTS set_updated_at();
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE process_status (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    file_name varchar(50) NOT NULL,
+    status varchar(50) NOT NULL,
+    error varchar(100) NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE TRIGGER process_status_updated_at BEFORE UPDATE ON process_status
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
\ No newline at end of file
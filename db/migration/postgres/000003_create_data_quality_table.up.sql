@@ -0,0 +1,19 @@
+CREATE TABLE data_quality (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    symbol varchar(50) NOT NULL,
+    day date NOT NULL,
+    interval_seconds bigint NOT NULL,
+    expected_count bigint NOT NULL,
+    present_count bigint NOT NULL,
+    missing_count bigint NOT NULL,
+    duplicate_count bigint NOT NULL,
+    coverage numeric(5,2) NOT NULL,
+    gaps jsonb NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT data_quality_symbol_day_interval UNIQUE (symbol, day, interval_seconds)
+);
+
+CREATE TRIGGER data_quality_updated_at BEFORE UPDATE ON data_quality
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
\ No newline at end of file
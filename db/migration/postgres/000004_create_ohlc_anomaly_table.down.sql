@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ohlc_anomaly;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE ohlc_anomaly (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    time timestamptz NOT NULL,
+    symbol varchar(50) NOT NULL,
+    open numeric(20,10) NOT NULL,
+    high numeric(20,10) NOT NULL,
+    low numeric(20,10) NOT NULL,
+    close numeric(20,10) NOT NULL,
+    reason varchar(255) NOT NULL,
+    status varchar(50) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX ohlc_anomaly_symbol_status ON ohlc_anomaly (symbol, status);
\ No newline at end of file
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS ohlc_anomaly_source_file;
+
+ALTER TABLE ohlc_anomaly
+    DROP COLUMN source_file;
+
+DROP INDEX IF EXISTS ohlc_data_source_file;
+
+ALTER TABLE ohlc_data
+    DROP COLUMN source_file;
\ No newline at end of file
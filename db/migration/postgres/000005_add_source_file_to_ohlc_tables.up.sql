@@ -0,0 +1,9 @@
+ALTER TABLE ohlc_data
+    ADD COLUMN source_file varchar(1024) NULL;
+
+CREATE INDEX ohlc_data_source_file ON ohlc_data (source_file);
+
+ALTER TABLE ohlc_anomaly
+    ADD COLUMN source_file varchar(1024) NULL;
+
+CREATE INDEX ohlc_anomaly_source_file ON ohlc_anomaly (source_file);
\ No newline at end of file
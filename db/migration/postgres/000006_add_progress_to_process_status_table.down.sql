@@ -0,0 +1,11 @@
+ALTER TABLE process_status
+    DROP COLUMN bytes_total,
+    DROP COLUMN bytes_read,
+    DROP COLUMN rows_parsed,
+    DROP COLUMN rows_inserted,
+    DROP COLUMN rows_rejected,
+    DROP COLUMN symbols,
+    DROP COLUMN range_start,
+    DROP COLUMN range_end,
+    DROP COLUMN started_at,
+    DROP COLUMN finished_at;
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS process_status_uploader;
+
+DROP INDEX IF EXISTS process_status_created_at;
+
+ALTER TABLE process_status
+    DROP CONSTRAINT process_status_file_name,
+    DROP COLUMN uploader;
\ No newline at end of file
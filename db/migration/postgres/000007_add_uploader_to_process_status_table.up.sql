@@ -0,0 +1,11 @@
+DELETE FROM process_status AS older
+    USING process_status AS newer
+    WHERE newer.file_name = older.file_name AND newer.id > older.id;
+
+ALTER TABLE process_status
+    ADD COLUMN uploader varchar(100) NULL,
+    ADD CONSTRAINT process_status_file_name UNIQUE (file_name);
+
+CREATE INDEX process_status_created_at ON process_status (created_at);
+
+CREATE INDEX process_status_uploader ON process_status (uploader);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS webhook_delivery;
+
+ALTER TABLE process_status
+    DROP COLUMN callback_url;
\ No newline at end of file
@@ -0,0 +1,16 @@
+ALTER TABLE process_status
+    ADD COLUMN callback_url varchar(2048) NULL;
+
+CREATE TABLE webhook_delivery (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    file_name varchar(50) NOT NULL,
+    url varchar(2048) NOT NULL,
+    attempt int NOT NULL,
+    status_code int NULL,
+    error text NULL,
+    delivered boolean NOT NULL DEFAULT FALSE,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX webhook_delivery_file_name ON webhook_delivery (file_name);
\ No newline at end of file
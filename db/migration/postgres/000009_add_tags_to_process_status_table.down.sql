@@ -0,0 +1,2 @@
+ALTER TABLE process_status
+    DROP COLUMN tags;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE process_status
+    ADD COLUMN tags jsonb NULL;
\ No newline at end of file
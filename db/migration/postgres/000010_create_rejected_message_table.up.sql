@@ -0,0 +1,8 @@
+CREATE TABLE rejected_message (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    message_id varchar(100) NOT NULL,
+    body text NOT NULL,
+    reason text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX ohlc_data_symbol_time ON ohlc_data (symbol, time);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ohlc_rollup;
\ No newline at end of file
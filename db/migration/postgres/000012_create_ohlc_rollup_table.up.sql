@@ -0,0 +1,17 @@
+CREATE TABLE ohlc_rollup (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    symbol varchar(50) NOT NULL,
+    interval_seconds bigint NOT NULL,
+    time timestamptz NOT NULL,
+    open numeric(20,10) NOT NULL,
+    high numeric(20,10) NOT NULL,
+    low numeric(20,10) NOT NULL,
+    close numeric(20,10) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT ohlc_rollup_symbol_interval_time UNIQUE (symbol, interval_seconds, time)
+);
+
+CREATE TRIGGER ohlc_rollup_updated_at BEFORE UPDATE ON ohlc_rollup
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
\ No newline at end of file
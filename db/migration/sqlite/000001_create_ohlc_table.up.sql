@@ -0,0 +1,10 @@
+CREATE TABLE ohlc_data (
+    id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    time timestamp NOT NULL,
+    symbol varchar(50) NOT NULL,
+    open decimal(20,10) NOT NULL,
+    high decimal(20,10) NOT NULL,
+    low decimal(20,10) NOT NULL,
+    close decimal(20,10) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
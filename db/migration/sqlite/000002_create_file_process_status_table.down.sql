@@ -0,0 +1 @@
+DROP TABLE IF EXISTS process_status;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE process_status (
+    id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    file_name varchar(50) NOT NULL,
+    status varchar(50) NOT NULL,
+    error varchar(100) NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER process_status_updated_at AFTER UPDATE ON process_status
+FOR EACH ROW BEGIN
+    UPDATE process_status SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
\ No newline at end of file
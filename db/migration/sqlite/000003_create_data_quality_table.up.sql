@@ -0,0 +1,20 @@
+CREATE TABLE data_quality (
+    id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    symbol varchar(50) NOT NULL,
+    day date NOT NULL,
+    interval_seconds bigint NOT NULL,
+    expected_count bigint NOT NULL,
+    present_count bigint NOT NULL,
+    missing_count bigint NOT NULL,
+    duplicate_count bigint NOT NULL,
+    coverage decimal(5,2) NOT NULL,
+    gaps json NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT data_quality_symbol_day_interval UNIQUE (symbol, day, interval_seconds)
+);
+
+CREATE TRIGGER data_quality_updated_at AFTER UPDATE ON data_quality
+FOR EACH ROW BEGIN
+    UPDATE data_quality SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE ohlc_anomaly (
+    id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    time timestamp NOT NULL,
+    symbol varchar(50) NOT NULL,
+    open decimal(20,10) NOT NULL,
+    high decimal(20,10) NOT NULL,
+    low decimal(20,10) NOT NULL,
+    close decimal(20,10) NOT NULL,
+    reason varchar(255) NOT NULL,
+    status varchar(50) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX ohlc_anomaly_symbol_status ON ohlc_anomaly (symbol, status);
\ No newline at end of file
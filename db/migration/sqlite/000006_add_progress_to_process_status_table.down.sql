@@ -0,0 +1,29 @@
+ALTER TABLE process_status
+    DROP COLUMN bytes_total;
+
+ALTER TABLE process_status
+    DROP COLUMN bytes_read;
+
+ALTER TABLE process_status
+    DROP COLUMN rows_parsed;
+
+ALTER TABLE process_status
+    DROP COLUMN rows_inserted;
+
+ALTER TABLE process_status
+    DROP COLUMN rows_rejected;
+
+ALTER TABLE process_status
+    DROP COLUMN symbols;
+
+ALTER TABLE process_status
+    DROP COLUMN range_start;
+
+ALTER TABLE process_status
+    DROP COLUMN range_end;
+
+ALTER TABLE process_status
+    DROP COLUMN started_at;
+
+ALTER TABLE process_status
+    DROP COLUMN finished_at;
\ No newline at end of file
@@ -0,0 +1,29 @@
+ALTER TABLE process_status
+    ADD COLUMN bytes_total bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE process_status
+    ADD COLUMN bytes_read bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE process_status
+    ADD COLUMN rows_parsed bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE process_status
+    ADD COLUMN rows_inserted bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE process_status
+    ADD COLUMN rows_rejected bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE process_status
+    ADD COLUMN symbols json NULL;
+
+ALTER TABLE process_status
+    ADD COLUMN range_start timestamp NULL;
+
+ALTER TABLE process_status
+    ADD COLUMN range_end timestamp NULL;
+
+ALTER TABLE process_status
+    ADD COLUMN started_at timestamp NULL;
+
+ALTER TABLE process_status
+    ADD COLUMN finished_at timestamp NULL;
\ No newline at end of file
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS process_status_uploader;
+
+DROP INDEX IF EXISTS process_status_created_at;
+
+DROP INDEX IF EXISTS process_status_file_name;
+
+ALTER TABLE process_status
+    DROP COLUMN uploader;
\ No newline at end of file
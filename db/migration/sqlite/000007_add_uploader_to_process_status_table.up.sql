@@ -0,0 +1,11 @@
+DELETE FROM process_status
+    WHERE id NOT IN (SELECT MAX(id) FROM process_status GROUP BY file_name);
+
+ALTER TABLE process_status
+    ADD COLUMN uploader varchar(100) NULL;
+
+CREATE UNIQUE INDEX process_status_file_name ON process_status (file_name);
+
+CREATE INDEX process_status_created_at ON process_status (created_at);
+
+CREATE INDEX process_status_uploader ON process_status (uploader);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE process_status
+    ADD COLUMN tags json NULL;
\ No newline at end of file
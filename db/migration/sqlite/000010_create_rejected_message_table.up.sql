@@ -0,0 +1,7 @@
+CREATE TABLE rejected_message (
+    id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    message_id varchar(100) NOT NULL,
+    body text NOT NULL,
+    reason text NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS ohlc_data_symbol_time;
\ No newline at end of file
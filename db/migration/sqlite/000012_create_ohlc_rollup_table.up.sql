@@ -0,0 +1,18 @@
+CREATE TABLE ohlc_rollup (
+    id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    symbol varchar(50) NOT NULL,
+    interval_seconds bigint NOT NULL,
+    time timestamp NOT NULL,
+    open decimal(20,10) NOT NULL,
+    high decimal(20,10) NOT NULL,
+    low decimal(20,10) NOT NULL,
+    close decimal(20,10) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT ohlc_rollup_symbol_interval_time UNIQUE (symbol, interval_seconds, time)
+);
+
+CREATE TRIGGER ohlc_rollup_updated_at AFTER UPDATE ON ohlc_rollup
+FOR EACH ROW BEGIN
+    UPDATE ohlc_rollup SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
\ No newline at end of file
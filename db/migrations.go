@@ -0,0 +1,17 @@
+// Package db holds the database migrations.
+package db
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migration/sqlite/*.sql
+var sqliteMigrations embed.FS
+
+// SQLiteMigrations returns the migrations of SQLite databases, which the server applies itself on startup since
+// an in-memory database cannot be migrated by another process.
+func SQLiteMigrations() fs.FS {
+	migrations, _ := fs.Sub(sqliteMigrations, "migration/sqlite")
+	return migrations
+}
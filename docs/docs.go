@@ -15,6 +15,249 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/cache": {
+            "get": {
+                "description": "The endpoint returns the hits and misses of the query cache since the server started, along with the failures of its stores, the reads and writes that fell back to the local store while Redis was unavailable, and the invalidations.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Returns the counters of the query cache",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_cache.Stats"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/jobs": {
+            "get": {
+                "description": "The endpoint lists the background jobs along with their schedule, whether they are running, the outcome of their last run and their next scheduled run.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Lists the background jobs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_scheduler.GetJobsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/jobs/{name}/run": {
+            "post": {
+                "description": "The endpoint runs a background job outside of its schedule. The job runs in the background, and cannot be triggered while it is running.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Triggers a run of a background job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"retention\"",
+                        "description": "This is the job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/internal_scheduler.JobState"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/anomalies": {
+            "get": {
+                "description": "The endpoint returns the flagged and quarantined OHLC points awaiting review",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the suspicious OHLC points detected during ingestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "BTC",
+                        "description": "This is the symbol of the OHLC token",
+                        "name": "symbol",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "FLAGGED",
+                            "QUARANTINED"
+                        ],
+                        "type": "string",
+                        "description": "Review status of the OHLC point",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "page of response",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Number of OHLC points per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.GetAnomaliesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/anomalies/{id}": {
+            "delete": {
+                "description": "The endpoint discards a suspicious OHLC point. A flagged point is also removed from the stored OHLC points.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deletes a suspicious OHLC point",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "ID of the suspicious OHLC point",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/anomalies/{id}/accept": {
+            "post": {
+                "description": "The endpoint accepts a suspicious OHLC point. A quarantined point is stored, a flagged point is kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Accepts a suspicious OHLC point",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "ID of the suspicious OHLC point",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/data": {
             "get": {
                 "description": "The endpoint returns the OHLC points for a particular Symbol for  the given time range",
@@ -59,6 +302,25 @@ const docTemplate = `{
                         "description": "Number of OHLC datapoints per page",
                         "name": "page_size",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1h",
+                        "description": "Resample the OHLC datapoints into candles of this interval",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "previous",
+                            "null",
+                            "linear"
+                        ],
+                        "type": "string",
+                        "description": "How missing candles are synthesised when an interval is set",
+                        "name": "fill",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -83,7 +345,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "The endpoint takes a small CSV file upload and processes it. Max file size is 30MB.",
+                "description": "The endpoint takes a CSV file upload and processes it. The maximum request size is configurable and defaults to 30MB. A file processed synchronously is responded with the number of rows inserted and rejected. Files uploaded with async=true, or above a configurable threshold of 10MB by default, are handed over to the S3 pipeline and processed asynchronously, in which case the endpoint responds with 202 Accepted and the filename to track the processing with through GET /status. The uploader and callback_url fields must precede the file in the form.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -92,6 +354,24 @@ const docTemplate = `{
                 ],
                 "summary": "Takes a CSV file upload and processes it",
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Whether to process the file asynchronously",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who uploads the file",
+                        "name": "uploader",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URL notified with a signed JSON payload once the file is processed",
+                        "name": "callback_url",
+                        "in": "formData"
+                    },
                     {
                         "type": "file",
                         "description": "CSV file to be processed",
@@ -102,7 +382,16 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.UploadResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.UploadResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -110,6 +399,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
                         }
                     },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -123,6 +418,22 @@ const docTemplate = `{
             "get": {
                 "description": "The endpoint generates a pre-signed URL for the given file name for uploading on S3, It supports huge files",
                 "summary": "Generates a pre-signed URL for the given file name for uploading on S3",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ops-team",
+                        "description": "Who uploads the file",
+                        "name": "uploader",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "https://etl.example.com/hooks/candles",
+                        "description": "URL notified with a signed JSON payload once the file is processed",
+                        "name": "callback_url",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -143,30 +454,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/status/{filename}": {
-            "get": {
-                "description": "The endpoint returns the status of the file processing",
+            },
+            "post": {
+                "description": "The endpoint generates a pre-signed URL for uploading a file on S3. The expected size, checksums and tags are enforced by S3, the returned headers having to be sent with the upload. The format and column mapping are stored with the file and applied when it is processed.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "returns the status of the file processing",
+                "summary": "Generates a pre-signed URL with upload constraints and processing options for uploading on S3",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv\"",
-                        "description": "This is the filename",
-                        "name": "filename",
-                        "in": "path",
-                        "required": true
+                        "description": "Upload constraints and processing options",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.GeneratePresignedURLRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.ProcessingStatusEntity"
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.GeneratePresignedURLResponse"
                         }
                     },
                     "400": {
@@ -183,20 +496,972 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_teezzan_candles_internal_controller_ohlc_data.GeneratePresignedURLResponse": {
-            "type": "object",
-            "properties": {
+        },
+        "/quality": {
+            "get": {
+                "description": "The endpoint returns the persisted data quality reports of a symbol for the days in the given time range",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the data quality reports of a symbol for the given time range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "BTC",
+                        "description": "This is the symbol of the OHLC token",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "10344553332",
+                        "description": "UNIX time representation of the start time",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "101019283847",
+                        "description": "UNIX time representation of the end time",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.GetDataQualityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The endpoint scans the stored OHLC points of a symbol for the expected interval and reports, per day, the missing buckets, duplicate timestamps and coverage percentage. At most 366 days are scanned at once. The reports are persisted.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Scans the OHLC points of a symbol and reports the data completeness per day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "BTC",
+                        "description": "This is the symbol of the OHLC token",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "1m",
+                        "description": "Expected interval between OHLC points",
+                        "name": "interval",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "10344553332",
+                        "description": "UNIX time representation of the start time",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "101019283847",
+                        "description": "UNIX time representation of the end time",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.GetDataQualityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rejected_messages": {
+            "get": {
+                "description": "The endpoint returns the SQS messages not notifying an upload to the bucket, most recent first. A message is rejected if it does not carry an S3 event, or if any of its records is not an object creation in the bucket matching the upload key filter.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the SQS messages rejected by the consumer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "page of response",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Number of messages per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.GetRejectedMessagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/status/{filename}": {
+            "get": {
+                "description": "The endpoint returns the status of the file processing",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the status of the file processing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv\"",
+                        "description": "This is the filename",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.ProcessingStatusEntity"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads": {
+            "get": {
+                "description": "The endpoint lists the uploaded files and their processing status, optionally filtered by status, uploader, symbol touched and creation time range",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the uploaded files and their processing status",
+                "parameters": [
+                    {
+                        "enum": [
+                            "AWAITING_UPLOAD",
+                            "QUEUED",
+                            "IN_PROGRESS",
+                            "RETRYING",
+                            "COMPLETED",
+                            "FAILED",
+                            "CANCELLED",
+                            "ROLLED_BACK"
+                        ],
+                        "type": "string",
+                        "description": "Processing status of the files",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "ops-team",
+                        "description": "Who uploaded the files",
+                        "name": "uploader",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "BTC",
+                        "description": "Symbol touched by the files",
+                        "name": "symbol",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "10344553332",
+                        "description": "UNIX time representation of the start time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "101019283847",
+                        "description": "UNIX time representation of the end time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at",
+                            "file_name",
+                            "status"
+                        ],
+                        "type": "string",
+                        "description": "Field the files are sorted by",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "page of response",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Number of files per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.GetUploadsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/events": {
+            "get": {
+                "description": "The endpoint streams the status transitions and progress of the uploaded files, optionally of a single uploader, as Server-Sent Events.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "Streams the processing status of the uploaded files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ops-team",
+                        "description": "Who uploaded the files",
+                        "name": "uploader",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.ProcessingStatusEntity"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/multipart": {
+            "post": {
+                "description": "The endpoint initiates a multipart upload on S3 for files larger than a single presigned URL allows, and returns the presigned URLs of its first parts. Each part but the last must be at least 5MB and the ETag returned by S3 for each part must be kept to complete the upload.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Initiates a multipart upload on S3",
+                "parameters": [
+                    {
+                        "description": "Number of parts, upload constraints and processing options",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.InitiateMultipartUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.MultipartUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{filename}/cancel": {
+            "post": {
+                "description": "The endpoint cancels the processing of a queued or in-flight file. The file is marked as CANCELLED once its processing stops.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Cancels the processing of an uploaded file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv\"",
+                        "description": "This is the filename",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{filename}/events": {
+            "get": {
+                "description": "The endpoint streams the status transitions and progress of an uploaded file as Server-Sent Events. The current status is sent first and the stream closes once the file reaches a terminal status.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "Streams the processing status of an uploaded file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv\"",
+                        "description": "This is the filename",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.ProcessingStatusEntity"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{filename}/multipart": {
+            "delete": {
+                "description": "The endpoint aborts a multipart upload, discarding its uploaded parts. The file is marked as CANCELLED.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Aborts a multipart upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv\"",
+                        "description": "This is the filename",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the multipart upload",
+                        "name": "upload_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{filename}/multipart/complete": {
+            "post": {
+                "description": "The endpoint assembles the uploaded parts of a multipart upload into the file, which is then processed like any file uploaded on S3",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Completes a multipart upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv\"",
+                        "description": "This is the filename",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upload ID and uploaded parts",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.CompleteMultipartUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{filename}/multipart/parts": {
+            "post": {
+                "description": "The endpoint returns the presigned URLs of the requested parts of a multipart upload, to resume an interrupted upload or upload more parts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Returns the presigned URLs of parts of a multipart upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv\"",
+                        "description": "This is the filename",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upload ID and part numbers",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.GetPartURLsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.MultipartUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{filename}/retry": {
+            "post": {
+                "description": "The endpoint re-runs the processing of a failed, cancelled or rolled back file still stored on S3. The file is processed in the background with the RETRYING status.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Re-runs the processing of a file uploaded on S3",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv\"",
+                        "description": "This is the filename",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{filename}/rollback": {
+            "post": {
+                "description": "The endpoint deletes all OHLC points inserted from an uploaded file and marks its processing status as ROLLED_BACK. In dry-run mode, it only returns the number of OHLC points that would be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deletes all OHLC points originating from an uploaded file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv\"",
+                        "description": "This is the filename",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Only count the OHLC points that would be deleted",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.RollbackUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{filename}/webhooks": {
+            "get": {
+                "description": "The endpoint returns every attempt to deliver the webhooks of an uploaded file, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the webhook delivery log of an uploaded file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv\"",
+                        "description": "This is the filename",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.GetWebhookDeliveriesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_teezzan_candles_internal_httputil.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_teezzan_candles_internal_controller_ohlc_data.AnomalyEntity": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "open": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "source_file": {
+                    "$ref": "#/definitions/github_com_teezzan_candles_internal_null.String"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.ColumnMapping": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.CompleteMultipartUploadRequest": {
+            "type": "object",
+            "properties": {
+                "parts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.CompletedPart"
+                    }
+                },
+                "upload_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.CompletedPart": {
+            "type": "object",
+            "properties": {
+                "etag": {
+                    "type": "string"
+                },
+                "part_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.DataQualityEntity": {
+            "type": "object",
+            "properties": {
+                "coverage": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "day": {
+                    "type": "string"
+                },
+                "duplicate_count": {
+                    "type": "integer"
+                },
+                "expected_count": {
+                    "type": "integer"
+                },
+                "gaps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.Gap"
+                    }
+                },
+                "interval_seconds": {
+                    "type": "integer"
+                },
+                "missing_count": {
+                    "type": "integer"
+                },
+                "present_count": {
+                    "type": "integer"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.Gap": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.GeneratePresignedURLRequest": {
+            "type": "object",
+            "properties": {
+                "callback_url": {
+                    "type": "string"
+                },
+                "column_mapping": {
+                    "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.ColumnMapping"
+                },
+                "content_md5": {
+                    "type": "string"
+                },
+                "content_sha256": {
+                    "type": "string"
+                },
+                "expected_size": {
+                    "type": "integer"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "csv",
+                        "csv.gz"
+                    ]
+                },
+                "tags": {
+                    "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.Tags"
+                },
+                "uploader": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.GeneratePresignedURLResponse": {
+            "type": "object",
+            "properties": {
                 "filename": {
                     "type": "string"
                 },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "url": {
                     "type": "string"
                 }
             }
         },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.GetAnomaliesResponse": {
+            "type": "object",
+            "properties": {
+                "anomalies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.AnomalyEntity"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.GetDataQualityResponse": {
+            "type": "object",
+            "properties": {
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.DataQualityEntity"
+                    }
+                }
+            }
+        },
         "github_com_teezzan_candles_internal_controller_ohlc_data.GetOHLCResponse": {
             "type": "object",
             "properties": {
@@ -211,6 +1476,107 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.GetPartURLsRequest": {
+            "type": "object",
+            "properties": {
+                "part_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "upload_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.GetRejectedMessagesResponse": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.RejectedMessageEntity"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.GetUploadsResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "uploads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.ProcessingStatusEntity"
+                    }
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.GetWebhookDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.WebhookDeliveryEntity"
+                    }
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.InitiateMultipartUploadRequest": {
+            "type": "object",
+            "properties": {
+                "callback_url": {
+                    "type": "string"
+                },
+                "column_mapping": {
+                    "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.ColumnMapping"
+                },
+                "expected_size": {
+                    "type": "integer"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "csv",
+                        "csv.gz"
+                    ]
+                },
+                "part_count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "tags": {
+                    "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.Tags"
+                },
+                "uploader": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.MultipartUploadResponse": {
+            "type": "object",
+            "properties": {
+                "filename": {
+                    "type": "string"
+                },
+                "parts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.PartURL"
+                    }
+                },
+                "upload_id": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_teezzan_candles_internal_controller_ohlc_data.OHLC": {
             "type": "object",
             "properties": {
@@ -229,28 +1595,173 @@ const docTemplate = `{
                 "symbol": {
                     "type": "string"
                 },
+                "synthetic": {
+                    "type": "boolean"
+                },
                 "unix": {
                     "type": "integer"
                 }
             }
         },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.PartURL": {
+            "type": "object",
+            "properties": {
+                "part_number": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_teezzan_candles_internal_controller_ohlc_data.ProcessingStatusEntity": {
             "type": "object",
             "properties": {
+                "bytes_read": {
+                    "type": "integer"
+                },
+                "bytes_total": {
+                    "type": "integer"
+                },
+                "callback_url": {
+                    "$ref": "#/definitions/github_com_teezzan_candles_internal_null.String"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "error": {
                     "$ref": "#/definitions/github_com_teezzan_candles_internal_null.String"
                 },
+                "estimated_completion": {
+                    "type": "string"
+                },
                 "file_name": {
                     "type": "string"
                 },
+                "finished_at": {
+                    "type": "string"
+                },
+                "range_end": {
+                    "type": "string"
+                },
+                "range_start": {
+                    "type": "string"
+                },
+                "rows_inserted": {
+                    "type": "integer"
+                },
+                "rows_parsed": {
+                    "type": "integer"
+                },
+                "rows_rejected": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
                 },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "$ref": "#/definitions/github_com_teezzan_candles_internal_controller_ohlc_data.Tags"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "uploader": {
+                    "$ref": "#/definitions/github_com_teezzan_candles_internal_null.String"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.RejectedMessageEntity": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.RollbackUploadResponse": {
+            "type": "object",
+            "properties": {
+                "affected_rows": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "filename": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.Tags": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.UploadResponse": {
+            "type": "object",
+            "properties": {
+                "filename": {
+                    "type": "string"
+                },
+                "rows_inserted": {
+                    "type": "integer"
+                },
+                "rows_rejected": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_teezzan_candles_internal_controller_ohlc_data.WebhookDeliveryEntity": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_teezzan_candles_internal_null.String"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "$ref": "#/definitions/github_com_teezzan_candles_internal_null.Int"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
@@ -265,6 +1776,18 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_teezzan_candles_internal_null.Int": {
+            "type": "object",
+            "properties": {
+                "int64": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "description": "Valid is true if Int64 is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
         "github_com_teezzan_candles_internal_null.String": {
             "type": "object",
             "properties": {
@@ -276,6 +1799,77 @@ const docTemplate = `{
                     "type": "boolean"
                 }
             }
+        },
+        "internal_cache.Stats": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "integer"
+                },
+                "fallbacks": {
+                    "type": "integer"
+                },
+                "hit_ratio": {
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "invalidations": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "remote": {
+                    "description": "Remote is the state of the remote store: disabled, available or unavailable.",
+                    "type": "string",
+                    "example": "available"
+                }
+            }
+        },
+        "internal_scheduler.GetJobsResponse": {
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_scheduler.JobState"
+                    }
+                }
+            }
+        },
+        "internal_scheduler.JobState": {
+            "type": "object",
+            "properties": {
+                "last_error": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "last_run_duration_in_ms": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "skipped_runs": {
+                    "type": "integer"
+                },
+                "timeout_in_seconds": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
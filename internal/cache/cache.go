@@ -0,0 +1,204 @@
+// Package cache provides a cache of query results backed by Redis, with a local in-memory fallback.
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// invalidateTimeout is the timeout of an invalidation. Invalidations do not depend on the context of the write that
+// triggered them, so that a cancelled request does not leave stale entries behind.
+const invalidateTimeout = 5 * time.Second
+
+// remoteRetryDelay is the delay before the remote store is probed again once unavailable.
+const remoteRetryDelay = 5 * time.Second
+
+// ErrMiss is returned by a store when a key is not cached.
+var ErrMiss = errors.New("cache miss")
+
+// Store defines a key-value store whose entries are grouped by tag, so that the entries of a tag can be invalidated
+// at once.
+type Store interface {
+	Get(ctx context.Context, tag string, key string) ([]byte, error)
+	Set(ctx context.Context, tag string, key string, value []byte, ttl time.Duration) error
+	Invalidate(ctx context.Context, tag string) error
+}
+
+// Stats holds the counters of a cache since it was created.
+type Stats struct {
+	// Remote is the state of the remote store: disabled, available or unavailable.
+	Remote        string  `json:"remote" example:"available"`
+	Hits          int64   `json:"hits"`
+	Misses        int64   `json:"misses"`
+	HitRatio      float64 `json:"hit_ratio"`
+	Errors        int64   `json:"errors"`
+	Fallbacks     int64   `json:"fallbacks"`
+	Invalidations int64   `json:"invalidations"`
+}
+
+// Cache caches values in a remote store shared by every server, such as Redis, and falls back to a local store while
+// the remote one is unavailable. Without remote store, the local store is used. Failures are logged and counted but
+// never returned: a failing cache behaves as an empty one. While unavailable, the remote store is skipped, and a
+// single operation probes it once every `remoteRetryDelay`.
+//
+// The entries written to the local store while the remote one is unavailable are only invalidated by the writes of
+// the same server, and the invalidations missed by the remote store in the meantime are not replayed. Both are bounded
+// by the time to live of the entries.
+type Cache struct {
+	logger *zap.Logger
+	remote Store
+	local  Store
+	ttl    time.Duration
+	now    func() time.Time
+
+	remoteDown    atomic.Bool
+	remoteRetryAt atomic.Int64
+	hits          atomic.Int64
+	misses        atomic.Int64
+	errors        atomic.Int64
+	fallbacks     atomic.Int64
+	invalidations atomic.Int64
+}
+
+// New initializes a new cache whose entries expire after the given time to live. The remote store is optional.
+func New(
+	logger *zap.Logger,
+	remote Store,
+	local Store,
+	ttl time.Duration,
+) *Cache {
+	return &Cache{
+		logger: logger,
+		remote: remote,
+		local:  local,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Get returns the value of a key, and whether it was cached.
+func (c *Cache) Get(ctx context.Context, tag string, key string) ([]byte, bool) {
+	value, err := c.get(ctx, tag, key)
+	if err != nil {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	return value, true
+}
+
+// get reads a key from the remote store, or from the local one if the remote store is disabled or unavailable.
+func (c *Cache) get(ctx context.Context, tag string, key string) ([]byte, error) {
+	if c.useRemote() {
+		value, err := c.remote.Get(ctx, tag, key)
+		if !c.remoteFailed(ctx, err) {
+			return value, err
+		}
+	}
+	if c.remote != nil {
+		c.fallbacks.Add(1)
+	}
+	value, err := c.local.Get(ctx, tag, key)
+	if err != nil && !errors.Is(err, ErrMiss) {
+		c.errors.Add(1)
+		c.logger.Warn("local cache read failed", zap.Error(err))
+	}
+	return value, err
+}
+
+// Set caches the value of a key.
+func (c *Cache) Set(ctx context.Context, tag string, key string, value []byte) {
+	if c.useRemote() {
+		err := c.remote.Set(ctx, tag, key, value, c.ttl)
+		if !c.remoteFailed(ctx, err) {
+			return
+		}
+	}
+	if c.remote != nil {
+		c.fallbacks.Add(1)
+	}
+	err := c.local.Set(ctx, tag, key, value, c.ttl)
+	if err != nil {
+		c.errors.Add(1)
+		c.logger.Warn("local cache write failed", zap.Error(err))
+	}
+}
+
+// Invalidate removes the entries of the tags. The local entries are removed even when the remote store is available,
+// in case it becomes unavailable later on.
+func (c *Cache) Invalidate(tags ...string) {
+	ctx, cancel := context.WithTimeout(context.Background(), invalidateTimeout)
+	defer cancel()
+
+	for _, tag := range tags {
+		c.invalidations.Add(1)
+		err := c.local.Invalidate(ctx, tag)
+		if err != nil {
+			c.errors.Add(1)
+			c.logger.Warn("local cache invalidation failed", zap.String("tag", tag), zap.Error(err))
+		}
+		if c.useRemote() {
+			c.remoteFailed(ctx, c.remote.Invalidate(ctx, tag))
+		}
+	}
+}
+
+// useRemote reports whether an operation goes to the remote store: always while it is available, otherwise only the
+// first operation once `remoteRetryDelay` has elapsed since the last failure, to probe it.
+func (c *Cache) useRemote() bool {
+	if c.remote == nil {
+		return false
+	}
+	if !c.remoteDown.Load() {
+		return true
+	}
+	now := c.now().UnixNano()
+	retryAt := c.remoteRetryAt.Load()
+	return now >= retryAt && c.remoteRetryAt.CompareAndSwap(retryAt, now+int64(remoteRetryDelay))
+}
+
+// remoteFailed records the outcome of an operation of the remote store, and reports whether it failed.
+// A miss is not a failure. Only the changes of availability of the remote store are logged, and an operation failing
+// because its context is done does not make the remote store unavailable.
+func (c *Cache) remoteFailed(ctx context.Context, err error) bool {
+	if err != nil && !errors.Is(err, ErrMiss) {
+		c.errors.Add(1)
+		if ctx.Err() == nil {
+			c.remoteRetryAt.Store(c.now().Add(remoteRetryDelay).UnixNano())
+			if !c.remoteDown.Swap(true) {
+				c.logger.Warn("remote cache unavailable, falling back to the local cache", zap.Error(err))
+			}
+		}
+		return true
+	}
+	if c.remoteDown.Swap(false) {
+		c.logger.Info("remote cache available again")
+	}
+	return false
+}
+
+// Stats returns the counters of the cache.
+func (c *Cache) Stats() Stats {
+	stats := Stats{
+		Remote:        "disabled",
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Errors:        c.errors.Load(),
+		Fallbacks:     c.fallbacks.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+	if c.remote != nil {
+		stats.Remote = "available"
+		if c.remoteDown.Load() {
+			stats.Remote = "unavailable"
+		}
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/config"
+	"go.uber.org/zap"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore(3)
+	s.now = func() time.Time { return now }
+
+	require.NoError(t, s.Set(ctx, "BTC", "a", []byte("1"), time.Minute))
+	require.NoError(t, s.Set(ctx, "BTC", "b", []byte("2"), 2*time.Minute))
+	require.NoError(t, s.Set(ctx, "ETH", "a", []byte("3"), time.Minute))
+	value, err := s.Get(ctx, "BTC", "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+	_, err = s.Get(ctx, "BTC", "c")
+	assert.ErrorIs(t, err, ErrMiss)
+
+	// The entries of the other tags are kept.
+	require.NoError(t, s.Invalidate(ctx, "BTC"))
+	_, err = s.Get(ctx, "BTC", "a")
+	assert.ErrorIs(t, err, ErrMiss)
+	_, err = s.Get(ctx, "ETH", "a")
+	assert.NoError(t, err)
+
+	// Once full, the expired entries are evicted first.
+	require.NoError(t, s.Set(ctx, "BTC", "a", []byte("1"), 2*time.Minute))
+	require.NoError(t, s.Set(ctx, "BTC", "b", []byte("2"), 2*time.Minute))
+	now = now.Add(time.Minute)
+	_, err = s.Get(ctx, "ETH", "a")
+	assert.ErrorIs(t, err, ErrMiss)
+	require.NoError(t, s.Set(ctx, "BTC", "c", []byte("3"), time.Minute))
+	assert.Equal(t, 3, s.count)
+	for _, key := range []string{"a", "b", "c"} {
+		_, err = s.Get(ctx, "BTC", key)
+		assert.NoError(t, err, key)
+	}
+	require.NoError(t, s.Set(ctx, "BTC", "d", []byte("4"), time.Minute))
+	assert.Equal(t, 3, s.count)
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	s := NewRedisStore(config.CacheConfig{RedisAddress: server.Addr()})
+	defer s.Close()
+
+	require.NoError(t, s.Set(ctx, "BTC", "a", []byte("1"), time.Minute))
+	require.NoError(t, s.Set(ctx, "ETH", "a", []byte("2"), time.Minute))
+	value, err := s.Get(ctx, "BTC", "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	require.NoError(t, s.Invalidate(ctx, "BTC"))
+	_, err = s.Get(ctx, "BTC", "a")
+	assert.ErrorIs(t, err, ErrMiss)
+	_, err = s.Get(ctx, "ETH", "a")
+	assert.NoError(t, err)
+
+	require.NoError(t, s.Set(ctx, "BTC", "a", []byte("3"), time.Minute))
+	value, err = s.Get(ctx, "BTC", "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("3"), value)
+	server.FastForward(time.Minute)
+	_, err = s.Get(ctx, "BTC", "a")
+	assert.ErrorIs(t, err, ErrMiss)
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	remote := NewRedisStore(config.CacheConfig{RedisAddress: server.Addr()})
+	defer remote.Close()
+	local := NewMemoryStore(100)
+	c := New(zap.NewNop(), remote, local, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "BTC", "a", []byte("1"))
+	value, ok := c.Get(ctx, "BTC", "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+	_, err := local.Get(ctx, "BTC", "a")
+	assert.ErrorIs(t, err, ErrMiss, "the local store is only written while the remote one is unavailable")
+
+	// While Redis is unavailable, the local store is used without trying Redis.
+	server.Close()
+	_, ok = c.Get(ctx, "BTC", "a")
+	assert.False(t, ok)
+	c.Set(ctx, "BTC", "a", []byte("2"))
+	value, ok = c.Get(ctx, "BTC", "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("2"), value)
+	c.Invalidate("BTC")
+	_, ok = c.Get(ctx, "BTC", "a")
+	assert.False(t, ok)
+
+	stats := c.Stats()
+	assert.Equal(t, "unavailable", stats.Remote)
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, int64(2), stats.Misses)
+	assert.Equal(t, 0.5, stats.HitRatio)
+	assert.Equal(t, int64(4), stats.Fallbacks)
+	assert.Equal(t, int64(1), stats.Errors)
+	assert.Equal(t, int64(1), stats.Invalidations)
+
+	// Redis is probed again once the retry delay has elapsed.
+	now = now.Add(remoteRetryDelay)
+	_, ok = c.Get(ctx, "BTC", "a")
+	assert.False(t, ok)
+	assert.Equal(t, int64(2), c.Stats().Errors)
+	_, ok = c.Get(ctx, "BTC", "a")
+	assert.False(t, ok)
+	assert.Equal(t, int64(2), c.Stats().Errors, "Redis is not probed again before the retry delay has elapsed")
+
+	// Once Redis is available again, its entries are used, including the ones whose invalidation it missed.
+	require.NoError(t, server.Restart())
+	require.Eventually(t, func() bool {
+		now = now.Add(remoteRetryDelay)
+		value, ok = c.Get(ctx, "BTC", "a")
+		return ok
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, "available", c.Stats().Remote)
+
+	c = New(zap.NewNop(), nil, NewMemoryStore(100), time.Minute)
+	c.Set(ctx, "BTC", "a", []byte("1"))
+	_, ok = c.Get(ctx, "BTC", "a")
+	assert.True(t, ok)
+	assert.Equal(t, Stats{Remote: "disabled", Hits: 1, HitRatio: 1}, c.Stats())
+}
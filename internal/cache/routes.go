@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"github.com/gin-gonic/gin"
+	E "github.com/teezzan/candles/internal/errors"
+	"github.com/teezzan/candles/internal/httputil"
+	"go.uber.org/zap"
+)
+
+// HTTPHandler is the HTTP handler for the cache.
+type HTTPHandler struct {
+	logger *zap.Logger
+	cache  *Cache
+}
+
+// NewHTTPHandler initializes a new HTTP Handler. The cache is nil if caching is disabled.
+func NewHTTPHandler(
+	logger *zap.Logger,
+	cache *Cache,
+) *HTTPHandler {
+	return &HTTPHandler{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+// SetupRouter sets up the router for the cache.
+func (h *HTTPHandler) SetupRouter(r *gin.RouterGroup) error {
+	handler := httputil.NewHandlerWrapper(h.logger)
+
+	r.GET("/admin/cache", handler(h.getStatsHandler))
+
+	return nil
+}
+
+// getStatsHandler returns the counters of the cache.
+//
+//	@Summary		Returns the counters of the query cache
+//	@Description	The endpoint returns the hits and misses of the query cache since the server started, along with the failures of its stores, the reads and writes that fell back to the local store while Redis was unavailable, and the invalidations.
+//	@Produce		json
+//	@Success		200	{object}	cache.Stats
+//	@Failure		404	{object}	httputil.ErrorResponse
+//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Router			/admin/cache [get]
+func (h *HTTPHandler) getStatsHandler(c *gin.Context) error {
+	if h.cache == nil {
+		return E.NewErrNotFound("the cache is disabled")
+	}
+	return httputil.OK(c, h.cache.Stats())
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+// memoryEntry is a value cached in memory along with its expiry time.
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryStore implements a thread-safe store keeping its entries in memory, up to a maximum number of entries.
+// Once full, the expired entries are evicted first, then arbitrary ones.
+type MemoryStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	count      int
+	tags       map[string]map[string]memoryEntry
+	now        func() time.Time
+}
+
+// NewMemoryStore initializes a new empty memory store.
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		tags:       map[string]map[string]memoryEntry{},
+		now:        time.Now,
+	}
+}
+
+// Get returns the value of a key, or ErrMiss if it is not cached or expired.
+func (s *MemoryStore) Get(ctx context.Context, tag string, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.tags[tag][key]
+	if !ok || !s.now().Before(entry.expiresAt) {
+		return nil, ErrMiss
+	}
+	return entry.value, nil
+}
+
+// Set caches the value of a key until the time to live elapses.
+func (s *MemoryStore) Set(ctx context.Context, tag string, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.maxEntries <= 0 {
+		return nil
+	}
+	if _, ok := s.tags[tag][key]; !ok {
+		if s.count >= s.maxEntries {
+			s.evict()
+		}
+		s.count++
+	}
+	entries, ok := s.tags[tag]
+	if !ok {
+		entries = map[string]memoryEntry{}
+		s.tags[tag] = entries
+	}
+	entries[key] = memoryEntry{value: value, expiresAt: s.now().Add(ttl)}
+	return nil
+}
+
+// Invalidate removes the entries of a tag.
+func (s *MemoryStore) Invalidate(ctx context.Context, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.count -= len(s.tags[tag])
+	delete(s.tags, tag)
+	return nil
+}
+
+// evict removes the expired entries, or an arbitrary entry if none has expired.
+func (s *MemoryStore) evict() {
+	now := s.now()
+	for tag, entries := range s.tags {
+		for key, entry := range entries {
+			if !now.Before(entry.expiresAt) {
+				s.remove(tag, key)
+			}
+		}
+	}
+	if s.count < s.maxEntries {
+		return
+	}
+	for tag, entries := range s.tags {
+		for key := range entries {
+			s.remove(tag, key)
+			return
+		}
+	}
+}
+
+// remove removes an entry.
+func (s *MemoryStore) remove(tag string, key string) {
+	entries := s.tags[tag]
+	delete(entries, key)
+	if len(entries) == 0 {
+		delete(s.tags, tag)
+	}
+	s.count--
+}
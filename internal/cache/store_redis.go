@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/teezzan/candles/internal/config"
+)
+
+// redisKeyPrefix is the prefix of the keys of a Redis store.
+const redisKeyPrefix = "candles:cache:"
+
+var _ Store = (*RedisStore)(nil)
+
+// RedisStore implements a store backed by Redis. Every tag has a version, part of the keys of its entries, so that
+// invalidating a tag only takes incrementing its version; the entries of the previous versions are left to expire.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore initializes a new Redis store. The connection is established lazily.
+func NewRedisStore(conf config.CacheConfig) *RedisStore {
+	return &RedisStore{
+		client: redis.NewClient(&redis.Options{
+			Addr:     conf.RedisAddress,
+			Password: conf.RedisPassword,
+			DB:       conf.RedisDB,
+		}),
+	}
+}
+
+// Close closes the connections to Redis.
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
+
+// Get returns the value of a key, or ErrMiss if it is not cached.
+func (s *RedisStore) Get(ctx context.Context, tag string, key string) ([]byte, error) {
+	version, err := s.version(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.client.Get(ctx, entryKey(tag, version, key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return value, err
+}
+
+// Set caches the value of a key until the time to live elapses.
+func (s *RedisStore) Set(ctx context.Context, tag string, key string, value []byte, ttl time.Duration) error {
+	version, err := s.version(ctx, tag)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, entryKey(tag, version, key), value, ttl).Err()
+}
+
+// Invalidate removes the entries of a tag by incrementing its version.
+func (s *RedisStore) Invalidate(ctx context.Context, tag string) error {
+	return s.client.Incr(ctx, versionKey(tag)).Err()
+}
+
+// version returns the current version of a tag, zero if it was never invalidated.
+func (s *RedisStore) version(ctx context.Context, tag string) (int64, error) {
+	version, err := s.client.Get(ctx, versionKey(tag)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return version, err
+}
+
+// versionKey returns the key of the version of a tag. The tag is enclosed in braces so that it cannot be confused with
+// the version of an entry key.
+func versionKey(tag string) string {
+	return redisKeyPrefix + "{" + tag + "}:version"
+}
+
+// entryKey returns the key of an entry of a version of a tag.
+func entryKey(tag string, version int64, key string) string {
+	return fmt.Sprintf("%s{%s}:%d:%s", redisKeyPrefix, tag, version, key)
+}
@@ -1,13 +1,63 @@
 // Package s3 provides an S3 clients.
 package s3
 
-import "context"
+import (
+	"context"
+	"io"
+	"time"
+)
 
 //go:generate moq -rm -out client_mock.go . Client
 
 // Client defines the AWS S3 client interface.
 type Client interface {
 	ListBuckets(ctx context.Context) error
-	GeneratePresignedURL(ctx context.Context, key string) (string, error)
+	Bucket() string
+	GeneratePresignedURL(ctx context.Context, key string, conditions UploadConditions) (*PresignedURL, error)
 	DownloadLargeObject(ctx context.Context, objectKey string) ([]byte, error)
+	OpenObject(ctx context.Context, objectKey string) (*Object, error)
+	UploadObject(ctx context.Context, key string, body io.Reader, contentType string, metadata map[string]string) error
+	CreateMultipartUpload(ctx context.Context, key string, conditions UploadConditions) (string, error)
+	GeneratePresignedPartURL(ctx context.Context, key string, uploadID string, partNumber int32) (string, error)
+	CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []CompletedPart) error
+	AbortMultipartUpload(ctx context.Context, key string, uploadID string) error
+	ListMultipartUploads(ctx context.Context) ([]MultipartUpload, error)
+}
+
+// UploadConditions defines the conditions a presigned upload must meet. The zero value allows any upload.
+// Every non-empty condition is signed, so the upload must send the matching header.
+type UploadConditions struct {
+	ContentType    string
+	ContentLength  int64
+	ContentMD5     string
+	ChecksumSHA256 string
+	Metadata       map[string]string
+	Tags           map[string]string
+}
+
+// PresignedURL defines a presigned upload URL along with the headers the upload must send.
+type PresignedURL struct {
+	URL     string
+	Headers map[string]string
+}
+
+// Object defines an object opened for streaming.
+type Object struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+	Metadata    map[string]string
+}
+
+// CompletedPart defines an uploaded part of a multipart upload.
+type CompletedPart struct {
+	PartNumber int32
+	ETag       string
+}
+
+// MultipartUpload defines an incomplete multipart upload.
+type MultipartUpload struct {
+	Key       string
+	UploadID  string
+	Initiated time.Time
 }
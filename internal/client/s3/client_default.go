@@ -3,11 +3,15 @@ package s3
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/url"
+	"strings"
 	"time"
 
 	s3Config "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/teezzan/candles/internal/config"
 
@@ -53,6 +57,11 @@ func NewClient(
 	}, nil
 }
 
+// Bucket returns the name of the bucket the files are uploaded to.
+func (c *DefaultClient) Bucket() string {
+	return c.bucketName
+}
+
 // ListBuckets lists all the available buckets in the S3 client
 // It prints the name of each bucket to the standard output.
 func (c *DefaultClient) ListBuckets(ctx context.Context) error {
@@ -67,19 +76,46 @@ func (c *DefaultClient) ListBuckets(ctx context.Context) error {
 }
 
 // GeneratePresignedURL returns a presigned URL for the provided object key in the specified bucket.
+// The upload conditions are signed along with the URL, and the metadata and tags are stored with the object.
+// The headers signed along with the URL are returned so that they can be sent with the upload.
 // The URL will expire after the time specified in the `presignURLExpiryTime` field.
-func (c *DefaultClient) GeneratePresignedURL(ctx context.Context, key string) (string, error) {
-	req, err := c.presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
-		Bucket: aws.String(c.bucketName),
-		Key:    aws.String(key),
-	}, func(opts *s3.PresignOptions) {
+func (c *DefaultClient) GeneratePresignedURL(ctx context.Context, key string, conditions UploadConditions) (*PresignedURL, error) {
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(c.bucketName),
+		Key:           aws.String(key),
+		ContentLength: conditions.ContentLength,
+		Metadata:      conditions.Metadata,
+	}
+	if conditions.ContentType != "" {
+		input.ContentType = aws.String(conditions.ContentType)
+	}
+	if conditions.ContentMD5 != "" {
+		input.ContentMD5 = aws.String(conditions.ContentMD5)
+	}
+	if conditions.ChecksumSHA256 != "" {
+		input.ChecksumSHA256 = aws.String(conditions.ChecksumSHA256)
+	}
+	input.Tagging = tagging(conditions.Tags)
+
+	req, err := c.presignClient.PresignPutObject(ctx, input, func(opts *s3.PresignOptions) {
 		opts.Expires = time.Duration(int64(c.presignURLExpiryTime) * int64(time.Second))
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return req.URL, nil
+
+	headers := map[string]string{}
+	for name := range req.SignedHeader {
+		if strings.EqualFold(name, "Host") {
+			continue
+		}
+		headers[name] = req.SignedHeader.Get(name)
+	}
+	return &PresignedURL{
+		URL:     req.URL,
+		Headers: headers,
+	}, nil
 }
 
 // DownloadLargeObject downloads a large object from an Amazon S3 bucket. It downloads the object
@@ -100,3 +136,139 @@ func (c *DefaultClient) DownloadLargeObject(ctx context.Context, objectKey strin
 	}
 	return buffer.Bytes(), err
 }
+
+// OpenObject opens an object for streaming. It returns the object body, which must be closed by the caller,
+// along with the object size in bytes, content type and metadata.
+func (c *DefaultClient) OpenObject(ctx context.Context, objectKey string) (*Object, error) {
+	out, err := c.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucketName),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Object{
+		Body:        out.Body,
+		Size:        out.ContentLength,
+		ContentType: aws.StringValue(out.ContentType),
+		Metadata:    out.Metadata,
+	}, nil
+}
+
+// UploadObject streams an object of unknown size to the specified bucket, uploading it in parts if it is large.
+func (c *DefaultClient) UploadObject(ctx context.Context, key string, body io.Reader, contentType string, metadata map[string]string) error {
+	uploader := manager.NewUploader(c.s3Client)
+	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(c.bucketName),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+		Metadata:    metadata,
+	})
+	return err
+}
+
+// CreateMultipartUpload initiates a multipart upload of the provided object key in the specified bucket and returns its ID.
+// Only the content type, metadata and tags of the upload conditions apply to a multipart upload.
+func (c *DefaultClient) CreateMultipartUpload(ctx context.Context, key string, conditions UploadConditions) (string, error) {
+	input := &s3.CreateMultipartUploadInput{
+		Bucket:   aws.String(c.bucketName),
+		Key:      aws.String(key),
+		Metadata: conditions.Metadata,
+		Tagging:  tagging(conditions.Tags),
+	}
+	if conditions.ContentType != "" {
+		input.ContentType = aws.String(conditions.ContentType)
+	}
+
+	out, err := c.s3Client.CreateMultipartUpload(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(out.UploadId), nil
+}
+
+// GeneratePresignedPartURL returns a presigned URL for uploading a part of a multipart upload.
+// The URL will expire after the time specified in the `presignURLExpiryTime` field.
+func (c *DefaultClient) GeneratePresignedPartURL(ctx context.Context, key string, uploadID string, partNumber int32) (string, error) {
+	req, err := c.presignClient.PresignUploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(c.bucketName),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: partNumber,
+	}, func(opts *s3.PresignOptions) {
+		opts.Expires = time.Duration(int64(c.presignURLExpiryTime) * int64(time.Second))
+	})
+	if err != nil {
+		return "", err
+	}
+	return req.URL, nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts of a multipart upload into the object.
+func (c *DefaultClient) CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []CompletedPart) error {
+	completed := make([]types.CompletedPart, 0, len(parts))
+	for _, part := range parts {
+		completed = append(completed, types.CompletedPart{
+			PartNumber: part.PartNumber,
+			ETag:       aws.String(part.ETag),
+		})
+	}
+	_, err := c.s3Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(c.bucketName),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	return err
+}
+
+// AbortMultipartUpload aborts a multipart upload, S3 freeing the storage of its uploaded parts.
+func (c *DefaultClient) AbortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	_, err := c.s3Client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(c.bucketName),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	return err
+}
+
+// ListMultipartUploads lists the incomplete multipart uploads of the specified bucket.
+func (c *DefaultClient) ListMultipartUploads(ctx context.Context) ([]MultipartUpload, error) {
+	var (
+		uploads []MultipartUpload
+		input   = &s3.ListMultipartUploadsInput{
+			Bucket: aws.String(c.bucketName),
+		}
+	)
+	for {
+		out, err := c.s3Client.ListMultipartUploads(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, upload := range out.Uploads {
+			uploads = append(uploads, MultipartUpload{
+				Key:       aws.StringValue(upload.Key),
+				UploadID:  aws.StringValue(upload.UploadId),
+				Initiated: aws.TimeValue(upload.Initiated),
+			})
+		}
+		if !out.IsTruncated {
+			return uploads, nil
+		}
+		input.KeyMarker = out.NextKeyMarker
+		input.UploadIdMarker = out.NextUploadIdMarker
+	}
+}
+
+// tagging encodes tags as the query string S3 expects, or returns nil if there are no tags.
+func tagging(tags map[string]string) *string {
+	if len(tags) == 0 {
+		return nil
+	}
+	values := url.Values{}
+	for k, v := range tags {
+		values.Set(k, v)
+	}
+	return aws.String(values.Encode())
+}
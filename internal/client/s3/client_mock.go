@@ -5,6 +5,7 @@ package s3
 
 import (
 	"context"
+	"io"
 	"sync"
 )
 
@@ -18,15 +19,39 @@ var _ Client = &ClientMock{}
 //
 //		// make and configure a mocked Client
 //		mockedClient := &ClientMock{
+//			AbortMultipartUploadFunc: func(ctx context.Context, key string, uploadID string) error {
+//				panic("mock out the AbortMultipartUpload method")
+//			},
+//			BucketFunc: func() string {
+//				panic("mock out the Bucket method")
+//			},
+//			CompleteMultipartUploadFunc: func(ctx context.Context, key string, uploadID string, parts []CompletedPart) error {
+//				panic("mock out the CompleteMultipartUpload method")
+//			},
+//			CreateMultipartUploadFunc: func(ctx context.Context, key string, conditions UploadConditions) (string, error) {
+//				panic("mock out the CreateMultipartUpload method")
+//			},
 //			DownloadLargeObjectFunc: func(ctx context.Context, objectKey string) ([]byte, error) {
 //				panic("mock out the DownloadLargeObject method")
 //			},
-//			GeneratePresignedURLFunc: func(ctx context.Context, key string) (string, error) {
+//			GeneratePresignedPartURLFunc: func(ctx context.Context, key string, uploadID string, partNumber int32) (string, error) {
+//				panic("mock out the GeneratePresignedPartURL method")
+//			},
+//			GeneratePresignedURLFunc: func(ctx context.Context, key string, conditions UploadConditions) (*PresignedURL, error) {
 //				panic("mock out the GeneratePresignedURL method")
 //			},
 //			ListBucketsFunc: func(ctx context.Context) error {
 //				panic("mock out the ListBuckets method")
 //			},
+//			ListMultipartUploadsFunc: func(ctx context.Context) ([]MultipartUpload, error) {
+//				panic("mock out the ListMultipartUploads method")
+//			},
+//			OpenObjectFunc: func(ctx context.Context, objectKey string) (*Object, error) {
+//				panic("mock out the OpenObject method")
+//			},
+//			UploadObjectFunc: func(ctx context.Context, key string, body io.Reader, contentType string, metadata map[string]string) error {
+//				panic("mock out the UploadObject method")
+//			},
 //		}
 //
 //		// use mockedClient in code that requires Client
@@ -34,17 +59,73 @@ var _ Client = &ClientMock{}
 //
 //	}
 type ClientMock struct {
+	// AbortMultipartUploadFunc mocks the AbortMultipartUpload method.
+	AbortMultipartUploadFunc func(ctx context.Context, key string, uploadID string) error
+
+	// BucketFunc mocks the Bucket method.
+	BucketFunc func() string
+
+	// CompleteMultipartUploadFunc mocks the CompleteMultipartUpload method.
+	CompleteMultipartUploadFunc func(ctx context.Context, key string, uploadID string, parts []CompletedPart) error
+
+	// CreateMultipartUploadFunc mocks the CreateMultipartUpload method.
+	CreateMultipartUploadFunc func(ctx context.Context, key string, conditions UploadConditions) (string, error)
+
 	// DownloadLargeObjectFunc mocks the DownloadLargeObject method.
 	DownloadLargeObjectFunc func(ctx context.Context, objectKey string) ([]byte, error)
 
+	// GeneratePresignedPartURLFunc mocks the GeneratePresignedPartURL method.
+	GeneratePresignedPartURLFunc func(ctx context.Context, key string, uploadID string, partNumber int32) (string, error)
+
 	// GeneratePresignedURLFunc mocks the GeneratePresignedURL method.
-	GeneratePresignedURLFunc func(ctx context.Context, key string) (string, error)
+	GeneratePresignedURLFunc func(ctx context.Context, key string, conditions UploadConditions) (*PresignedURL, error)
 
 	// ListBucketsFunc mocks the ListBuckets method.
 	ListBucketsFunc func(ctx context.Context) error
 
+	// ListMultipartUploadsFunc mocks the ListMultipartUploads method.
+	ListMultipartUploadsFunc func(ctx context.Context) ([]MultipartUpload, error)
+
+	// OpenObjectFunc mocks the OpenObject method.
+	OpenObjectFunc func(ctx context.Context, objectKey string) (*Object, error)
+
+	// UploadObjectFunc mocks the UploadObject method.
+	UploadObjectFunc func(ctx context.Context, key string, body io.Reader, contentType string, metadata map[string]string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AbortMultipartUpload holds details about calls to the AbortMultipartUpload method.
+		AbortMultipartUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// UploadID is the uploadID argument value.
+			UploadID string
+		}
+		// Bucket holds details about calls to the Bucket method.
+		Bucket []struct {
+		}
+		// CompleteMultipartUpload holds details about calls to the CompleteMultipartUpload method.
+		CompleteMultipartUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// UploadID is the uploadID argument value.
+			UploadID string
+			// Parts is the parts argument value.
+			Parts []CompletedPart
+		}
+		// CreateMultipartUpload holds details about calls to the CreateMultipartUpload method.
+		CreateMultipartUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Conditions is the conditions argument value.
+			Conditions UploadConditions
+		}
 		// DownloadLargeObject holds details about calls to the DownloadLargeObject method.
 		DownloadLargeObject []struct {
 			// Ctx is the ctx argument value.
@@ -52,22 +133,219 @@ type ClientMock struct {
 			// ObjectKey is the objectKey argument value.
 			ObjectKey string
 		}
+		// GeneratePresignedPartURL holds details about calls to the GeneratePresignedPartURL method.
+		GeneratePresignedPartURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// UploadID is the uploadID argument value.
+			UploadID string
+			// PartNumber is the partNumber argument value.
+			PartNumber int32
+		}
 		// GeneratePresignedURL holds details about calls to the GeneratePresignedURL method.
 		GeneratePresignedURL []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Key is the key argument value.
 			Key string
+			// Conditions is the conditions argument value.
+			Conditions UploadConditions
 		}
 		// ListBuckets holds details about calls to the ListBuckets method.
 		ListBuckets []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// ListMultipartUploads holds details about calls to the ListMultipartUploads method.
+		ListMultipartUploads []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// OpenObject holds details about calls to the OpenObject method.
+		OpenObject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ObjectKey is the objectKey argument value.
+			ObjectKey string
+		}
+		// UploadObject holds details about calls to the UploadObject method.
+		UploadObject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Body is the body argument value.
+			Body io.Reader
+			// ContentType is the contentType argument value.
+			ContentType string
+			// Metadata is the metadata argument value.
+			Metadata map[string]string
+		}
+	}
+	lockAbortMultipartUpload     sync.RWMutex
+	lockBucket                   sync.RWMutex
+	lockCompleteMultipartUpload  sync.RWMutex
+	lockCreateMultipartUpload    sync.RWMutex
+	lockDownloadLargeObject      sync.RWMutex
+	lockGeneratePresignedPartURL sync.RWMutex
+	lockGeneratePresignedURL     sync.RWMutex
+	lockListBuckets              sync.RWMutex
+	lockListMultipartUploads     sync.RWMutex
+	lockOpenObject               sync.RWMutex
+	lockUploadObject             sync.RWMutex
+}
+
+// AbortMultipartUpload calls AbortMultipartUploadFunc.
+func (mock *ClientMock) AbortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	if mock.AbortMultipartUploadFunc == nil {
+		panic("ClientMock.AbortMultipartUploadFunc: method is nil but Client.AbortMultipartUpload was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Key      string
+		UploadID string
+	}{
+		Ctx:      ctx,
+		Key:      key,
+		UploadID: uploadID,
+	}
+	mock.lockAbortMultipartUpload.Lock()
+	mock.calls.AbortMultipartUpload = append(mock.calls.AbortMultipartUpload, callInfo)
+	mock.lockAbortMultipartUpload.Unlock()
+	return mock.AbortMultipartUploadFunc(ctx, key, uploadID)
+}
+
+// AbortMultipartUploadCalls gets all the calls that were made to AbortMultipartUpload.
+// Check the length with:
+//
+//	len(mockedClient.AbortMultipartUploadCalls())
+func (mock *ClientMock) AbortMultipartUploadCalls() []struct {
+	Ctx      context.Context
+	Key      string
+	UploadID string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Key      string
+		UploadID string
+	}
+	mock.lockAbortMultipartUpload.RLock()
+	calls = mock.calls.AbortMultipartUpload
+	mock.lockAbortMultipartUpload.RUnlock()
+	return calls
+}
+
+// Bucket calls BucketFunc.
+func (mock *ClientMock) Bucket() string {
+	if mock.BucketFunc == nil {
+		panic("ClientMock.BucketFunc: method is nil but Client.Bucket was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockBucket.Lock()
+	mock.calls.Bucket = append(mock.calls.Bucket, callInfo)
+	mock.lockBucket.Unlock()
+	return mock.BucketFunc()
+}
+
+// BucketCalls gets all the calls that were made to Bucket.
+// Check the length with:
+//
+//	len(mockedClient.BucketCalls())
+func (mock *ClientMock) BucketCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockBucket.RLock()
+	calls = mock.calls.Bucket
+	mock.lockBucket.RUnlock()
+	return calls
+}
+
+// CompleteMultipartUpload calls CompleteMultipartUploadFunc.
+func (mock *ClientMock) CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []CompletedPart) error {
+	if mock.CompleteMultipartUploadFunc == nil {
+		panic("ClientMock.CompleteMultipartUploadFunc: method is nil but Client.CompleteMultipartUpload was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Key      string
+		UploadID string
+		Parts    []CompletedPart
+	}{
+		Ctx:      ctx,
+		Key:      key,
+		UploadID: uploadID,
+		Parts:    parts,
+	}
+	mock.lockCompleteMultipartUpload.Lock()
+	mock.calls.CompleteMultipartUpload = append(mock.calls.CompleteMultipartUpload, callInfo)
+	mock.lockCompleteMultipartUpload.Unlock()
+	return mock.CompleteMultipartUploadFunc(ctx, key, uploadID, parts)
+}
+
+// CompleteMultipartUploadCalls gets all the calls that were made to CompleteMultipartUpload.
+// Check the length with:
+//
+//	len(mockedClient.CompleteMultipartUploadCalls())
+func (mock *ClientMock) CompleteMultipartUploadCalls() []struct {
+	Ctx      context.Context
+	Key      string
+	UploadID string
+	Parts    []CompletedPart
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Key      string
+		UploadID string
+		Parts    []CompletedPart
+	}
+	mock.lockCompleteMultipartUpload.RLock()
+	calls = mock.calls.CompleteMultipartUpload
+	mock.lockCompleteMultipartUpload.RUnlock()
+	return calls
+}
+
+// CreateMultipartUpload calls CreateMultipartUploadFunc.
+func (mock *ClientMock) CreateMultipartUpload(ctx context.Context, key string, conditions UploadConditions) (string, error) {
+	if mock.CreateMultipartUploadFunc == nil {
+		panic("ClientMock.CreateMultipartUploadFunc: method is nil but Client.CreateMultipartUpload was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Key        string
+		Conditions UploadConditions
+	}{
+		Ctx:        ctx,
+		Key:        key,
+		Conditions: conditions,
+	}
+	mock.lockCreateMultipartUpload.Lock()
+	mock.calls.CreateMultipartUpload = append(mock.calls.CreateMultipartUpload, callInfo)
+	mock.lockCreateMultipartUpload.Unlock()
+	return mock.CreateMultipartUploadFunc(ctx, key, conditions)
+}
+
+// CreateMultipartUploadCalls gets all the calls that were made to CreateMultipartUpload.
+// Check the length with:
+//
+//	len(mockedClient.CreateMultipartUploadCalls())
+func (mock *ClientMock) CreateMultipartUploadCalls() []struct {
+	Ctx        context.Context
+	Key        string
+	Conditions UploadConditions
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Key        string
+		Conditions UploadConditions
 	}
-	lockDownloadLargeObject  sync.RWMutex
-	lockGeneratePresignedURL sync.RWMutex
-	lockListBuckets          sync.RWMutex
+	mock.lockCreateMultipartUpload.RLock()
+	calls = mock.calls.CreateMultipartUpload
+	mock.lockCreateMultipartUpload.RUnlock()
+	return calls
 }
 
 // DownloadLargeObject calls DownloadLargeObjectFunc.
@@ -106,22 +384,68 @@ func (mock *ClientMock) DownloadLargeObjectCalls() []struct {
 	return calls
 }
 
+// GeneratePresignedPartURL calls GeneratePresignedPartURLFunc.
+func (mock *ClientMock) GeneratePresignedPartURL(ctx context.Context, key string, uploadID string, partNumber int32) (string, error) {
+	if mock.GeneratePresignedPartURLFunc == nil {
+		panic("ClientMock.GeneratePresignedPartURLFunc: method is nil but Client.GeneratePresignedPartURL was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Key        string
+		UploadID   string
+		PartNumber int32
+	}{
+		Ctx:        ctx,
+		Key:        key,
+		UploadID:   uploadID,
+		PartNumber: partNumber,
+	}
+	mock.lockGeneratePresignedPartURL.Lock()
+	mock.calls.GeneratePresignedPartURL = append(mock.calls.GeneratePresignedPartURL, callInfo)
+	mock.lockGeneratePresignedPartURL.Unlock()
+	return mock.GeneratePresignedPartURLFunc(ctx, key, uploadID, partNumber)
+}
+
+// GeneratePresignedPartURLCalls gets all the calls that were made to GeneratePresignedPartURL.
+// Check the length with:
+//
+//	len(mockedClient.GeneratePresignedPartURLCalls())
+func (mock *ClientMock) GeneratePresignedPartURLCalls() []struct {
+	Ctx        context.Context
+	Key        string
+	UploadID   string
+	PartNumber int32
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Key        string
+		UploadID   string
+		PartNumber int32
+	}
+	mock.lockGeneratePresignedPartURL.RLock()
+	calls = mock.calls.GeneratePresignedPartURL
+	mock.lockGeneratePresignedPartURL.RUnlock()
+	return calls
+}
+
 // GeneratePresignedURL calls GeneratePresignedURLFunc.
-func (mock *ClientMock) GeneratePresignedURL(ctx context.Context, key string) (string, error) {
+func (mock *ClientMock) GeneratePresignedURL(ctx context.Context, key string, conditions UploadConditions) (*PresignedURL, error) {
 	if mock.GeneratePresignedURLFunc == nil {
 		panic("ClientMock.GeneratePresignedURLFunc: method is nil but Client.GeneratePresignedURL was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		Key string
+		Ctx        context.Context
+		Key        string
+		Conditions UploadConditions
 	}{
-		Ctx: ctx,
-		Key: key,
+		Ctx:        ctx,
+		Key:        key,
+		Conditions: conditions,
 	}
 	mock.lockGeneratePresignedURL.Lock()
 	mock.calls.GeneratePresignedURL = append(mock.calls.GeneratePresignedURL, callInfo)
 	mock.lockGeneratePresignedURL.Unlock()
-	return mock.GeneratePresignedURLFunc(ctx, key)
+	return mock.GeneratePresignedURLFunc(ctx, key, conditions)
 }
 
 // GeneratePresignedURLCalls gets all the calls that were made to GeneratePresignedURL.
@@ -129,12 +453,14 @@ func (mock *ClientMock) GeneratePresignedURL(ctx context.Context, key string) (s
 //
 //	len(mockedClient.GeneratePresignedURLCalls())
 func (mock *ClientMock) GeneratePresignedURLCalls() []struct {
-	Ctx context.Context
-	Key string
+	Ctx        context.Context
+	Key        string
+	Conditions UploadConditions
 } {
 	var calls []struct {
-		Ctx context.Context
-		Key string
+		Ctx        context.Context
+		Key        string
+		Conditions UploadConditions
 	}
 	mock.lockGeneratePresignedURL.RLock()
 	calls = mock.calls.GeneratePresignedURL
@@ -173,3 +499,119 @@ func (mock *ClientMock) ListBucketsCalls() []struct {
 	mock.lockListBuckets.RUnlock()
 	return calls
 }
+
+// ListMultipartUploads calls ListMultipartUploadsFunc.
+func (mock *ClientMock) ListMultipartUploads(ctx context.Context) ([]MultipartUpload, error) {
+	if mock.ListMultipartUploadsFunc == nil {
+		panic("ClientMock.ListMultipartUploadsFunc: method is nil but Client.ListMultipartUploads was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListMultipartUploads.Lock()
+	mock.calls.ListMultipartUploads = append(mock.calls.ListMultipartUploads, callInfo)
+	mock.lockListMultipartUploads.Unlock()
+	return mock.ListMultipartUploadsFunc(ctx)
+}
+
+// ListMultipartUploadsCalls gets all the calls that were made to ListMultipartUploads.
+// Check the length with:
+//
+//	len(mockedClient.ListMultipartUploadsCalls())
+func (mock *ClientMock) ListMultipartUploadsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListMultipartUploads.RLock()
+	calls = mock.calls.ListMultipartUploads
+	mock.lockListMultipartUploads.RUnlock()
+	return calls
+}
+
+// OpenObject calls OpenObjectFunc.
+func (mock *ClientMock) OpenObject(ctx context.Context, objectKey string) (*Object, error) {
+	if mock.OpenObjectFunc == nil {
+		panic("ClientMock.OpenObjectFunc: method is nil but Client.OpenObject was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ObjectKey string
+	}{
+		Ctx:       ctx,
+		ObjectKey: objectKey,
+	}
+	mock.lockOpenObject.Lock()
+	mock.calls.OpenObject = append(mock.calls.OpenObject, callInfo)
+	mock.lockOpenObject.Unlock()
+	return mock.OpenObjectFunc(ctx, objectKey)
+}
+
+// OpenObjectCalls gets all the calls that were made to OpenObject.
+// Check the length with:
+//
+//	len(mockedClient.OpenObjectCalls())
+func (mock *ClientMock) OpenObjectCalls() []struct {
+	Ctx       context.Context
+	ObjectKey string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ObjectKey string
+	}
+	mock.lockOpenObject.RLock()
+	calls = mock.calls.OpenObject
+	mock.lockOpenObject.RUnlock()
+	return calls
+}
+
+// UploadObject calls UploadObjectFunc.
+func (mock *ClientMock) UploadObject(ctx context.Context, key string, body io.Reader, contentType string, metadata map[string]string) error {
+	if mock.UploadObjectFunc == nil {
+		panic("ClientMock.UploadObjectFunc: method is nil but Client.UploadObject was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Key         string
+		Body        io.Reader
+		ContentType string
+		Metadata    map[string]string
+	}{
+		Ctx:         ctx,
+		Key:         key,
+		Body:        body,
+		ContentType: contentType,
+		Metadata:    metadata,
+	}
+	mock.lockUploadObject.Lock()
+	mock.calls.UploadObject = append(mock.calls.UploadObject, callInfo)
+	mock.lockUploadObject.Unlock()
+	return mock.UploadObjectFunc(ctx, key, body, contentType, metadata)
+}
+
+// UploadObjectCalls gets all the calls that were made to UploadObject.
+// Check the length with:
+//
+//	len(mockedClient.UploadObjectCalls())
+func (mock *ClientMock) UploadObjectCalls() []struct {
+	Ctx         context.Context
+	Key         string
+	Body        io.Reader
+	ContentType string
+	Metadata    map[string]string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Key         string
+		Body        io.Reader
+		ContentType string
+		Metadata    map[string]string
+	}
+	mock.lockUploadObject.RLock()
+	calls = mock.calls.UploadObject
+	mock.lockUploadObject.RUnlock()
+	return calls
+}
@@ -8,6 +8,13 @@ import (
 
 // Client defines the AWS SQS client interface.
 type Client interface {
-	GetFilenamesFromMessages(ctx context.Context) ([]string, error)
+	ReceiveMessages(ctx context.Context, maxMessages int) ([]Message, error)
 	DeleteMessages(ctx context.Context, messageHandles []string) error
 }
+
+// Message is a message of the queue notifying uploaded files.
+type Message struct {
+	ID            string
+	ReceiptHandle string
+	Body          string
+}
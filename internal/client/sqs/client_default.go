@@ -2,12 +2,11 @@ package sqs
 
 import (
 	"context"
-	"fmt"
 
 	s3Config "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/teezzan/candles/internal/config"
-	"github.com/tidwall/gjson"
 
 	"go.uber.org/zap"
 )
@@ -16,9 +15,11 @@ var _ Client = (*DefaultClient)(nil)
 
 // DefaultClient implements the default AWS S3 client.
 type DefaultClient struct {
-	logger    *zap.Logger
-	sqsClient *sqs.Client
-	queueURL  string
+	logger      *zap.Logger
+	sqsClient   *sqs.Client
+	queueURL    string
+	maxMessages int
+	waitTime    int
 }
 
 // NewClient initializes a new default AWS SQS client.
@@ -47,105 +48,44 @@ func NewClient(
 	}
 
 	return &DefaultClient{
-		logger:    logger,
-		sqsClient: client,
-		queueURL:  *urlResult.QueueUrl,
+		logger:      logger,
+		sqsClient:   client,
+		queueURL:    *urlResult.QueueUrl,
+		maxMessages: conf.MaxMessages,
+		waitTime:    conf.WaitTimeInSeconds,
 	}, nil
 }
 
-// GetFilenamesFromMessages retrieves filenames from messages stored in an AWS SQS queue.
-// It receives messages from the queue by polling and returns an array of filenames.
-// If any error occurs while receiving messages or deleting messages from the queue,
-// the function returns an error.
-func (c *DefaultClient) GetFilenamesFromMessages(ctx context.Context) ([]string, error) {
-	// Receive messages from queue by polling
+// ReceiveMessages long polls the SQS queue for at most `maxMessages` messages, capped by the configured batch size.
+// It waits up to the configured wait time for messages to arrive. The S3 event notification carried by a message
+// can be parsed with ParseEvent.
+// The messages are not deleted from the queue, and are received again once their visibility timeout elapses
+// unless they are deleted with DeleteMessages.
+func (c *DefaultClient) ReceiveMessages(ctx context.Context, maxMessages int) ([]Message, error) {
+	if maxMessages > c.maxMessages {
+		maxMessages = c.maxMessages
+	}
 	result, err := c.sqsClient.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
-		QueueUrl: &c.queueURL,
+		QueueUrl:            &c.queueURL,
+		MaxNumberOfMessages: int32(maxMessages),
+		WaitTimeSeconds:     int32(c.waitTime),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	var filenames []string
-	var receiptHandles []string
+	messages := make([]Message, 0, len(result.Messages))
 	for _, m := range result.Messages {
-		if m.Body != nil {
-			keys, err := extractKeyFromMessage(*m.Body)
-			if err != nil {
-				continue
-			}
-			filenames = append(filenames, keys...)
-		}
-		if m.ReceiptHandle != nil {
-			receiptHandles = append(receiptHandles, *m.ReceiptHandle)
-		}
-	}
-
-	err = c.DeleteMessages(ctx, receiptHandles)
-	if err != nil {
-		return nil, err
-	}
-
-	return filenames, nil
-}
-
-// extractKeyFromMessage extracts the file keys from the S3 records in a message.
-// The message is expected to be in JSON format and contains information about the S3 operations,
-// such as a PutObject or DeleteObject. The function returns the file keys present in the message.
-//
-// Example:
-//
-//	m := `{
-//	  "Records": [
-//	    {
-//	      "eventVersion": "2.1",
-//	      "eventSource": "aws:s3",
-//	      "s3": {
-//	        "bucket": {
-//	          "name": "my-bucket"
-//	        },
-//	        "object": {
-//	          "key": "file1.txt"
-//	        }
-//	      }
-//	    },
-//	    {
-//	      "eventVersion": "2.1",
-//	      "eventSource": "aws:s3",
-//	      "s3": {
-//	        "bucket": {
-//	          "name": "my-bucket"
-//	        },
-//	        "object": {
-//	          "key": "file2.txt"
-//	        }
-//	      }
-//	    }
-//	  ]
-//	}`
-//	keys, err := extractKeyFromMessage(m)
-//	fmt.Println(keys, err)
-//
-// Output: [file1.txt file2.txt] <nil>
-func extractKeyFromMessage(m string) ([]string, error) {
-	var keys []string
-	n := gjson.Get(m, "Records.#")
-	for i := 0; i < int(n.Int()); i++ {
-		s3 := gjson.Get(m, fmt.Sprintf("Records.%d.s3", i))
-		if !s3.Exists() {
+		if m.ReceiptHandle == nil {
 			continue
 		}
-		object := gjson.Get(m, fmt.Sprintf("Records.%d.s3.object", i))
-		if !object.Exists() {
-			continue
-		}
-		key := gjson.Get(m, fmt.Sprintf("Records.%d.s3.object.key", i))
-		if !key.Exists() {
-			continue
-		}
-		keys = append(keys, key.String())
+		messages = append(messages, Message{
+			ID:            aws.StringValue(m.MessageId),
+			ReceiptHandle: *m.ReceiptHandle,
+			Body:          aws.StringValue(m.Body),
+		})
 	}
-	return keys, nil
+	return messages, nil
 }
 
 // DeleteMessages deletes the specified messages from the SQS queue.
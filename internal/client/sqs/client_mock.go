@@ -21,8 +21,8 @@ var _ Client = &ClientMock{}
 //			DeleteMessagesFunc: func(ctx context.Context, messageHandles []string) error {
 //				panic("mock out the DeleteMessages method")
 //			},
-//			GetFilenamesFromMessagesFunc: func(ctx context.Context) ([]string, error) {
-//				panic("mock out the GetFilenamesFromMessages method")
+//			ReceiveMessagesFunc: func(ctx context.Context, maxMessages int) ([]Message, error) {
+//				panic("mock out the ReceiveMessages method")
 //			},
 //		}
 //
@@ -34,8 +34,8 @@ type ClientMock struct {
 	// DeleteMessagesFunc mocks the DeleteMessages method.
 	DeleteMessagesFunc func(ctx context.Context, messageHandles []string) error
 
-	// GetFilenamesFromMessagesFunc mocks the GetFilenamesFromMessages method.
-	GetFilenamesFromMessagesFunc func(ctx context.Context) ([]string, error)
+	// ReceiveMessagesFunc mocks the ReceiveMessages method.
+	ReceiveMessagesFunc func(ctx context.Context, maxMessages int) ([]Message, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -46,14 +46,16 @@ type ClientMock struct {
 			// MessageHandles is the messageHandles argument value.
 			MessageHandles []string
 		}
-		// GetFilenamesFromMessages holds details about calls to the GetFilenamesFromMessages method.
-		GetFilenamesFromMessages []struct {
+		// ReceiveMessages holds details about calls to the ReceiveMessages method.
+		ReceiveMessages []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// MaxMessages is the maxMessages argument value.
+			MaxMessages int
 		}
 	}
-	lockDeleteMessages           sync.RWMutex
-	lockGetFilenamesFromMessages sync.RWMutex
+	lockDeleteMessages  sync.RWMutex
+	lockReceiveMessages sync.RWMutex
 }
 
 // DeleteMessages calls DeleteMessagesFunc.
@@ -92,34 +94,38 @@ func (mock *ClientMock) DeleteMessagesCalls() []struct {
 	return calls
 }
 
-// GetFilenamesFromMessages calls GetFilenamesFromMessagesFunc.
-func (mock *ClientMock) GetFilenamesFromMessages(ctx context.Context) ([]string, error) {
-	if mock.GetFilenamesFromMessagesFunc == nil {
-		panic("ClientMock.GetFilenamesFromMessagesFunc: method is nil but Client.GetFilenamesFromMessages was just called")
+// ReceiveMessages calls ReceiveMessagesFunc.
+func (mock *ClientMock) ReceiveMessages(ctx context.Context, maxMessages int) ([]Message, error) {
+	if mock.ReceiveMessagesFunc == nil {
+		panic("ClientMock.ReceiveMessagesFunc: method is nil but Client.ReceiveMessages was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx         context.Context
+		MaxMessages int
 	}{
-		Ctx: ctx,
+		Ctx:         ctx,
+		MaxMessages: maxMessages,
 	}
-	mock.lockGetFilenamesFromMessages.Lock()
-	mock.calls.GetFilenamesFromMessages = append(mock.calls.GetFilenamesFromMessages, callInfo)
-	mock.lockGetFilenamesFromMessages.Unlock()
-	return mock.GetFilenamesFromMessagesFunc(ctx)
+	mock.lockReceiveMessages.Lock()
+	mock.calls.ReceiveMessages = append(mock.calls.ReceiveMessages, callInfo)
+	mock.lockReceiveMessages.Unlock()
+	return mock.ReceiveMessagesFunc(ctx, maxMessages)
 }
 
-// GetFilenamesFromMessagesCalls gets all the calls that were made to GetFilenamesFromMessages.
+// ReceiveMessagesCalls gets all the calls that were made to ReceiveMessages.
 // Check the length with:
 //
-//	len(mockedClient.GetFilenamesFromMessagesCalls())
-func (mock *ClientMock) GetFilenamesFromMessagesCalls() []struct {
-	Ctx context.Context
+//	len(mockedClient.ReceiveMessagesCalls())
+func (mock *ClientMock) ReceiveMessagesCalls() []struct {
+	Ctx         context.Context
+	MaxMessages int
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx         context.Context
+		MaxMessages int
 	}
-	mock.lockGetFilenamesFromMessages.RLock()
-	calls = mock.calls.GetFilenamesFromMessages
-	mock.lockGetFilenamesFromMessages.RUnlock()
+	mock.lockReceiveMessages.RLock()
+	calls = mock.calls.ReceiveMessages
+	mock.lockReceiveMessages.RUnlock()
 	return calls
 }
@@ -0,0 +1,90 @@
+package sqs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// s3TestEvent is the event S3 sends to a queue once notifications are configured.
+const s3TestEvent = "s3:TestEvent"
+
+// Event is an S3 event notification.
+type Event struct {
+	// TestEvent reports whether the event is the test event S3 sends once notifications are configured.
+	TestEvent bool
+	Records   []Record
+}
+
+// Record is a record of an S3 event notification.
+type Record struct {
+	EventSource string
+	EventName   string
+	Bucket      string
+	Key         string
+	Size        int64
+}
+
+// IsObjectCreated reports whether the record notifies the creation of an object.
+func (r Record) IsObjectCreated() bool {
+	return strings.HasPrefix(r.EventName, "ObjectCreated:")
+}
+
+// ParseEvent parses the S3 event notification carried by the body of a message.
+// The object keys, URL-encoded in notifications, are decoded.
+//
+// Example:
+//
+//	m := `{
+//	  "Records": [
+//	    {
+//	      "eventVersion": "2.1",
+//	      "eventSource": "aws:s3",
+//	      "eventName": "ObjectCreated:Put",
+//	      "s3": {
+//	        "bucket": {
+//	          "name": "my-bucket"
+//	        },
+//	        "object": {
+//	          "key": "my+file.csv",
+//	          "size": 1024
+//	        }
+//	      }
+//	    }
+//	  ]
+//	}`
+//	event, err := ParseEvent(m)
+//	fmt.Println(event.Records[0].Key, err)
+//
+// Output: my file.csv <nil>
+func ParseEvent(body string) (*Event, error) {
+	if !gjson.Valid(body) {
+		return nil, errors.New("invalid JSON")
+	}
+	if gjson.Get(body, "Event").String() == s3TestEvent {
+		return &Event{TestEvent: true}, nil
+	}
+
+	records := gjson.Get(body, "Records")
+	if !records.IsArray() {
+		return nil, errors.New("no records")
+	}
+	event := &Event{}
+	for i, r := range records.Array() {
+		key, err := url.QueryUnescape(r.Get("s3.object.key").String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid key of record %d: %w", i, err)
+		}
+		event.Records = append(event.Records, Record{
+			EventSource: r.Get("eventSource").String(),
+			EventName:   r.Get("eventName").String(),
+			Bucket:      r.Get("s3.bucket.name").String(),
+			Key:         key,
+			Size:        r.Get("s3.object.size").Int(),
+		})
+	}
+	return event, nil
+}
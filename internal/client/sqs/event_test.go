@@ -0,0 +1,65 @@
+package sqs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    *Event
+		wantErr bool
+	}{
+		{
+			name: "object created",
+			body: `{"Records":[{"eventSource":"aws:s3","eventName":"ObjectCreated:Put","s3":{"bucket":{"name":"bucket"},"object":{"key":"uploads/my+file%281%29.csv","size":1024}}}]}`,
+			want: &Event{
+				Records: []Record{
+					{
+						EventSource: "aws:s3",
+						EventName:   "ObjectCreated:Put",
+						Bucket:      "bucket",
+						Key:         "uploads/my file(1).csv",
+						Size:        1024,
+					},
+				},
+			},
+		},
+		{
+			name: "test event",
+			body: `{"Service":"Amazon S3","Event":"s3:TestEvent","Time":"2023-01-01T00:00:00.000Z","Bucket":"bucket"}`,
+			want: &Event{TestEvent: true},
+		},
+		{
+			name:    "invalid JSON",
+			body:    `{"Records":`,
+			wantErr: true,
+		},
+		{
+			name:    "no records",
+			body:    `{"Message":"hello"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid key encoding",
+			body:    `{"Records":[{"eventSource":"aws:s3","eventName":"ObjectCreated:Put","s3":{"bucket":{"name":"bucket"},"object":{"key":"%zz","size":1}}}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEvent(tt.body)
+			require.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRecord_IsObjectCreated(t *testing.T) {
+	assert.True(t, Record{EventName: "ObjectCreated:CompleteMultipartUpload"}.IsObjectCreated())
+	assert.False(t, Record{EventName: "ObjectRemoved:Delete"}.IsObjectCreated())
+}
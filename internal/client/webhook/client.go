@@ -0,0 +1,13 @@
+// Package webhook provides a webhook client.
+package webhook
+
+import (
+	"context"
+)
+
+//go:generate moq -rm -out client_mock.go . Client
+
+// Client defines the webhook client interface.
+type Client interface {
+	Send(ctx context.Context, url string, payload []byte) (int, error)
+}
@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/teezzan/candles/internal/config"
+	"go.uber.org/zap"
+)
+
+const (
+	// SignatureHeader is the header carrying the HMAC-SHA256 signature of a payload.
+	SignatureHeader = "X-Candles-Signature"
+	// TimestampHeader is the header carrying the Unix time at which a payload was signed.
+	TimestampHeader = "X-Candles-Timestamp"
+)
+
+// ErrForbiddenAddress is returned when a webhook is sent to a loopback, private or link-local address while private
+// networks are not allowed.
+var ErrForbiddenAddress = errors.New("forbidden webhook address")
+
+var _ Client = (*DefaultClient)(nil)
+
+// DefaultClient implements the default webhook client.
+type DefaultClient struct {
+	logger     *zap.Logger
+	httpClient *http.Client
+	secret     []byte
+}
+
+// NewClient initializes a new default webhook client. Unless private networks are allowed, the addresses are checked
+// when connecting, after DNS resolution and on every redirect, so that webhooks cannot reach internal services.
+// Without secret, the payloads are sent unsigned, as a signature with an empty key could be forged by anyone.
+func NewClient(
+	logger *zap.Logger,
+	conf config.WebhookConfig,
+) *DefaultClient {
+	if conf.Secret == "" {
+		logger.Warn("no webhook secret configured, webhooks are sent unsigned")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !conf.AllowPrivateNetworks {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   checkPublicAddress,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	return &DefaultClient{
+		logger: logger,
+		httpClient: &http.Client{
+			Timeout:   time.Duration(conf.TimeoutInSeconds) * time.Second,
+			Transport: transport,
+		},
+		secret: []byte(conf.Secret),
+	}
+}
+
+// checkPublicAddress rejects the connections to loopback, private, link-local, multicast and unspecified addresses.
+func checkPublicAddress(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// Sign returns the signature of a payload signed at the given Unix time: the hex encoded HMAC-SHA256
+// of the timestamp and the payload joined by a dot, prefixed by the hash algorithm.
+func Sign(secret []byte, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts a JSON payload to a URL, signed unless the client has no secret.
+// It returns the status code of the response, or an error if the request failed or was not acknowledged with a 2xx status code.
+func (c *DefaultClient) Send(ctx context.Context, url string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	if len(c.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(c.secret, timestamp, payload))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	c.logger.Debug("webhook delivered", zap.String("url", url), zap.Int("status", resp.StatusCode))
+	return resp.StatusCode, nil
+}
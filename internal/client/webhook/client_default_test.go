@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/config"
+	"go.uber.org/zap"
+)
+
+func TestDefaultClient_Send(t *testing.T) {
+	tests := []struct {
+		name                 string
+		secret               string
+		statusCode           int
+		allowPrivateNetworks bool
+		wantStatusCode       int
+		wantErr              bool
+	}{
+		{
+			name:                 "acknowledged",
+			secret:               "secret",
+			statusCode:           http.StatusOK,
+			allowPrivateNetworks: true,
+			wantStatusCode:       http.StatusOK,
+		},
+		{
+			name:                 "rejected",
+			secret:               "secret",
+			statusCode:           http.StatusInternalServerError,
+			allowPrivateNetworks: true,
+			wantStatusCode:       http.StatusInternalServerError,
+			wantErr:              true,
+		},
+		{
+			name:                 "unsigned",
+			statusCode:           http.StatusOK,
+			allowPrivateNetworks: true,
+			wantStatusCode:       http.StatusOK,
+		},
+		{
+			name:       "private network",
+			secret:     "secret",
+			statusCode: http.StatusOK,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			payload := []byte(`{"event":"upload.finished"}`)
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+				require.NoError(t, err)
+
+				assert.Equal(t, payload, body)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				if tt.secret == "" {
+					assert.Empty(t, r.Header.Get(SignatureHeader))
+				} else {
+					assert.Equal(t, Sign([]byte(tt.secret), timestamp, body), r.Header.Get(SignatureHeader))
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer receiver.Close()
+
+			c := NewClient(zap.NewNop(), config.WebhookConfig{Secret: tt.secret, TimeoutInSeconds: 5, AllowPrivateNetworks: tt.allowPrivateNetworks})
+			statusCode, err := c.Send(context.Background(), receiver.URL, payload)
+			require.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantStatusCode, statusCode)
+			if !tt.allowPrivateNetworks {
+				assert.ErrorIs(t, err, ErrForbiddenAddress)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package webhook
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that ClientMock does implement Client.
+// If this is not the case, regenerate this file with moq.
+var _ Client = &ClientMock{}
+
+// ClientMock is a mock implementation of Client.
+//
+//	func TestSomethingThatUsesClient(t *testing.T) {
+//
+//		// make and configure a mocked Client
+//		mockedClient := &ClientMock{
+//			SendFunc: func(ctx context.Context, url string, payload []byte) (int, error) {
+//				panic("mock out the Send method")
+//			},
+//		}
+//
+//		// use mockedClient in code that requires Client
+//		// and then make assertions.
+//
+//	}
+type ClientMock struct {
+	// SendFunc mocks the Send method.
+	SendFunc func(ctx context.Context, url string, payload []byte) (int, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// URL is the url argument value.
+			URL string
+			// Payload is the payload argument value.
+			Payload []byte
+		}
+	}
+	lockSend sync.RWMutex
+}
+
+// Send calls SendFunc.
+func (mock *ClientMock) Send(ctx context.Context, url string, payload []byte) (int, error) {
+	if mock.SendFunc == nil {
+		panic("ClientMock.SendFunc: method is nil but Client.Send was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		URL     string
+		Payload []byte
+	}{
+		Ctx:     ctx,
+		URL:     url,
+		Payload: payload,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	return mock.SendFunc(ctx, url, payload)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//
+//	len(mockedClient.SendCalls())
+func (mock *ClientMock) SendCalls() []struct {
+	Ctx     context.Context
+	URL     string
+	Payload []byte
+} {
+	var calls []struct {
+		Ctx     context.Context
+		URL     string
+		Payload []byte
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
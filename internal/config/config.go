@@ -1,33 +1,68 @@
 package config
 
-import "github.com/teezzan/candles/internal/util"
+import (
+	"fmt"
+
+	"github.com/teezzan/candles/internal/util"
+)
 
 type Config struct {
-	Database                  DatabaseConfig
-	Server                    ServerConfig
-	OHLCConfig                OHLCConfig
-	S3Config                  S3Config
-	SQSConfig                 SQSConfig
-	CronJobFrequencyInMinutes int
-	CleanupCronJobFrequencyInDays int
+	Database                                DatabaseConfig
+	Server                                  ServerConfig
+	OHLCConfig                              OHLCConfig
+	S3Config                                S3Config
+	SQSConfig                               SQSConfig
+	WebhookConfig                           WebhookConfig
+	CacheConfig                             CacheConfig
+	SchedulerConfig                         SchedulerConfig
+	CleanupCronJobFrequencyInDays           int
+	DataQualityCronJobFrequencyInHours      int
+	MultipartCleanupCronJobFrequencyInHours int
+	StaleMultipartUploadAgeInHours          int
+	PartitionMonthsAhead                    int
+	PartitionRetentionInMonths              int
 }
 
 type DatabaseConfig struct {
+	Driver   string
 	Host     string
 	Port     int
 	Username string
 	Password string
 	Database string
+	SSLMode  string
 }
 
 type ServerConfig struct {
-	Host string
-	Port int
+	Host                     string
+	Port                     int
+	ShutdownTimeoutInSeconds int
 }
 
 type OHLCConfig struct {
-	DiscardInCompleteRow  bool
-	DefaultDataPointLimit int
+	DiscardInCompleteRow            bool
+	DefaultDataPointLimit           int
+	DataQualitySymbols              []string
+	DataQualityInterval             string
+	AnomalyAction                   string
+	AnomalyMaxJumpPercent           float64
+	AnomalyZScore                   float64
+	AnomalyWindowSize               int
+	IngestionMode                   string
+	IngestionBatchSize              int
+	ProgressUpdateIntervalInSeconds int
+	MaxUploadSizeInMB               int
+	AsyncUploadThresholdInMB        int
+	WorkerCount                     int
+	WorkerShutdownTimeoutInSeconds  int
+	UploadKeyPrefix                 string
+	UploadKeySuffix                 string
+	WebhookURLs                     []string
+	WebhookMaxAttempts              int
+	WebhookBackoffInSeconds         int
+	RetentionRules                  []string
+	RollupIntervals                 []string
+	PricePrecisions                 []string
 }
 
 type S3Config struct {
@@ -37,26 +72,85 @@ type S3Config struct {
 }
 
 type SQSConfig struct {
-	Region string
-	Queue  string
+	Region            string
+	Queue             string
+	MaxMessages       int
+	WaitTimeInSeconds int
+}
+
+type WebhookConfig struct {
+	Secret               string
+	TimeoutInSeconds     int
+	AllowPrivateNetworks bool
+}
+
+// CacheConfig configures the cache of the candle queries. Without Redis address, the candles are cached in memory.
+type CacheConfig struct {
+	Enabled         bool
+	RedisAddress    string
+	RedisPassword   string
+	RedisDB         int
+	TTLInSeconds    int
+	LocalMaxEntries int
+}
+
+// SchedulerConfig configures the background jobs. Their schedules default to the frequencies of the cron jobs, if any.
+type SchedulerConfig struct {
+	StaleStatusCleanup   JobConfig
+	DataQualityCheck     JobConfig
+	MultipartCleanup     JobConfig
+	PartitionMaintenance JobConfig
+	Retention            JobConfig
+	RollupRebuild        JobConfig
+}
+
+// JobConfig configures a background job. A zero timeout lets the job run without time limit.
+type JobConfig struct {
+	Schedule         string
+	TimeoutInMinutes int
 }
 
 func Init() *Config {
-	return &Config{
+	driver := util.GetString("DB_DRIVER", defaultDBDriver)
+	conf := &Config{
 		Database: DatabaseConfig{
+			Driver:   driver,
 			Host:     util.GetString("DB_HOST", defaultDBHost),
-			Port:     util.GetInt("DB_PORT", defaultDBPort),
+			Port:     util.GetInt("DB_PORT", defaultDBPortOf(driver)),
 			Username: util.GetString("DB_USERNAME", defaultDBUsername),
 			Password: util.GetString("DB_PASSWORD", defaultDBPassword),
 			Database: util.GetString("DB_NAME", defaultDBName),
+			SSLMode:  util.GetString("DB_SSL_MODE", defaultDBSSLMode),
 		},
 		Server: ServerConfig{
-			Host: util.GetString("SERVER_HOST", defaultServerHost),
-			Port: util.GetInt("SERVER_PORT", defaultServerPort),
+			Host:                     util.GetString("SERVER_HOST", defaultServerHost),
+			Port:                     util.GetInt("SERVER_PORT", defaultServerPort),
+			ShutdownTimeoutInSeconds: util.GetInt("SERVER_SHUTDOWN_TIMEOUT_IN_SECONDS", defaultServerShutdownTimeoutInSeconds),
 		},
 		OHLCConfig: OHLCConfig{
-			DiscardInCompleteRow:  util.GetBool("OHLC_DISCARD_INCOMPLETE_ROW", defaultDiscardInCompleteRow),
-			DefaultDataPointLimit: util.GetInt("OHLC_DATA_POINT_LIMIT", defaultDataPointLimit),
+			DiscardInCompleteRow:            util.GetBool("OHLC_DISCARD_INCOMPLETE_ROW", defaultDiscardInCompleteRow),
+			DefaultDataPointLimit:           util.GetInt("OHLC_DATA_POINT_LIMIT", defaultDataPointLimit),
+			DataQualitySymbols:              util.GetStringSlice("OHLC_DATA_QUALITY_SYMBOLS", defaultDataQualitySymbols),
+			DataQualityInterval:             util.GetString("OHLC_DATA_QUALITY_INTERVAL", defaultDataQualityInterval),
+			AnomalyAction:                   util.GetString("OHLC_ANOMALY_ACTION", defaultAnomalyAction),
+			AnomalyMaxJumpPercent:           util.GetFloat64("OHLC_ANOMALY_MAX_JUMP_PERCENT", defaultAnomalyMaxJumpPercent),
+			AnomalyZScore:                   util.GetFloat64("OHLC_ANOMALY_Z_SCORE", defaultAnomalyZScore),
+			AnomalyWindowSize:               util.GetInt("OHLC_ANOMALY_WINDOW_SIZE", defaultAnomalyWindowSize),
+			IngestionMode:                   util.GetString("OHLC_INGESTION_MODE", defaultIngestionMode),
+			IngestionBatchSize:              util.GetInt("OHLC_INGESTION_BATCH_SIZE", defaultIngestionBatchSize),
+			ProgressUpdateIntervalInSeconds: util.GetInt("OHLC_PROGRESS_UPDATE_INTERVAL_IN_SECONDS", defaultProgressUpdateIntervalInSeconds),
+			MaxUploadSizeInMB:               util.GetInt("OHLC_MAX_UPLOAD_SIZE_IN_MB", defaultMaxUploadSizeInMB),
+			AsyncUploadThresholdInMB:        util.GetInt("OHLC_ASYNC_UPLOAD_THRESHOLD_IN_MB", defaultAsyncUploadThresholdInMB),
+			WorkerCount:                     util.GetInt("OHLC_WORKER_COUNT", defaultWorkerCount),
+			WorkerShutdownTimeoutInSeconds:  util.GetInt("OHLC_WORKER_SHUTDOWN_TIMEOUT_IN_SECONDS", defaultWorkerShutdownTimeoutInSeconds),
+			UploadKeyPrefix:                 util.GetString("OHLC_UPLOAD_KEY_PREFIX", defaultUploadKeyPrefix),
+			UploadKeySuffix:                 util.GetString("OHLC_UPLOAD_KEY_SUFFIX", defaultUploadKeySuffix),
+			WebhookURLs:                     util.GetStringSlice("OHLC_WEBHOOK_URLS", defaultWebhookURLs),
+			WebhookMaxAttempts:              util.GetInt("OHLC_WEBHOOK_MAX_ATTEMPTS", defaultWebhookMaxAttempts),
+			WebhookBackoffInSeconds:         util.GetInt("OHLC_WEBHOOK_BACKOFF_IN_SECONDS", defaultWebhookBackoffInSeconds),
+			RetentionRules:                  util.GetStringSlice("OHLC_RETENTION_RULES", defaultRetentionRules),
+			RollupIntervals:                 util.GetStringSlice("OHLC_ROLLUP_INTERVALS", defaultRollupIntervals),
+			PricePrecisions:                 util.GetStringSlice("OHLC_PRICE_PRECISIONS", defaultPricePrecisions),
 		},
 		S3Config: S3Config{
 			Region:               util.GetString("S3_REGION", defaultS3Region),
@@ -64,12 +158,64 @@ func Init() *Config {
 			PresignURLExpiryTime: util.GetInt("S3_PRESIGN_URL_EXPIRY_TIME", defaultS3PresignURLExpiryTime),
 		},
 		SQSConfig: SQSConfig{
-			Region: util.GetString("SQS_REGION", defaultSQSRegion),
-			Queue:  util.GetString("SQS_QUEUE", defaultSQSQueue),
+			Region:            util.GetString("SQS_REGION", defaultSQSRegion),
+			Queue:             util.GetString("SQS_QUEUE", defaultSQSQueue),
+			MaxMessages:       util.GetInt("SQS_MAX_MESSAGES", defaultSQSMaxMessages),
+			WaitTimeInSeconds: util.GetInt("SQS_WAIT_TIME_IN_SECONDS", defaultSQSWaitTimeInSeconds),
+		},
+		WebhookConfig: WebhookConfig{
+			Secret:               util.GetString("WEBHOOK_SECRET", defaultWebhookSecret),
+			TimeoutInSeconds:     util.GetInt("WEBHOOK_TIMEOUT_IN_SECONDS", defaultWebhookTimeoutInSeconds),
+			AllowPrivateNetworks: util.GetBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", defaultWebhookAllowPrivateNetworks),
+		},
+		CacheConfig: CacheConfig{
+			Enabled:         util.GetBool("CACHE_ENABLED", defaultCacheEnabled),
+			RedisAddress:    util.GetString("CACHE_REDIS_ADDRESS", defaultCacheRedisAddress),
+			RedisPassword:   util.GetString("CACHE_REDIS_PASSWORD", defaultCacheRedisPassword),
+			RedisDB:         util.GetInt("CACHE_REDIS_DB", defaultCacheRedisDB),
+			TTLInSeconds:    util.GetInt("CACHE_TTL_IN_SECONDS", defaultCacheTTLInSeconds),
+			LocalMaxEntries: util.GetInt("CACHE_LOCAL_MAX_ENTRIES", defaultCacheLocalMaxEntries),
+		},
+		CleanupCronJobFrequencyInDays:           util.GetInt("CLEANUP_CRON_JOB_FREQUENCY_IN_DAYS", defaultCleanupCronJobFrequencyInDays),
+		DataQualityCronJobFrequencyInHours:      util.GetInt("DATA_QUALITY_CRON_JOB_FREQUENCY_IN_HOURS", defaultDataQualityCronJobFrequencyInHours),
+		MultipartCleanupCronJobFrequencyInHours: util.GetInt("MULTIPART_CLEANUP_CRON_JOB_FREQUENCY_IN_HOURS", defaultMultipartCleanupCronJobFrequencyInHours),
+		StaleMultipartUploadAgeInHours:          util.GetInt("STALE_MULTIPART_UPLOAD_AGE_IN_HOURS", defaultStaleMultipartUploadAgeInHours),
+		PartitionMonthsAhead:                    util.GetInt("PARTITION_MONTHS_AHEAD", defaultPartitionMonthsAhead),
+		PartitionRetentionInMonths:              util.GetInt("PARTITION_RETENTION_IN_MONTHS", defaultPartitionRetentionInMonths),
+	}
+	conf.SchedulerConfig = SchedulerConfig{
+		StaleStatusCleanup: JobConfig{
+			Schedule:         util.GetString("SCHEDULER_STALE_STATUS_CLEANUP_SCHEDULE", fmt.Sprintf("@every %dh", 24*conf.CleanupCronJobFrequencyInDays)),
+			TimeoutInMinutes: util.GetInt("SCHEDULER_STALE_STATUS_CLEANUP_TIMEOUT_IN_MINUTES", defaultStaleStatusCleanupTimeoutInMinutes),
+		},
+		DataQualityCheck: JobConfig{
+			Schedule:         util.GetString("SCHEDULER_DATA_QUALITY_CHECK_SCHEDULE", fmt.Sprintf("@every %dh", conf.DataQualityCronJobFrequencyInHours)),
+			TimeoutInMinutes: util.GetInt("SCHEDULER_DATA_QUALITY_CHECK_TIMEOUT_IN_MINUTES", defaultDataQualityCheckTimeoutInMinutes),
+		},
+		MultipartCleanup: JobConfig{
+			Schedule:         util.GetString("SCHEDULER_MULTIPART_CLEANUP_SCHEDULE", fmt.Sprintf("@every %dh", conf.MultipartCleanupCronJobFrequencyInHours)),
+			TimeoutInMinutes: util.GetInt("SCHEDULER_MULTIPART_CLEANUP_TIMEOUT_IN_MINUTES", defaultMultipartCleanupTimeoutInMinutes),
+		},
+		PartitionMaintenance: JobConfig{
+			Schedule:         util.GetString("SCHEDULER_PARTITION_MAINTENANCE_SCHEDULE", defaultPartitionMaintenanceSchedule),
+			TimeoutInMinutes: util.GetInt("SCHEDULER_PARTITION_MAINTENANCE_TIMEOUT_IN_MINUTES", defaultPartitionMaintenanceTimeoutInMinutes),
+		},
+		Retention: JobConfig{
+			Schedule:         util.GetString("SCHEDULER_RETENTION_SCHEDULE", defaultRetentionSchedule),
+			TimeoutInMinutes: util.GetInt("SCHEDULER_RETENTION_TIMEOUT_IN_MINUTES", defaultRetentionTimeoutInMinutes),
+		},
+		RollupRebuild: JobConfig{
+			Schedule:         util.GetString("SCHEDULER_ROLLUP_REBUILD_SCHEDULE", defaultRollupRebuildSchedule),
+			TimeoutInMinutes: util.GetInt("SCHEDULER_ROLLUP_REBUILD_TIMEOUT_IN_MINUTES", defaultRollupRebuildTimeoutInMinutes),
 		},
-		CronJobFrequencyInMinutes: util.GetInt("CRON_JOB_FREQUENCY_IN_MINUTES", defaultCronJobFrequencyInMinutes),
-		CleanupCronJobFrequencyInDays: util.GetInt("CLEANUP_CRON_JOB_FREQUENCY_IN_DAYS", defaultCleanupCronJobFrequencyInDays),
-		
 	}
+	return conf
+}
 
+// defaultDBPortOf returns the default database port of a driver, the port of mysql for the drivers without server.
+func defaultDBPortOf(driver string) int {
+	if driver == "postgres" {
+		return defaultPostgresPort
+	}
+	return defaultMySQLPort
 }
@@ -1,26 +1,67 @@
 package config
 
 const (
+	//defaultDBDriver is the default database driver, either mysql, postgres, sqlite or memory
+	defaultDBDriver = "mysql"
 	//defaultDBHost is the default database host
 	defaultDBHost = "localhost"
-	//defaultDBPort is the default database port
-	defaultDBPort = 5432
+	//defaultMySQLPort is the default database port of the mysql driver
+	defaultMySQLPort = 3306
+	//defaultPostgresPort is the default database port of the postgres driver
+	defaultPostgresPort = 5432
 	//defaultDBUsername is the default database username
 	defaultDBUsername = "root"
 	//defaultDBPassword is the default database password
 	defaultDBPassword = "root"
 	//defaultDBName is the default database name
 	defaultDBName = "candles"
+	//defaultDBSSLMode is the default SSL mode of the postgres connections
+	defaultDBSSLMode = "disable"
 
 	//defaultServerHost is the default server host
 	defaultServerHost = "localhost"
 	//defaultServerPort is the default server port
 	defaultServerPort = 8090
+	// defaultServerShutdownTimeoutInSeconds is the default time in seconds the requests and jobs in progress are waited for on shutdown
+	defaultServerShutdownTimeoutInSeconds = 30
 
 	//defaultDiscardInCompleteRow is the default value for discard incomplete row
 	defaultDiscardInCompleteRow = false
 	// defaultDataPointLimit is the default value for data point limit
 	defaultDataPointLimit = 100
+	// defaultDataQualityInterval is the default expected interval between candles for data quality checks
+	defaultDataQualityInterval = "1m"
+	// defaultAnomalyAction is the default action taken on suspicious data points: off, flag, quarantine or reject
+	defaultAnomalyAction = "off"
+	// defaultAnomalyMaxJumpPercent is the default maximum price jump in percent between neighbouring data points
+	defaultAnomalyMaxJumpPercent = 50
+	// defaultAnomalyZScore is the default maximum z-score of a price over the rolling window of recent closes
+	defaultAnomalyZScore = 6
+	// defaultAnomalyWindowSize is the default number of recent closes in the rolling window
+	defaultAnomalyWindowSize = 30
+	// defaultIngestionMode is the default way a file is made visible: transaction or batch
+	defaultIngestionMode = "transaction"
+	// defaultIngestionBatchSize is the default number of data points inserted per statement
+	defaultIngestionBatchSize = 1000
+	// defaultProgressUpdateIntervalInSeconds is the default interval between two updates of the ingestion progress of a file in batch mode
+	defaultProgressUpdateIntervalInSeconds = 5
+	// defaultMaxUploadSizeInMB is the default maximum size in MB of a file uploaded directly
+	defaultMaxUploadSizeInMB = 30
+	// defaultAsyncUploadThresholdInMB is the default size in MB above which a file uploaded directly is processed asynchronously
+	defaultAsyncUploadThresholdInMB = 10
+	// defaultWorkerCount is the default number of files processed at the same time from the SQS queue
+	defaultWorkerCount = 4
+	// defaultWorkerShutdownTimeoutInSeconds is the default time in seconds the files being processed are waited for
+	// on shutdown before they are cancelled
+	defaultWorkerShutdownTimeoutInSeconds = 30
+	// defaultUploadKeyPrefix is the default prefix the keys of the files notified by S3 must start with
+	defaultUploadKeyPrefix = ""
+	// defaultUploadKeySuffix is the default suffix the keys of the files notified by S3 must end with
+	defaultUploadKeySuffix = ""
+	// defaultWebhookMaxAttempts is the default number of attempts to deliver a webhook
+	defaultWebhookMaxAttempts = 5
+	// defaultWebhookBackoffInSeconds is the default delay before retrying a webhook delivery, doubled after every attempt
+	defaultWebhookBackoffInSeconds = 2
 
 	//defaultS3Region is the default value for s3 region
 	defaultS3Region = "eu-west-1"
@@ -33,8 +74,81 @@ const (
 	defaultSQSRegion = "eu-west-1"
 	//defaultSQSQueue is the default value for sqs queue
 	defaultSQSQueue = "candle-files-notification-fifo"
-	// defaultCronJobFrequencyInMinutes is the default value for cron job frequency in minutes
-	defaultCronJobFrequencyInMinutes = 2
+	// defaultSQSMaxMessages is the default maximum number of messages received at once, 10 at most
+	defaultSQSMaxMessages = 10
+	// defaultSQSWaitTimeInSeconds is the default time in seconds a receive call waits for messages to arrive, 20 at most
+	defaultSQSWaitTimeInSeconds = 20
+
+	// defaultWebhookSecret is the default secret the webhook payloads are signed with
+	defaultWebhookSecret = ""
+	// defaultWebhookTimeoutInSeconds is the default timeout of a webhook delivery in seconds
+	defaultWebhookTimeoutInSeconds = 10
+	// defaultWebhookAllowPrivateNetworks is whether webhooks are delivered to loopback, private and link-local addresses
+	// by default
+	defaultWebhookAllowPrivateNetworks = false
+
+	// defaultCacheEnabled is the default value for caching the candle queries
+	defaultCacheEnabled = false
+	// defaultCacheRedisAddress is the default address of the Redis server the candles are cached in, in memory if empty
+	defaultCacheRedisAddress = ""
+	// defaultCacheRedisPassword is the default password of the Redis server
+	defaultCacheRedisPassword = ""
+	// defaultCacheRedisDB is the default Redis database the candles are cached in
+	defaultCacheRedisDB = 0
+	// defaultCacheTTLInSeconds is the default time in seconds the cached candles are kept
+	defaultCacheTTLInSeconds = 60
+	// defaultCacheLocalMaxEntries is the default maximum number of queries cached in memory
+	defaultCacheLocalMaxEntries = 10000
+
 	// defaultCleanupCronJobFrequencyInDays is the default value for cleanup of stale data processing status in days
 	defaultCleanupCronJobFrequencyInDays = 1
+	// defaultDataQualityCronJobFrequencyInHours is the default value for the data quality check cron job frequency in hours
+	defaultDataQualityCronJobFrequencyInHours = 24
+	// defaultMultipartCleanupCronJobFrequencyInHours is the default value for the stale multipart uploads cleanup cron job frequency in hours
+	defaultMultipartCleanupCronJobFrequencyInHours = 6
+	// defaultStaleMultipartUploadAgeInHours is the default age in hours after which an incomplete multipart upload is aborted
+	defaultStaleMultipartUploadAgeInHours = 24
+	// defaultPartitionMonthsAhead is the default number of monthly partitions of the data points created ahead of the current month
+	defaultPartitionMonthsAhead = 3
+	// defaultPartitionRetentionInMonths is the default number of months after which the partitions of the data points are dropped.
+	// Zero keeps the data points forever.
+	defaultPartitionRetentionInMonths = 0
+	// defaultPartitionMaintenanceSchedule is the default schedule of the partition maintenance job
+	defaultPartitionMaintenanceSchedule = "@daily"
+	// defaultRetentionSchedule is the default schedule of the retention job
+	defaultRetentionSchedule = "@daily"
+	// defaultRollupRebuildSchedule is the default schedule of the rollup rebuild job
+	defaultRollupRebuildSchedule = "@weekly"
+
+	// defaultStaleStatusCleanupTimeoutInMinutes is the default timeout of the stale processing status cleanup job in minutes
+	defaultStaleStatusCleanupTimeoutInMinutes = 10
+	// defaultDataQualityCheckTimeoutInMinutes is the default timeout of the data quality check job in minutes
+	defaultDataQualityCheckTimeoutInMinutes = 60
+	// defaultMultipartCleanupTimeoutInMinutes is the default timeout of the stale multipart uploads cleanup job in minutes
+	defaultMultipartCleanupTimeoutInMinutes = 10
+	// defaultPartitionMaintenanceTimeoutInMinutes is the default timeout of the partition maintenance job in minutes
+	defaultPartitionMaintenanceTimeoutInMinutes = 30
+	// defaultRetentionTimeoutInMinutes is the default timeout of the retention job in minutes
+	defaultRetentionTimeoutInMinutes = 60
+	// defaultRollupRebuildTimeoutInMinutes is the default timeout of the rollup rebuild job in minutes
+	defaultRollupRebuildTimeoutInMinutes = 240
 )
+
+// defaultDataQualitySymbols is the default list of symbols checked by the data quality cron job.
+// An empty list checks every stored symbol.
+var defaultDataQualitySymbols = []string{}
+
+// defaultRetentionRules is the default list of retention rules of the candles, such as `1m=90d`, `1d=forever` or `BTC/1m=30d`.
+// An empty list keeps every candle forever.
+var defaultRetentionRules = []string{}
+
+// defaultRollupIntervals is the default list of intervals of the rollups maintained along with the data points.
+// An empty list disables the rollups.
+var defaultRollupIntervals = []string{"5m", "1h", "1d"}
+
+// defaultPricePrecisions is the default list of price precision rules, such as `BTC=8` or `EUR=4`.
+// An empty list keeps the prices of every symbol with 10 decimal places.
+var defaultPricePrecisions = []string{}
+
+// defaultWebhookURLs is the default list of URLs notified when the processing of any uploaded file finishes.
+var defaultWebhookURLs = []string{}
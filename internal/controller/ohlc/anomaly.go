@@ -0,0 +1,230 @@
+package ohlc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shopspring/decimal"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	E "github.com/teezzan/candles/internal/errors"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+// minZScoreWindow is the minimum number of recent closes needed before the z-score check applies.
+const minZScoreWindow = 3
+
+// anomalyDetector detects fat-finger prints by comparing a data point with its neighbours
+// and with the rolling window of recent closes of its symbol.
+type anomalyDetector struct {
+	maxJumpPercent float64
+	zScore         float64
+	windowSize     int
+}
+
+// inspect returns the reason why the candle is suspicious, or an empty string if it looks sound.
+// A candle is suspicious if its prices are inconsistent, if it jumps by more than maxJumpPercent from both
+// the previous and the next data point, or if one of its prices has a z-score above the threshold over the
+// window of recent closes. prev and next are nil when the candle has no such neighbour.
+func (d *anomalyDetector) inspect(window []float64, prev *data.OHLCEntity, candle data.OHLCEntity, next *data.OHLCEntity) string {
+	if candle.High.LessThan(decimal.Max(candle.Open, candle.Close)) || candle.Low.GreaterThan(decimal.Min(candle.Open, candle.Close)) {
+		return "inconsistent prices: high and low do not bound open and close"
+	}
+
+	if prev != nil {
+		jump := maxJumpPercent(candle, prev.Close)
+		if jump > d.maxJumpPercent && (next == nil || maxJumpPercent(candle, next.Close) > d.maxJumpPercent) {
+			return fmt.Sprintf("price jump of %.2f%% from the previous close", jump)
+		}
+	}
+
+	if len(window) >= minZScoreWindow {
+		mean, std := meanAndStdDev(window)
+		if std > 0 {
+			for _, price := range prices(candle) {
+				if z := math.Abs(price-mean) / std; z > d.zScore {
+					return fmt.Sprintf("z-score of %.2f over the last %d closes", z, len(window))
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// maxJumpPercent returns the largest deviation in percent of the candle prices from the reference price.
+func maxJumpPercent(candle data.OHLCEntity, referencePrice decimal.Decimal) float64 {
+	reference := referencePrice.InexactFloat64()
+	if reference == 0 {
+		return 0
+	}
+	var jump float64
+	for _, price := range prices(candle) {
+		jump = math.Max(jump, math.Abs(price-reference)/reference*100)
+	}
+	return jump
+}
+
+// prices returns the prices of the candle as floating-point numbers, precise enough for the statistics of the detection.
+func prices(candle data.OHLCEntity) []float64 {
+	return []float64{candle.Open.InexactFloat64(), candle.High.InexactFloat64(), candle.Low.InexactFloat64(), candle.Close.InexactFloat64()}
+}
+
+// meanAndStdDev returns the mean and the population standard deviation of the values.
+func meanAndStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// screenDataPoints runs the anomaly detection on the data points according to the configured action.
+// It returns the data points to be inserted and the suspicious data points to be recorded for review.
+// Rejected and quarantined data points are not returned for insertion, flagged data points are.
+// The rolling window of every symbol is seeded with the latest closes preceding its data points stored in `repo`.
+func (s *DefaultService) screenDataPoints(ctx context.Context, repo repository.Repository, points []data.OHLCEntity) ([]data.OHLCEntity, []data.AnomalyEntity, error) {
+	if s.anomalyAction == data.AnomalyActionOff || len(points) == 0 {
+		return points, nil, nil
+	}
+
+	bySymbol := map[string][]data.OHLCEntity{}
+	symbols := []string{}
+	for _, p := range points {
+		if _, ok := bySymbol[p.Symbol]; !ok {
+			symbols = append(symbols, p.Symbol)
+		}
+		bySymbol[p.Symbol] = append(bySymbol[p.Symbol], p)
+	}
+
+	accepted := make([]data.OHLCEntity, 0, len(points))
+	anomalies := []data.AnomalyEntity{}
+	for _, symbol := range symbols {
+		series := bySymbol[symbol]
+		sort.SliceStable(series, func(i, j int) bool {
+			return series[i].Time.Before(series[j].Time)
+		})
+
+		history, err := repo.GetLatestDataPoints(ctx, symbol, series[0].Time, s.anomalyDetector.windowSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		window := make([]float64, 0, len(history))
+		var prev *data.OHLCEntity
+		for i := range history {
+			window = append(window, history[i].Close.InexactFloat64())
+			prev = &history[i]
+		}
+
+		for i, candle := range series {
+			var next *data.OHLCEntity
+			if i+1 < len(series) {
+				next = &series[i+1]
+			}
+
+			reason := s.anomalyDetector.inspect(window, prev, candle, next)
+			if reason == "" {
+				accepted = append(accepted, candle)
+				window = append(window, candle.Close.InexactFloat64())
+				if len(window) > s.anomalyDetector.windowSize {
+					window = window[1:]
+				}
+				prev = &series[i]
+				continue
+			}
+
+			switch s.anomalyAction {
+			case data.AnomalyActionFlag:
+				accepted = append(accepted, candle)
+				anomalies = append(anomalies, data.NewAnomalyEntity(candle, reason, data.AnomalyStatusFlagged))
+			case data.AnomalyActionQuarantine:
+				anomalies = append(anomalies, data.NewAnomalyEntity(candle, reason, data.AnomalyStatusQuarantined))
+			case data.AnomalyActionReject:
+				// The data point is dropped.
+			}
+			s.logger.Warn("Suspicious data point detected",
+				zap.String("symbol", candle.Symbol),
+				zap.Time("time", candle.Time),
+				zap.String("reason", reason),
+				zap.String("action", string(s.anomalyAction)),
+			)
+		}
+	}
+	return accepted, anomalies, nil
+}
+
+// GetAnomalies returns the suspicious data points awaiting review, optionally filtered by symbol and status.
+// The page size and page number are optional and default to defaultDataPointLimit and 1 respectively if not provided.
+func (s *DefaultService) GetAnomalies(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, *int, error) {
+	if payload.PageSize.Valid && payload.PageSize.Int64 <= 0 {
+		return nil, nil, E.NewErrInvalidArgument("page size must be greater than 0")
+	}
+	if payload.PageNumber.Valid && payload.PageNumber.Int64 <= 0 {
+		return nil, nil, E.NewErrInvalidArgument("page number must be greater than 0")
+	}
+	if !payload.PageSize.Valid {
+		payload.PageSize = null.NewInt(s.defaulDataPointLimit)
+	}
+	if !payload.PageNumber.Valid {
+		payload.PageNumber = null.NewInt(1)
+	}
+
+	anomalies, err := s.repository.GetAnomalies(ctx, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return anomalies, payload.PageNumber.AsRef(), nil
+}
+
+// AcceptAnomaly marks a suspicious data point as sound. A quarantined data point is inserted into the repository and its
+// rollups are updated, a flagged data point is already stored and is only removed from the review list.
+// The writes are made in a single transaction.
+func (s *DefaultService) AcceptAnomaly(ctx context.Context, id int64) error {
+	return s.repository.WithTransaction(ctx, func(repo repository.Repository) error {
+		anomaly, err := repo.GetAnomaly(ctx, id)
+		if err != nil {
+			return err
+		}
+		if anomaly.Status == data.AnomalyStatusQuarantined {
+			err = repo.InsertDataPoints(ctx, []data.OHLCEntity{anomaly.ToOHLCEntity()})
+			if err != nil {
+				return err
+			}
+			err = s.updateRollups(ctx, repo, touchedRangesOf([]data.OHLCEntity{anomaly.ToOHLCEntity()}))
+			if err != nil {
+				return err
+			}
+		}
+		return repo.DeleteAnomaly(ctx, id)
+	})
+}
+
+// DeleteAnomaly discards a suspicious data point. A flagged data point is also removed from the stored data points
+// and its rollups are updated. The writes are made in a single transaction.
+func (s *DefaultService) DeleteAnomaly(ctx context.Context, id int64) error {
+	return s.repository.WithTransaction(ctx, func(repo repository.Repository) error {
+		anomaly, err := repo.GetAnomaly(ctx, id)
+		if err != nil {
+			return err
+		}
+		if anomaly.Status == data.AnomalyStatusFlagged {
+			err = repo.DeleteDataPoint(ctx, anomaly.Symbol, anomaly.Time)
+			if err != nil {
+				return err
+			}
+			err = s.updateRollups(ctx, repo, touchedRangesOf([]data.OHLCEntity{anomaly.ToOHLCEntity()}))
+			if err != nil {
+				return err
+			}
+		}
+		return repo.DeleteAnomaly(ctx, id)
+	})
+}
@@ -0,0 +1,181 @@
+package ohlc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"go.uber.org/zap"
+)
+
+func TestDefaultService_screenDataPoints(t *testing.T) {
+	start := time.Unix(1610000000, 0)
+	candle := func(i int, p float64) data.OHLCEntity {
+		return data.OHLCEntity{
+			Symbol: "BTC",
+			Time:   start.Add(time.Duration(i) * time.Minute),
+			Open:   price(p),
+			High:   price(p),
+			Low:    price(p),
+			Close:  price(p),
+		}
+	}
+	history := []data.OHLCEntity{candle(-3, 100), candle(-2, 101), candle(-1, 99)}
+
+	tests := []struct {
+		name          string
+		action        data.AnomalyAction
+		points        []data.OHLCEntity
+		wantAccepted  int
+		wantAnomalies []data.AnomalyStatus
+	}{
+		{
+			name:         "sound data points",
+			action:       data.AnomalyActionQuarantine,
+			points:       []data.OHLCEntity{candle(0, 100), candle(1, 102), candle(2, 101)},
+			wantAccepted: 3,
+		},
+		{
+			name:          "spike is quarantined",
+			action:        data.AnomalyActionQuarantine,
+			points:        []data.OHLCEntity{candle(0, 100), candle(1, 10000), candle(2, 101)},
+			wantAccepted:  2,
+			wantAnomalies: []data.AnomalyStatus{data.AnomalyStatusQuarantined},
+		},
+		{
+			name:          "spike is flagged",
+			action:        data.AnomalyActionFlag,
+			points:        []data.OHLCEntity{candle(0, 100), candle(1, 10000), candle(2, 101)},
+			wantAccepted:  3,
+			wantAnomalies: []data.AnomalyStatus{data.AnomalyStatusFlagged},
+		},
+		{
+			name:         "spike is rejected",
+			action:       data.AnomalyActionReject,
+			points:       []data.OHLCEntity{candle(0, 100), candle(1, 10000), candle(2, 101)},
+			wantAccepted: 2,
+		},
+		{
+			name:          "inconsistent prices are quarantined",
+			action:        data.AnomalyActionQuarantine,
+			points:        []data.OHLCEntity{{Symbol: "BTC", Time: start, Open: price(100), High: price(90), Low: price(80), Close: price(100)}},
+			wantAccepted:  0,
+			wantAnomalies: []data.AnomalyStatus{data.AnomalyStatusQuarantined},
+		},
+		{
+			name:         "detection is off",
+			action:       data.AnomalyActionOff,
+			points:       []data.OHLCEntity{candle(0, 100), candle(1, 10000), candle(2, 101)},
+			wantAccepted: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx            = context.Background()
+				logger         = zap.NewNop()
+				mockS3Client   = &s3.ClientMock{}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{
+					GetLatestDataPointsFunc: func(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error) {
+						return history, nil
+					},
+				}
+			)
+			conf := config.Init()
+			conf.OHLCConfig.AnomalyAction = string(tt.action)
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			accepted, anomalies, err := s.screenDataPoints(ctx, mockRepository, tt.points)
+			require.NoError(t, err)
+			assert.Len(t, accepted, tt.wantAccepted)
+			require.Len(t, anomalies, len(tt.wantAnomalies))
+			for i, status := range tt.wantAnomalies {
+				assert.Equal(t, status, anomalies[i].Status)
+				assert.NotEmpty(t, anomalies[i].Reason)
+			}
+		})
+	}
+}
+
+func TestDefaultService_reviewAnomaly(t *testing.T) {
+	ctx := context.Background()
+	anomaly := data.AnomalyEntity{
+		ID:     1,
+		Symbol: "BTC",
+		Time:   time.Unix(1610000000, 0),
+		Open:   price(100),
+		High:   price(100),
+		Low:    price(100),
+		Close:  price(100),
+	}
+	errDelete := errors.New("delete failed")
+
+	tests := []struct {
+		name   string
+		status data.AnomalyStatus
+		review func(s *DefaultService) error
+		want   func(t *testing.T, tx *repository.RepositoryMock)
+	}{
+		{
+			name:   "accept quarantined",
+			status: data.AnomalyStatusQuarantined,
+			review: func(s *DefaultService) error { return s.AcceptAnomaly(ctx, anomaly.ID) },
+			want: func(t *testing.T, tx *repository.RepositoryMock) {
+				assert.Len(t, tx.InsertDataPointsCalls(), 1)
+			},
+		},
+		{
+			name:   "delete flagged",
+			status: data.AnomalyStatusFlagged,
+			review: func(s *DefaultService) error { return s.DeleteAnomaly(ctx, anomaly.ID) },
+			want: func(t *testing.T, tx *repository.RepositoryMock) {
+				assert.Len(t, tx.DeleteDataPointCalls(), 1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &repository.RepositoryMock{
+				GetAnomalyFunc: func(ctx context.Context, id int64) (*data.AnomalyEntity, error) {
+					a := anomaly
+					a.Status = tt.status
+					return &a, nil
+				},
+				InsertDataPointsFunc: func(ctx context.Context, points []data.OHLCEntity) error {
+					return nil
+				},
+				DeleteDataPointFunc: func(ctx context.Context, symbol string, t time.Time) error {
+					return nil
+				},
+				DeleteAnomalyFunc: func(ctx context.Context, id int64) error {
+					return errDelete
+				},
+			}
+			mockRepository := &repository.RepositoryMock{
+				WithTransactionFunc: func(ctx context.Context, fn func(repository.Repository) error) error {
+					return fn(tx)
+				},
+			}
+			conf := config.Init()
+			conf.OHLCConfig.RollupIntervals = nil
+			s := newService(t, zap.NewNop(), mockRepository, &s3.ClientMock{}, &sqs.ClientMock{}, &webhook.ClientMock{}, conf.OHLCConfig)
+
+			err := tt.review(s)
+			assert.ErrorIs(t, err, errDelete)
+			assert.Len(t, mockRepository.WithTransactionCalls(), 1)
+			assert.Len(t, tx.DeleteAnomalyCalls(), 1)
+			tt.want(t, tx)
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package ohlc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	E "github.com/teezzan/candles/internal/errors"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+// consumerRetryDelay is the delay before polling the SQS queue again after a failed receive.
+const consumerRetryDelay = 5 * time.Second
+
+// ConsumeSQSMessages continuously long polls the SQS queue for uploaded files and processes them with a pool of
+// `workerCount` workers. Messages are only received for the idle workers, so that files wait in the queue rather
+// than in memory while every worker is busy. The S3 event of a message is validated, see filenamesOf, and the
+// message is deleted once its files are handed to workers or ignored, see dispatch.
+// It returns once ctx is done and the files being processed are finished. The files still being processed
+// after `workerShutdownTimeout` are cancelled, so that they are recorded as cancelled and can be retried.
+func (s *DefaultService) ConsumeSQSMessages(ctx context.Context) error {
+	pool := newWorkerPool(s.workerCount)
+	defer s.stopWorkers(pool)
+
+	for {
+		if !pool.acquire(ctx) {
+			return nil
+		}
+		messages, err := s.sqsClient.ReceiveMessages(ctx, pool.idle()+1)
+		if err != nil {
+			pool.release()
+			if ctx.Err() != nil {
+				return nil
+			}
+			s.logger.Error("failed to receive SQS messages", zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(consumerRetryDelay):
+			}
+			continue
+		}
+
+		acquired := true
+		for _, message := range messages {
+			dispatched := true
+			for _, filename := range s.filenamesOf(uncancelled(ctx), message) {
+				if !acquired && !pool.acquire(ctx) {
+					// The message is received again once its visibility timeout elapses.
+					return nil
+				}
+				acquired = false
+				err = s.dispatch(pool, filename)
+				if err != nil {
+					s.logger.Error("failed to dispatch file", zap.String("filename", filename), zap.Error(err))
+					dispatched = false
+				}
+			}
+			if !dispatched {
+				// The message is received again once its visibility timeout elapses.
+				continue
+			}
+			err = s.sqsClient.DeleteMessages(uncancelled(ctx), []string{message.ReceiptHandle})
+			if err != nil {
+				s.logger.Error("failed to delete SQS message", zap.Error(err))
+			}
+		}
+		if acquired {
+			pool.release()
+		}
+	}
+}
+
+// filenamesOf returns the files whose upload is notified by the S3 event of a message, named after their key without
+// the upload key prefix. The records of other events,
+// buckets or keys are rejected, and the messages with rejected records or not carrying an S3 event are recorded
+// for inspection. S3 test events are ignored.
+func (s *DefaultService) filenamesOf(ctx context.Context, message sqs.Message) []string {
+	event, err := sqs.ParseEvent(message.Body)
+	if err != nil {
+		s.rejectMessage(ctx, message, fmt.Sprintf("malformed S3 event: %v", err))
+		return nil
+	}
+	if event.TestEvent {
+		s.logger.Info("ignoring S3 test event", zap.String("message_id", message.ID))
+		return nil
+	}
+
+	var filenames, reasons []string
+	for i, record := range event.Records {
+		if reason := s.checkRecord(record); reason != "" {
+			reasons = append(reasons, fmt.Sprintf("record %d: %s", i, reason))
+			continue
+		}
+		filenames = append(filenames, strings.TrimPrefix(record.Key, s.uploadKeyPrefix))
+	}
+	if len(event.Records) == 0 {
+		reasons = append(reasons, "no records")
+	}
+	if len(reasons) > 0 {
+		s.rejectMessage(ctx, message, strings.Join(reasons, "; "))
+	}
+	return filenames
+}
+
+// checkRecord returns why a record of an S3 event is rejected, or an empty string if it notifies an uploaded file.
+func (s *DefaultService) checkRecord(record sqs.Record) string {
+	switch {
+	case record.EventSource != "aws:s3":
+		return fmt.Sprintf("unexpected event source %q", record.EventSource)
+	case !record.IsObjectCreated():
+		return fmt.Sprintf("unexpected event %q", record.EventName)
+	case record.Bucket != s.s3Client.Bucket():
+		return fmt.Sprintf("unexpected bucket %q", record.Bucket)
+	case !strings.HasPrefix(record.Key, s.uploadKeyPrefix) || !strings.HasSuffix(record.Key, s.uploadKeySuffix):
+		return fmt.Sprintf("key %q does not match the upload key filter", record.Key)
+	case record.Size <= 0:
+		return fmt.Sprintf("empty object %q", record.Key)
+	}
+	return ""
+}
+
+// rejectMessage records a rejected message for inspection.
+func (s *DefaultService) rejectMessage(ctx context.Context, message sqs.Message, reason string) {
+	s.logger.Warn("rejecting SQS message", zap.String("message_id", message.ID), zap.String("reason", reason))
+	err := s.repository.InsertRejectedMessage(ctx, data.RejectedMessageEntity{
+		MessageID: message.ID,
+		Body:      message.Body,
+		Reason:    reason,
+	})
+	if err != nil {
+		s.logger.Error("failed to record rejected SQS message", zap.String("message_id", message.ID), zap.Error(err))
+	}
+}
+
+// GetRejectedMessages returns the SQS messages rejected by the consumer, most recent first.
+// The page size and page number are optional and default to defaultDataPointLimit and 1 respectively if not provided.
+func (s *DefaultService) GetRejectedMessages(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, *int, error) {
+	if payload.PageSize.Valid && payload.PageSize.Int64 <= 0 {
+		return nil, nil, E.NewErrInvalidArgument("page size must be greater than 0")
+	}
+	if payload.PageNumber.Valid && payload.PageNumber.Int64 <= 0 {
+		return nil, nil, E.NewErrInvalidArgument("page number must be greater than 0")
+	}
+	if !payload.PageSize.Valid {
+		payload.PageSize = null.NewInt(s.defaulDataPointLimit)
+	}
+	if !payload.PageNumber.Valid {
+		payload.PageNumber = null.NewInt(1)
+	}
+
+	messages, err := s.repository.GetRejectedMessages(ctx, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return messages, payload.PageNumber.AsRef(), nil
+}
+
+// dispatch queues a file uploaded to S3 and processes it on an acquired worker of the pool. Only the files awaiting
+// upload, queued or without status are processed, so that a redelivered message does not process a file again.
+// It returns an error if the status of the file cannot be read, in which case the worker is released.
+func (s *DefaultService) dispatch(pool *workerPool, filename string) error {
+	ctx := context.Background()
+	status, err := s.repository.GetProcessingStatus(ctx, filename)
+	if err != nil && !E.IsErrEntityNotFound(err) {
+		pool.release()
+		return err
+	}
+	if err == nil && status.Status != data.ProcessingStatusAwaitingUpload && status.Status != data.ProcessingStatusQueued {
+		s.logger.Info("ignoring file already processed", zap.String("filename", filename), zap.String("status", string(status.Status)))
+		pool.release()
+		return nil
+	}
+
+	err = s.repository.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{
+		FileName: filename,
+		Status:   data.ProcessingStatusQueued,
+	})
+	if err != nil {
+		s.logger.Warn("failed to queue file", zap.String("filename", filename), zap.Error(err))
+	}
+	s.publishUpload(ctx, filename, data.UploadEventTypeStatus)
+
+	jobCtx, cancel, ok := s.claimJob(filename)
+	if !ok {
+		s.logger.Warn("file is already being processed", zap.String("filename", filename))
+		pool.release()
+		return nil
+	}
+	pool.run(filename, func() {
+		s.runJob(jobCtx, cancel, filename, data.ProcessingStatusInProgress)
+	})
+	return nil
+}
+
+// stopWorkers waits for the files being processed by the pool, cancelling them after `workerShutdownTimeout`.
+func (s *DefaultService) stopWorkers(pool *workerPool) {
+	select {
+	case <-pool.wait():
+		return
+	case <-time.After(s.workerShutdownTimeout):
+	}
+	for _, filename := range pool.running() {
+		s.logger.Warn("cancelling file still being processed on shutdown", zap.String("filename", filename))
+		s.jobs.cancel(filename)
+	}
+	<-pool.wait()
+}
+
+// workerPool bounds the number of files processed at the same time.
+type workerPool struct {
+	slots chan struct{}
+	wg    sync.WaitGroup
+
+	mu        sync.Mutex
+	filenames map[string]struct{}
+}
+
+// newWorkerPool initializes a pool of `size` workers.
+func newWorkerPool(size int) *workerPool {
+	if size < 1 {
+		size = 1
+	}
+	return &workerPool{
+		slots:     make(chan struct{}, size),
+		filenames: map[string]struct{}{},
+	}
+}
+
+// acquire waits for an idle worker and reserves it. It returns false if ctx is done first.
+func (p *workerPool) acquire(ctx context.Context) bool {
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees a reserved worker.
+func (p *workerPool) release() {
+	<-p.slots
+}
+
+// idle returns the number of idle workers.
+func (p *workerPool) idle() int {
+	return cap(p.slots) - len(p.slots)
+}
+
+// run processes a file on a reserved worker in the background, and frees the worker once done.
+func (p *workerPool) run(filename string, fn func()) {
+	p.mu.Lock()
+	p.filenames[filename] = struct{}{}
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer p.release()
+		defer func() {
+			p.mu.Lock()
+			delete(p.filenames, filename)
+			p.mu.Unlock()
+		}()
+		fn()
+	}()
+}
+
+// running returns the files being processed.
+func (p *workerPool) running() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	filenames := make([]string, 0, len(p.filenames))
+	for filename := range p.filenames {
+		filenames = append(filenames, filename)
+	}
+	return filenames
+}
+
+// wait returns a channel closed once the files being processed are finished.
+func (p *workerPool) wait() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	return done
+}
@@ -0,0 +1,283 @@
+package ohlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"go.uber.org/zap"
+)
+
+// s3Event returns the body of a message notifying an S3 event on an object of 1KB.
+func s3Event(eventName string, bucket string, key string) string {
+	return fmt.Sprintf(
+		`{"Records":[{"eventSource":"aws:s3","eventName":%q,"s3":{"bucket":{"name":%q},"object":{"key":%q,"size":1024}}}]}`,
+		eventName, bucket, key,
+	)
+}
+
+func TestDefaultService_ConsumeSQSMessages(t *testing.T) {
+	tests := []struct {
+		name             string
+		shutdownTimeout  int
+		finishOnShutdown bool
+		wantStatus       data.ProcessingStatus
+	}{
+		{
+			name:             "files being processed are waited for",
+			shutdownTimeout:  60,
+			finishOnShutdown: true,
+			wantStatus:       data.ProcessingStatusCompleted,
+		},
+		{
+			name:            "files still being processed are cancelled",
+			shutdownTimeout: 0,
+			wantStatus:      data.ProcessingStatusCancelled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx, cancel  = context.WithCancel(context.Background())
+				logger       = zap.NewNop()
+				finish       = make(chan struct{})
+				receives     = make(chan int, 2)
+				mockS3Client = &s3.ClientMock{
+					BucketFunc: func() string {
+						return "bucket"
+					},
+					OpenObjectFunc: func(ctx context.Context, objectKey string) (*s3.Object, error) {
+						select {
+						case <-finish:
+							return &s3.Object{Body: io.NopCloser(strings.NewReader(validCSV)), Size: int64(len(validCSV))}, nil
+						case <-ctx.Done():
+							return nil, ctx.Err()
+						}
+					},
+				}
+				mockSQSClient = &sqs.ClientMock{
+					DeleteMessagesFunc: func(ctx context.Context, messageHandles []string) error {
+						return nil
+					},
+				}
+				mockRepository = &repository.RepositoryMock{
+					InsertProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+						return nil
+					},
+					InsertDataPointsFunc: func(ctx context.Context, rows []data.OHLCEntity) error {
+						return nil
+					},
+					GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+						return &data.ProcessingStatusEntity{FileName: fileName, Status: data.ProcessingStatusQueued}, nil
+					},
+					UpdateProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+						return nil
+					},
+					UpdateProcessingProgressFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+						return nil
+					},
+				}
+			)
+			defer cancel()
+			mockSQSClient.ReceiveMessagesFunc = func(ctx context.Context, maxMessages int) ([]sqs.Message, error) {
+				receives <- maxMessages
+				if len(mockSQSClient.ReceiveMessagesCalls()) == 1 {
+					return []sqs.Message{{ReceiptHandle: "handle", Body: s3Event("ObjectCreated:Put", "bucket", "test.csv")}}, nil
+				}
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			mockRepository.WithTransactionFunc = func(ctx context.Context, fn func(repository.Repository) error) error {
+				return fn(mockRepository)
+			}
+			conf := config.Init()
+			conf.OHLCConfig.RollupIntervals = nil
+			conf.OHLCConfig.WorkerCount = 2
+			conf.OHLCConfig.WorkerShutdownTimeoutInSeconds = tt.shutdownTimeout
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			done := make(chan error)
+			go func() {
+				done <- s.ConsumeSQSMessages(ctx)
+			}()
+
+			// Messages are received for every idle worker, and the busy worker is not polled for.
+			assert.Equal(t, 2, <-receives)
+			assert.Equal(t, 1, <-receives)
+			deletes := mockSQSClient.DeleteMessagesCalls()
+			require.Len(t, deletes, 1)
+			assert.Equal(t, []string{"handle"}, deletes[0].MessageHandles)
+
+			cancel()
+			if tt.finishOnShutdown {
+				close(finish)
+			}
+			require.NoError(t, <-done)
+
+			assert.Len(t, mockS3Client.OpenObjectCalls(), 1)
+			updates := mockRepository.UpdateProcessingStatusCalls()
+			require.NotEmpty(t, updates)
+			assert.Equal(t, tt.wantStatus, updates[len(updates)-1].Status.Status)
+		})
+	}
+}
+
+func TestDefaultService_filenamesOf(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		keyPrefix    string
+		want         []string
+		wantRejected bool
+	}{
+		{
+			name: "object created",
+			body: s3Event("ObjectCreated:Put", "bucket", "uploads/test%2B1.csv"),
+			want: []string{"uploads/test+1.csv"},
+		},
+		{
+			name:         "object removed",
+			body:         s3Event("ObjectRemoved:Delete", "bucket", "test.csv"),
+			wantRejected: true,
+		},
+		{
+			name:         "other bucket",
+			body:         s3Event("ObjectCreated:Put", "other", "test.csv"),
+			wantRejected: true,
+		},
+		{
+			name:      "key matching the filter",
+			body:      s3Event("ObjectCreated:Put", "bucket", "uploads/test.csv"),
+			keyPrefix: "uploads/",
+			want:      []string{"test.csv"},
+		},
+		{
+			name:         "key not matching the filter",
+			body:         s3Event("ObjectCreated:Put", "bucket", "exports/test.csv"),
+			keyPrefix:    "uploads/",
+			wantRejected: true,
+		},
+		{
+			name: "test event",
+			body: `{"Service":"Amazon S3","Event":"s3:TestEvent","Bucket":"bucket"}`,
+		},
+		{
+			name:         "malformed message",
+			body:         "hello",
+			wantRejected: true,
+		},
+		{
+			name: "valid and rejected records",
+			body: `{"Records":[` +
+				`{"eventSource":"aws:s3","eventName":"ObjectCreated:Put","s3":{"bucket":{"name":"bucket"},"object":{"key":"a.csv","size":1}}},` +
+				`{"eventSource":"aws:s3","eventName":"ObjectCreated:Put","s3":{"bucket":{"name":"bucket"},"object":{"key":"b.csv","size":0}}}` +
+				`]}`,
+			want:         []string{"a.csv"},
+			wantRejected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				logger       = zap.NewNop()
+				mockS3Client = &s3.ClientMock{
+					BucketFunc: func() string {
+						return "bucket"
+					},
+				}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{
+					InsertRejectedMessageFunc: func(ctx context.Context, message data.RejectedMessageEntity) error {
+						return nil
+					},
+				}
+			)
+			conf := config.Init()
+			conf.OHLCConfig.UploadKeyPrefix = tt.keyPrefix
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			got := s.filenamesOf(ctx, sqs.Message{ID: "id", Body: tt.body})
+			assert.Equal(t, tt.want, got)
+
+			rejected := mockRepository.InsertRejectedMessageCalls()
+			if !tt.wantRejected {
+				assert.Empty(t, rejected)
+				return
+			}
+			require.Len(t, rejected, 1)
+			assert.Equal(t, "id", rejected[0].Message.MessageID)
+			assert.Equal(t, tt.body, rejected[0].Message.Body)
+			assert.NotEmpty(t, rejected[0].Message.Reason)
+		})
+	}
+}
+
+func TestDefaultService_dispatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    data.ProcessingStatus
+		statusErr error
+		wantErr   bool
+	}{
+		{
+			name:   "completed file",
+			status: data.ProcessingStatusCompleted,
+		},
+		{
+			name:   "cancelled file",
+			status: data.ProcessingStatusCancelled,
+		},
+		{
+			name:   "rolled back file",
+			status: data.ProcessingStatusRolledBack,
+		},
+		{
+			name:      "unreadable status",
+			statusErr: errors.New("connection refused"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				logger         = zap.NewNop()
+				mockRepository = &repository.RepositoryMock{
+					GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+						if tt.statusErr != nil {
+							return nil, tt.statusErr
+						}
+						return &data.ProcessingStatusEntity{FileName: fileName, Status: tt.status}, nil
+					},
+				}
+				pool = newWorkerPool(1)
+			)
+			conf := config.Init()
+
+			s := newService(t, logger, mockRepository, &s3.ClientMock{}, &sqs.ClientMock{}, &webhook.ClientMock{}, conf.OHLCConfig)
+			require.True(t, pool.acquire(context.Background()))
+			err := s.dispatch(pool, "test.csv")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// The file is not processed again and the worker is released.
+			assert.Empty(t, mockRepository.InsertProcessingStatusCalls())
+			assert.Empty(t, pool.running())
+			assert.Equal(t, 1, pool.idle())
+		})
+	}
+}
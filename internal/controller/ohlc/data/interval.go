@@ -0,0 +1,45 @@
+package data
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	E "github.com/teezzan/candles/internal/errors"
+)
+
+// intervalUnits defines the units supported on top of time.ParseDuration.
+var intervalUnits = map[string]time.Duration{
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// ParseInterval parses a candle interval such as `1m`, `4h` or `1d`.
+// It accepts every unit supported by time.ParseDuration as well as days (`d`) and weeks (`w`).
+// It returns an error if the interval is malformed or not positive.
+func ParseInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, E.NewErrInvalidArgument("interval is required")
+	}
+
+	var d time.Duration
+	if unit, ok := intervalUnits[s[len(s)-1:]]; ok {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, E.NewErrInvalidArgument("invalid interval")
+		}
+		d = time.Duration(n) * unit
+	} else {
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, E.NewErrInvalidArgument("invalid interval")
+		}
+		d = v
+	}
+
+	if d < time.Second {
+		return 0, E.NewErrInvalidArgument("interval must be at least 1s")
+	}
+	return d, nil
+}
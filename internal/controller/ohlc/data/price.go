@@ -0,0 +1,91 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	E "github.com/teezzan/candles/internal/errors"
+)
+
+const (
+	// MaxPricePrecision is the number of decimal places of the stored prices, the scale of their DECIMAL(20,10) columns.
+	MaxPricePrecision = 10
+	// maxPriceDigits is the number of integer digits of the stored prices.
+	maxPriceDigits = 10
+)
+
+// maxPrice is the exclusive upper bound of the absolute value of the stored prices.
+var maxPrice = decimal.New(1, maxPriceDigits)
+
+// ParsePrice parses a price without going through a floating-point number, so that it keeps every decimal place.
+// It returns an error if the price is malformed or too large to be stored.
+func ParsePrice(s string) (decimal.Decimal, error) {
+	price, err := decimal.NewFromString(strings.TrimSpace(s))
+	if err != nil {
+		return decimal.Zero, E.NewErrInvalidArgument(fmt.Sprintf("invalid price %q", s))
+	}
+	if price.Abs().GreaterThanOrEqual(maxPrice) {
+		return decimal.Zero, E.NewErrInvalidArgument(fmt.Sprintf("price %q has more than %d integer digits", s, maxPriceDigits))
+	}
+	return price, nil
+}
+
+// PricePrecisionRule rounds the prices of the symbols starting with a prefix to a number of decimal places.
+type PricePrecisionRule struct {
+	Prefix    string
+	Precision int32
+}
+
+// PricePrecisions defines the number of decimal places the prices of each symbol are rounded to.
+type PricePrecisions struct {
+	rules []PricePrecisionRule
+}
+
+// ParsePricePrecisions parses price precision rules such as `BTC=8` or `EUR=4`. A rule applies to the symbols
+// starting with its prefix, an empty prefix applying to every symbol. The precision is at most MaxPricePrecision,
+// which is also the precision of the symbols without rule.
+func ParsePricePrecisions(rules []string) (*PricePrecisions, error) {
+	precisions := &PricePrecisions{}
+	seen := map[string]bool{}
+	for _, s := range rules {
+		prefix, precision, ok := strings.Cut(strings.TrimSpace(s), "=")
+		if !ok {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("invalid price precision rule %q", s))
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(precision))
+		if err != nil || n < 0 || n > MaxPricePrecision {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("invalid precision of price precision rule %q", s))
+		}
+		prefix = strings.TrimSpace(prefix)
+		if seen[prefix] {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("duplicate price precision rule %q", s))
+		}
+		seen[prefix] = true
+		precisions.rules = append(precisions.rules, PricePrecisionRule{Prefix: prefix, Precision: int32(n)})
+	}
+	return precisions, nil
+}
+
+// Precision returns the number of decimal places the prices of a symbol are rounded to.
+// The rule with the longest prefix the symbol starts with applies.
+func (p *PricePrecisions) Precision(symbol string) int32 {
+	precision := int32(MaxPricePrecision)
+	prefix := -1
+	for _, rule := range p.rules {
+		if strings.HasPrefix(symbol, rule.Prefix) && len(rule.Prefix) > prefix {
+			precision = rule.Precision
+			prefix = len(rule.Prefix)
+		}
+	}
+	return precision
+}
+
+// Round rounds the prices of the data point to the given number of decimal places, half away from zero.
+func (p *OHLCEntity) Round(precision int32) {
+	p.Open = p.Open.Round(precision)
+	p.High = p.High.Round(precision)
+	p.Low = p.Low.Round(precision)
+	p.Close = p.Close.Round(precision)
+}
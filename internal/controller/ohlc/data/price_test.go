@@ -0,0 +1,160 @@
+package data
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		price   string
+		wantErr bool
+		want    string
+	}{
+		{
+			name:  "decimal places kept",
+			price: "0.30000000000000004",
+			want:  "0.30000000000000004",
+		},
+		{
+			name:  "surrounding spaces",
+			price: " 68123.12345678 ",
+			want:  "68123.12345678",
+		},
+		{
+			name:  "largest price",
+			price: "-9999999999.9999999999",
+			want:  "-9999999999.9999999999",
+		},
+		{
+			name:    "too many integer digits",
+			price:   "10000000000",
+			wantErr: true,
+		},
+		{
+			name:    "malformed price",
+			price:   "1.2.3",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePrice(tt.price)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestParsePricePrecisions(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []string
+		wantErr bool
+		want    map[string]int32
+	}{
+		{
+			name: "no rules",
+			want: map[string]int32{"BTC/USD": MaxPricePrecision},
+		},
+		{
+			name:  "longest prefix applies",
+			rules: []string{"=4", "BTC=8", "BTC/EUR = 2"},
+			want:  map[string]int32{"BTC/USD": 8, "BTC/EUR": 2, "ETH/USD": 4},
+		},
+		{
+			name:    "missing precision",
+			rules:   []string{"BTC"},
+			wantErr: true,
+		},
+		{
+			name:    "precision too large",
+			rules:   []string{"BTC=11"},
+			wantErr: true,
+		},
+		{
+			name:    "negative precision",
+			rules:   []string{"BTC=-1"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate prefix",
+			rules:   []string{"BTC=8", "BTC=2"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePricePrecisions(tt.rules)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for symbol, precision := range tt.want {
+				assert.Equal(t, precision, got.Precision(symbol), symbol)
+			}
+		})
+	}
+}
+
+func TestOHLCEntity_Round(t *testing.T) {
+	p := OHLCEntity{
+		Open:  decimal.RequireFromString("0.125"),
+		High:  decimal.RequireFromString("0.135"),
+		Low:   decimal.RequireFromString("-0.125"),
+		Close: decimal.RequireFromString("0.1"),
+	}
+	p.Round(2)
+	assert.Equal(t, "0.13", p.Open.String())
+	assert.Equal(t, "0.14", p.High.String())
+	assert.Equal(t, "-0.13", p.Low.String())
+	assert.Equal(t, "0.1", p.Close.String())
+}
+
+func TestOHLCEntity_ToOHLC_json(t *testing.T) {
+	p := OHLCEntity{
+		Time:   time.Unix(1610000000, 0),
+		Symbol: "BTC/USD",
+		Open:   decimal.RequireFromString("0.3"),
+		High:   decimal.RequireFromString("68123.12345678"),
+		Low:    decimal.RequireFromString("0.00000001"),
+		Close:  decimal.RequireFromString("12.3456789012"),
+	}
+	got, err := json.Marshal(p.ToOHLC())
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `"open":0.3,"high":68123.12345678,"close":12.3456789012,"low":0.00000001`)
+}
+
+func TestAnomalyEntity_json(t *testing.T) {
+	a := NewAnomalyEntity(OHLCEntity{
+		Time:   time.Unix(1610000000, 0).UTC(),
+		Symbol: "BTC/USD",
+		Open:   decimal.RequireFromString("0.3"),
+		High:   decimal.RequireFromString("68123.12345678"),
+		Low:    decimal.RequireFromString("0.00000001"),
+		Close:  decimal.RequireFromString("12.3456789012"),
+	}, "price jump", AnomalyStatusQuarantined)
+	got, err := json.Marshal(a)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `"symbol":"BTC/USD"`)
+	assert.Contains(t, string(got), `"reason":"price jump"`)
+	assert.Contains(t, string(got), `"open":0.3,"high":68123.12345678,"low":0.00000001,"close":12.3456789012`)
+	assert.Equal(t, 1, strings.Count(string(got), `"open"`))
+
+	// Decimals keep their default encoding outside of the JSON responses.
+	got, err = json.Marshal(decimal.RequireFromString("0.3"))
+	require.NoError(t, err)
+	assert.Equal(t, `"0.3"`, string(got))
+}
@@ -0,0 +1,127 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	E "github.com/teezzan/candles/internal/errors"
+)
+
+// RetentionForever is the retention of the candles kept forever.
+const RetentionForever = "forever"
+
+// RetentionRule keeps the candles of a resolution for a period. A rule with a prefix only applies to the symbols
+// starting with it and overrides the rule without prefix of the same resolution.
+type RetentionRule struct {
+	Prefix     string
+	Resolution time.Duration
+	// Retention is zero if the candles are kept forever.
+	Retention time.Duration
+}
+
+// RetentionPolicy defines how long the candles of each resolution are kept. The finest resolution is the one of
+// the stored data points. Once expired, candles are downsampled into the next coarser resolution before being
+// removed, unless they have the coarsest resolution.
+type RetentionPolicy struct {
+	// Resolutions are the resolutions of the rules without prefix, finest first.
+	Resolutions []time.Duration
+	rules       []RetentionRule
+}
+
+// ParseRetentionPolicy parses retention rules such as `1m=90d`, `1d=forever` or `BTC/1m=30d`.
+// A resolution is a candle interval as parsed by ParseInterval, and a retention is either an interval, a number of
+// years (`5y`) or `forever`. Every resolution of a rule with a prefix must have a rule without prefix.
+// An empty policy keeps every candle forever.
+func ParseRetentionPolicy(rules []string) (*RetentionPolicy, error) {
+	policy := &RetentionPolicy{}
+	resolutions := map[time.Duration]bool{}
+	seen := map[string]bool{}
+	for _, s := range rules {
+		rule, err := parseRetentionRule(s)
+		if err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s/%d", rule.Prefix, rule.Resolution)
+		if seen[key] {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("duplicate retention rule %q", s))
+		}
+		seen[key] = true
+		if rule.Prefix == "" {
+			resolutions[rule.Resolution] = true
+			policy.Resolutions = append(policy.Resolutions, rule.Resolution)
+		}
+		policy.rules = append(policy.rules, rule)
+	}
+
+	for _, rule := range policy.rules {
+		if !resolutions[rule.Resolution] {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("no retention rule without prefix for the resolution of %s/%s", rule.Prefix, rule.Resolution))
+		}
+	}
+	sort.Slice(policy.Resolutions, func(i, j int) bool {
+		return policy.Resolutions[i] < policy.Resolutions[j]
+	})
+	for i := 1; i < len(policy.Resolutions); i++ {
+		if policy.Resolutions[i]%policy.Resolutions[i-1] != 0 {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("resolution %s is not a multiple of resolution %s", policy.Resolutions[i], policy.Resolutions[i-1]))
+		}
+	}
+	return policy, nil
+}
+
+// parseRetentionRule parses a single retention rule.
+func parseRetentionRule(s string) (RetentionRule, error) {
+	var rule RetentionRule
+	target, retention, ok := strings.Cut(strings.TrimSpace(s), "=")
+	if !ok {
+		return rule, E.NewErrInvalidArgument(fmt.Sprintf("invalid retention rule %q", s))
+	}
+	if prefix, resolution, ok := strings.Cut(target, "/"); ok {
+		rule.Prefix = prefix
+		target = resolution
+	}
+
+	var err error
+	rule.Resolution, err = ParseInterval(target)
+	if err != nil {
+		return rule, E.NewErrInvalidArgument(fmt.Sprintf("invalid resolution of retention rule %q", s))
+	}
+	rule.Retention, err = parseRetention(retention)
+	if err != nil {
+		return rule, E.NewErrInvalidArgument(fmt.Sprintf("invalid retention of retention rule %q", s))
+	}
+	return rule, nil
+}
+
+// parseRetention parses a retention, which is zero if the candles are kept forever.
+func parseRetention(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == RetentionForever {
+		return 0, nil
+	}
+	if strings.HasSuffix(s, "y") {
+		n, err := strconv.Atoi(strings.TrimSuffix(s, "y"))
+		if err != nil || n <= 0 {
+			return 0, E.NewErrInvalidArgument("invalid retention")
+		}
+		return time.Duration(n) * 365 * 24 * time.Hour, nil
+	}
+	return ParseInterval(s)
+}
+
+// Retention returns the retention of the candles of a symbol with the given resolution, zero meaning forever.
+// The rule with the longest prefix the symbol starts with applies.
+func (p *RetentionPolicy) Retention(symbol string, resolution time.Duration) time.Duration {
+	var retention time.Duration
+	prefix := -1
+	for _, rule := range p.rules {
+		if rule.Resolution == resolution && strings.HasPrefix(symbol, rule.Prefix) && len(rule.Prefix) > prefix {
+			retention = rule.Retention
+			prefix = len(rule.Prefix)
+		}
+	}
+	return retention
+}
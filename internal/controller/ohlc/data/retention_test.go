@@ -0,0 +1,75 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetentionPolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		rules           []string
+		wantErr         bool
+		wantResolutions []time.Duration
+	}{
+		{
+			name:            "no rules",
+			wantResolutions: nil,
+		},
+		{
+			name:            "resolutions sorted",
+			rules:           []string{"1d=forever", "1m=90d", "1h=5y", "BTC/1m=30d"},
+			wantResolutions: []time.Duration{time.Minute, time.Hour, 24 * time.Hour},
+		},
+		{
+			name:    "malformed rule",
+			rules:   []string{"1m"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid retention",
+			rules:   []string{"1m=ever"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate rule",
+			rules:   []string{"1m=90d", "1m=30d"},
+			wantErr: true,
+		},
+		{
+			name:    "prefix without default rule",
+			rules:   []string{"1m=90d", "BTC/1h=30d"},
+			wantErr: true,
+		},
+		{
+			name:    "resolution not a multiple of the finer one",
+			rules:   []string{"2m=90d", "3m=forever"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParseRetentionPolicy(tt.rules)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResolutions, policy.Resolutions)
+		})
+	}
+}
+
+func TestRetentionPolicy_Retention(t *testing.T) {
+	policy, err := ParseRetentionPolicy([]string{"1m=90d", "1h=5y", "1d=forever", "BTC/1m=30d", "BTCUSD/1m=forever"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 90*24*time.Hour, policy.Retention("ETH", time.Minute))
+	assert.Equal(t, 30*24*time.Hour, policy.Retention("BTCEUR", time.Minute))
+	assert.Equal(t, time.Duration(0), policy.Retention("BTCUSD", time.Minute), "the longest prefix applies")
+	assert.Equal(t, 5*365*24*time.Hour, policy.Retention("BTCEUR", time.Hour))
+	assert.Equal(t, time.Duration(0), policy.Retention("ETH", 24*time.Hour))
+}
@@ -0,0 +1,41 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	E "github.com/teezzan/candles/internal/errors"
+)
+
+// ParseRollupIntervals parses the intervals of the maintained rollups, such as `5m`, `1h` and `1d`, and returns them
+// finest first. Every interval must be a whole number of seconds and a multiple of the finer intervals, so that the
+// rollups of an interval can be aggregated from the ones of the previous interval.
+func ParseRollupIntervals(intervals []string) ([]time.Duration, error) {
+	parsed := []time.Duration{}
+	seen := map[time.Duration]bool{}
+	for _, s := range intervals {
+		interval, err := ParseInterval(s)
+		if err != nil {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("invalid rollup interval %q", s))
+		}
+		if interval%time.Second != 0 {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("rollup interval %q is not a whole number of seconds", s))
+		}
+		if seen[interval] {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("duplicate rollup interval %q", s))
+		}
+		seen[interval] = true
+		parsed = append(parsed, interval)
+	}
+
+	sort.Slice(parsed, func(i, j int) bool {
+		return parsed[i] < parsed[j]
+	})
+	for i := 1; i < len(parsed); i++ {
+		if parsed[i]%parsed[i-1] != 0 {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("rollup interval %s is not a multiple of rollup interval %s", parsed[i], parsed[i-1]))
+		}
+	}
+	return parsed, nil
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRollupIntervals(t *testing.T) {
+	tests := []struct {
+		name      string
+		intervals []string
+		wantErr   bool
+		want      []time.Duration
+	}{
+		{
+			name: "no intervals",
+			want: []time.Duration{},
+		},
+		{
+			name:      "intervals sorted",
+			intervals: []string{"1d", "5m", "1h"},
+			want:      []time.Duration{5 * time.Minute, time.Hour, 24 * time.Hour},
+		},
+		{
+			name:      "invalid interval",
+			intervals: []string{"5x"},
+			wantErr:   true,
+		},
+		{
+			name:      "fractional seconds",
+			intervals: []string{"1500ms"},
+			wantErr:   true,
+		},
+		{
+			name:      "duplicate interval",
+			intervals: []string{"1h", "60m"},
+			wantErr:   true,
+		},
+		{
+			name:      "interval not a multiple of the finer one",
+			intervals: []string{"2m", "3m"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRollupIntervals(tt.intervals)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,60 +1,227 @@
 package data
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/teezzan/candles/internal/null"
 )
 
 // Project defines the ohlc project DTO
 type OHLC struct {
-	Time   int64   `json:"unix"`
-	Symbol string  `json:"symbol"`
-	Open   float64 `json:"open"`
-	High   float64 `json:"high"`
-	Close  float64 `json:"close"`
-	Low    float64 `json:"low"`
+	Time      int64        `json:"unix"`
+	Symbol    string       `json:"symbol"`
+	Open      null.Decimal `json:"open" swaggertype:"number"`
+	High      null.Decimal `json:"high" swaggertype:"number"`
+	Close     null.Decimal `json:"close" swaggertype:"number"`
+	Low       null.Decimal `json:"low" swaggertype:"number"`
+	Synthetic bool         `json:"synthetic,omitempty"`
 }
 
 // OHLCEntity defines the ohlc data.
 type OHLCEntity struct {
-	ID     int64     `db:"id"`
-	Time   time.Time `db:"time"`
-	Symbol string    `db:"symbol"`
-	Open   float64   `db:"open"`
-	High   float64   `db:"high"`
-	Close  float64   `db:"close"`
-	Low    float64   `db:"low"`
+	ID         int64           `db:"id"`
+	Time       time.Time       `db:"time"`
+	Symbol     string          `db:"symbol"`
+	Open       decimal.Decimal `db:"open"`
+	High       decimal.Decimal `db:"high"`
+	Close      decimal.Decimal `db:"close"`
+	Low        decimal.Decimal `db:"low"`
+	SourceFile null.String     `db:"source_file"`
+	Synthetic  bool            `db:"-"`
 }
 
 // OHLCEntity converts OHLCEntity to OHLC
+// Synthetic placeholders carry no prices and are converted with null prices.
 func (p *OHLCEntity) ToOHLC() OHLC {
-	return OHLC{
-		Time:   p.Time.Unix(),
-		Symbol: p.Symbol,
-		Open:   p.Open,
-		High:   p.High,
-		Close:  p.Close,
-		Low:    p.Low,
+	o := OHLC{
+		Time:      p.Time.Unix(),
+		Symbol:    p.Symbol,
+		Synthetic: p.Synthetic,
 	}
+	if p.Synthetic && p.IsPlaceholder() {
+		return o
+	}
+	o.Open = null.NewDecimal(p.Open)
+	o.High = null.NewDecimal(p.High)
+	o.Close = null.NewDecimal(p.Close)
+	o.Low = null.NewDecimal(p.Low)
+	return o
+}
+
+// IsPlaceholder returns true if the OHLCEntity carries no prices.
+func (p *OHLCEntity) IsPlaceholder() bool {
+	return p.Open.IsZero() && p.High.IsZero() && p.Close.IsZero() && p.Low.IsZero()
 }
 
 // IsInComplete returns true if the OHLCEntity is incomplete.
 func (p *OHLCEntity) IsInComplete() bool {
-	return p.Time.IsZero() || p.Symbol == "" || p.Open == 0 || p.High == 0 || p.Close == 0 || p.Low == 0
+	return p.Time.IsZero() || p.Symbol == "" || p.Open.IsZero() || p.High.IsZero() || p.Close.IsZero() || p.Low.IsZero()
 }
 
 // ProcessingStatus defines the uploaded file processing status.
 type ProcessingStatus string
 
+// IsTerminal returns true if the file is no longer processed in the ProcessingStatus.
+func (p ProcessingStatus) IsTerminal() bool {
+	switch p {
+	case ProcessingStatusCompleted, ProcessingStatusFailed, ProcessingStatusCancelled, ProcessingStatusRolledBack:
+		return true
+	}
+	return false
+}
+
+// IsValid returns true if the ProcessingStatus is known.
+func (p ProcessingStatus) IsValid() bool {
+	switch p {
+	case ProcessingStatusAwaitingUpload, ProcessingStatusQueued, ProcessingStatusInProgress, ProcessingStatusRetrying,
+		ProcessingStatusCompleted, ProcessingStatusFailed, ProcessingStatusCancelled, ProcessingStatusRolledBack:
+		return true
+	}
+	return false
+}
+
 // ProcessingStatusEntity defines the uploaded file processing status entity.
+// The progress fields are updated periodically while the file is being ingested.
 type ProcessingStatusEntity struct {
-	ID        int64            `db:"id" json:"-"`
-	FileName  string           `db:"file_name" json:"file_name"`
-	Status    ProcessingStatus `db:"status" json:"status"`
-	Error     null.String      `db:"error" json:"error,omitempty"`
-	CreatedAt time.Time        `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time        `db:"updated_at" json:"updated_at"`
+	ID                  int64            `db:"id" json:"-"`
+	FileName            string           `db:"file_name" json:"file_name"`
+	Status              ProcessingStatus `db:"status" json:"status"`
+	Error               null.String      `db:"error" json:"error,omitempty"`
+	Uploader            null.String      `db:"uploader" json:"uploader"`
+	CallbackURL         null.String      `db:"callback_url" json:"callback_url"`
+	Tags                Tags             `db:"tags" json:"tags"`
+	BytesTotal          int64            `db:"bytes_total" json:"bytes_total"`
+	BytesRead           int64            `db:"bytes_read" json:"bytes_read"`
+	RowsParsed          int64            `db:"rows_parsed" json:"rows_parsed"`
+	RowsInserted        int64            `db:"rows_inserted" json:"rows_inserted"`
+	RowsRejected        int64            `db:"rows_rejected" json:"rows_rejected"`
+	Symbols             Symbols          `db:"symbols" json:"symbols"`
+	RangeStart          null.Time        `db:"range_start" json:"range_start" swaggertype:"string"`
+	RangeEnd            null.Time        `db:"range_end" json:"range_end" swaggertype:"string"`
+	StartedAt           null.Time        `db:"started_at" json:"started_at" swaggertype:"string"`
+	FinishedAt          null.Time        `db:"finished_at" json:"finished_at" swaggertype:"string"`
+	EstimatedCompletion null.Time        `db:"-" json:"estimated_completion" swaggertype:"string"`
+	CreatedAt           time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt           time.Time        `db:"updated_at" json:"updated_at"`
+}
+
+// UploadSortField defines the field the uploads are sorted by.
+type UploadSortField string
+
+// IsValid returns true if the UploadSortField is supported.
+func (f UploadSortField) IsValid() bool {
+	switch f {
+	case UploadSortFieldCreatedAt, UploadSortFieldUpdatedAt, UploadSortFieldFileName, UploadSortFieldStatus:
+		return true
+	}
+	return false
+}
+
+// SortOrder defines the order of a sort.
+type SortOrder string
+
+// IsValid returns true if the SortOrder is supported.
+func (o SortOrder) IsValid() bool {
+	switch o {
+	case SortOrderAsc, SortOrderDesc:
+		return true
+	}
+	return false
+}
+
+// GetUploadsRequest defines the get uploads request.
+type GetUploadsRequest struct {
+	Status     ProcessingStatus `form:"status"`
+	Uploader   string           `form:"uploader"`
+	Symbol     string           `form:"symbol"`
+	StartTime  null.Int64       `form:"from"`
+	EndTime    null.Int64       `form:"to"`
+	SortBy     UploadSortField  `form:"sort_by"`
+	Order      SortOrder        `form:"order"`
+	PageNumber null.Int         `form:"page"`
+	PageSize   null.Int         `form:"page_size"`
+}
+
+// GetUploadsResponse defines the get uploads response.
+type GetUploadsResponse struct {
+	Uploads []ProcessingStatusEntity `json:"uploads"`
+	Page    int                      `json:"page"`
+}
+
+// UploadEventType defines the type of an upload event.
+type UploadEventType string
+
+// UploadEvent defines an event streamed when the processing status of an uploaded file changes.
+type UploadEvent struct {
+	Type   UploadEventType        `json:"type"`
+	Upload ProcessingStatusEntity `json:"upload"`
+}
+
+// WatchUploadsRequest defines the watch uploads request.
+type WatchUploadsRequest struct {
+	Uploader string `form:"uploader"`
+}
+
+// WebhookEvent defines the event a webhook is delivered for.
+type WebhookEvent string
+
+// UploadWebhookPayload defines the JSON payload delivered to the webhooks when the processing of an uploaded file finishes.
+type UploadWebhookPayload struct {
+	Event  WebhookEvent           `json:"event"`
+	Upload ProcessingStatusEntity `json:"upload"`
+}
+
+// WebhookDeliveryEntity defines an attempt to deliver a webhook for an uploaded file.
+type WebhookDeliveryEntity struct {
+	ID         int64       `db:"id" json:"id"`
+	FileName   string      `db:"file_name" json:"file_name"`
+	URL        string      `db:"url" json:"url"`
+	Attempt    int         `db:"attempt" json:"attempt"`
+	StatusCode null.Int    `db:"status_code" json:"status_code"`
+	Error      null.String `db:"error" json:"error"`
+	Delivered  bool        `db:"delivered" json:"delivered"`
+	CreatedAt  time.Time   `db:"created_at" json:"created_at"`
+}
+
+// GetWebhookDeliveriesResponse defines the get webhook deliveries response.
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryEntity `json:"deliveries"`
+}
+
+// Symbols defines a list of symbols stored as JSON in the database.
+type Symbols []string
+
+// Value implements driver.Valuer, will be invoked automatically when written to
+// the db.
+func (s Symbols) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner, will be invoked automatically when read from the
+// db.
+func (s *Symbols) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = Symbols{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return errors.New("unsupported type for symbols")
+	}
 }
 
 // OHLCFieldName defines the OHLC Field name.
@@ -65,6 +232,15 @@ func (c OHLCFieldName) String() string {
 	return string(c)
 }
 
+// IsValid returns true if the OHLCFieldName is a known field.
+func (c OHLCFieldName) IsValid() bool {
+	switch c {
+	case OpenFieldName, HighFieldName, LowFieldName, CloseFieldName, SymbolFieldName, UnixFieldName:
+		return true
+	}
+	return false
+}
+
 // FieldIndex defines the OHLC Field index.
 type FieldIndex struct {
 	Name  OHLCFieldName
@@ -98,6 +274,20 @@ type GetOHLCRequest struct {
 	EndTime    null.Int64 `form:"to"`
 	PageNumber null.Int   `form:"page"`
 	PageSize   null.Int   `form:"page_size"`
+	Interval   string     `form:"interval"`
+	Fill       FillMode   `form:"fill"`
+}
+
+// FillMode defines how the missing buckets of a series are synthesised.
+type FillMode string
+
+// IsValid returns true if the FillMode is supported.
+func (f FillMode) IsValid() bool {
+	switch f {
+	case FillModeNone, FillModePrevious, FillModeNull, FillModeLinear:
+		return true
+	}
+	return false
 }
 
 // GetOHLCResponse defines the get ohlc response.
@@ -106,8 +296,397 @@ type GetOHLCResponse struct {
 	Page       int    `json:"page"`
 }
 
+// UploadRequest defines the direct upload request.
+type UploadRequest struct {
+	Uploader    string `form:"uploader"`
+	CallbackURL string `form:"callback_url"`
+	Async       bool   `form:"async"`
+}
+
+// UploadResponse defines the direct upload response.
+// A file processed asynchronously is returned as queued, its filename identifying its processing job,
+// and without row counts.
+type UploadResponse struct {
+	Filename     string           `json:"filename"`
+	Status       ProcessingStatus `json:"status"`
+	RowsInserted null.Int64       `json:"rows_inserted" swaggertype:"integer"`
+	RowsRejected null.Int64       `json:"rows_rejected" swaggertype:"integer"`
+}
+
+// RollbackUploadRequest defines the rollback upload request.
+type RollbackUploadRequest struct {
+	DryRun bool `form:"dry_run"`
+}
+
+// RollbackUploadResponse defines the rollback upload response.
+type RollbackUploadResponse struct {
+	Filename     string `json:"filename"`
+	AffectedRows int64  `json:"affected_rows"`
+	DryRun       bool   `json:"dry_run"`
+}
+
+// GeneratePresignedURLRequest defines the generate presigned url request.
+// The expected size, checksums and tags are enforced by S3 when the file is uploaded, while the format and
+// column mapping are stored with the file and applied when it is processed.
+type GeneratePresignedURLRequest struct {
+	Uploader      string        `form:"uploader" json:"uploader"`
+	CallbackURL   string        `form:"callback_url" json:"callback_url"`
+	Format        FileFormat    `form:"format" json:"format" enums:"csv,csv.gz"`
+	ExpectedSize  int64         `form:"expected_size" json:"expected_size"`
+	ContentMD5    string        `form:"content_md5" json:"content_md5"`
+	ContentSHA256 string        `form:"content_sha256" json:"content_sha256"`
+	ColumnMapping ColumnMapping `form:"-" json:"column_mapping"`
+	Tags          Tags          `form:"-" json:"tags"`
+}
+
 // GeneratePresignedURLResponse defines the generate presigned url response.
+// The headers must be sent along with the upload for the presigned URL to be valid.
 type GeneratePresignedURLResponse struct {
-	URL      string `json:"url"`
-	Filename string `json:"filename"`
+	URL      string            `json:"url"`
+	Filename string            `json:"filename"`
+	Headers  map[string]string `json:"headers"`
+}
+
+// InitiateMultipartUploadRequest defines the initiate multipart upload request.
+// The expected size is checked when the file is processed, while the format and column mapping are stored with
+// the file and applied when it is processed.
+type InitiateMultipartUploadRequest struct {
+	Uploader      string        `json:"uploader"`
+	CallbackURL   string        `json:"callback_url"`
+	Format        FileFormat    `json:"format" enums:"csv,csv.gz"`
+	ExpectedSize  int64         `json:"expected_size"`
+	ColumnMapping ColumnMapping `json:"column_mapping"`
+	Tags          Tags          `json:"tags"`
+	PartCount     int           `json:"part_count" example:"10"`
+}
+
+// MultipartUploadResponse defines the multipart upload response.
+type MultipartUploadResponse struct {
+	Filename string    `json:"filename"`
+	UploadID string    `json:"upload_id"`
+	Parts    []PartURL `json:"parts"`
+}
+
+// PartURL defines the presigned URL a part of a multipart upload is uploaded to.
+type PartURL struct {
+	PartNumber int32  `json:"part_number"`
+	URL        string `json:"url"`
+}
+
+// GetPartURLsRequest defines the get part URLs request, used to resume a multipart upload.
+type GetPartURLsRequest struct {
+	UploadID    string  `json:"upload_id"`
+	PartNumbers []int32 `json:"part_numbers"`
+}
+
+// CompleteMultipartUploadRequest defines the complete multipart upload request.
+type CompleteMultipartUploadRequest struct {
+	UploadID string          `json:"upload_id"`
+	Parts    []CompletedPart `json:"parts"`
+}
+
+// CompletedPart defines an uploaded part of a multipart upload, identified by the ETag returned when it was uploaded.
+type CompletedPart struct {
+	PartNumber int32  `json:"part_number"`
+	ETag       string `json:"etag"`
+}
+
+// AbortMultipartUploadRequest defines the abort multipart upload request.
+type AbortMultipartUploadRequest struct {
+	UploadID string `form:"upload_id"`
+}
+
+// FileFormat defines the format of an uploaded file.
+type FileFormat string
+
+// IsValid returns true if the FileFormat is supported.
+func (f FileFormat) IsValid() bool {
+	switch f {
+	case FileFormatCSV, FileFormatCSVGzip:
+		return true
+	}
+	return false
+}
+
+// ContentType returns the content type of the files of the FileFormat.
+func (f FileFormat) ContentType() string {
+	if f == FileFormatCSVGzip {
+		return "application/gzip"
+	}
+	return "text/csv"
+}
+
+// ColumnMapping maps the columns of an uploaded file to the OHLC fields, for files whose header
+// does not use the expected field names.
+type ColumnMapping map[string]OHLCFieldName
+
+// Validate returns an error if the ColumnMapping maps a column to an unknown field or several columns to the same field.
+func (m ColumnMapping) Validate() error {
+	mapped := map[OHLCFieldName]bool{}
+	for column, field := range m {
+		if column == "" {
+			return errors.New("column_mapping must not map an empty column")
+		}
+		if !field.IsValid() {
+			return errors.New("column_mapping must map columns to UNIX, SYMBOL, OPEN, HIGH, LOW or CLOSE")
+		}
+		if mapped[field] {
+			return errors.New("column_mapping must not map several columns to " + field.String())
+		}
+		mapped[field] = true
+	}
+	return nil
+}
+
+// Tags defines the tags of an uploaded file stored as JSON in the database.
+type Tags map[string]string
+
+// Value implements driver.Valuer, will be invoked automatically when written to
+// the db. No tags are written as NULL.
+func (t Tags) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner, will be invoked automatically when read from the
+// db.
+func (t *Tags) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*t = Tags{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	default:
+		return errors.New("unsupported type for tags")
+	}
+}
+
+// Gap defines a run of consecutive missing buckets, From and To being the UNIX start times
+// of the first and last missing bucket.
+type Gap struct {
+	From  int64 `json:"from"`
+	To    int64 `json:"to"`
+	Count int64 `json:"count"`
+}
+
+// Gaps defines a list of gaps stored as JSON in the database.
+type Gaps []Gap
+
+// Value implements driver.Valuer, will be invoked automatically when written to
+// the db.
+func (g Gaps) Value() (driver.Value, error) {
+	if g == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(g)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner, will be invoked automatically when read from the
+// db.
+func (g *Gaps) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*g = Gaps{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, g)
+	case string:
+		return json.Unmarshal([]byte(v), g)
+	default:
+		return errors.New("unsupported type for gaps")
+	}
+}
+
+// DataQualityEntity defines the data completeness report of a symbol for a single day.
+type DataQualityEntity struct {
+	ID              int64     `db:"id" json:"-"`
+	Symbol          string    `db:"symbol" json:"symbol"`
+	Day             time.Time `db:"day" json:"day"`
+	IntervalSeconds int64     `db:"interval_seconds" json:"interval_seconds"`
+	ExpectedCount   int64     `db:"expected_count" json:"expected_count"`
+	PresentCount    int64     `db:"present_count" json:"present_count"`
+	MissingCount    int64     `db:"missing_count" json:"missing_count"`
+	DuplicateCount  int64     `db:"duplicate_count" json:"duplicate_count"`
+	Coverage        float64   `db:"coverage" json:"coverage"`
+	Gaps            Gaps      `db:"gaps" json:"gaps"`
+	CreatedAt       time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// DataQualityRequest defines the data quality scan request.
+type DataQualityRequest struct {
+	Symbol    string     `form:"symbol"`
+	Interval  string     `form:"interval"`
+	StartTime int64      `form:"from"`
+	EndTime   null.Int64 `form:"to"`
+}
+
+// GetDataQualityResponse defines the data quality report response.
+type GetDataQualityResponse struct {
+	Reports []DataQualityEntity `json:"reports"`
+}
+
+// IngestionMode defines how the data points of a file are made visible during ingestion.
+type IngestionMode string
+
+// AnomalyAction defines how suspicious data points are handled during ingestion.
+type AnomalyAction string
+
+// IsValid returns true if the AnomalyAction is supported.
+func (a AnomalyAction) IsValid() bool {
+	switch a {
+	case AnomalyActionOff, AnomalyActionFlag, AnomalyActionQuarantine, AnomalyActionReject:
+		return true
+	}
+	return false
+}
+
+// AnomalyStatus defines the review status of a suspicious data point.
+type AnomalyStatus string
+
+// AnomalyEntity defines a suspicious data point detected during ingestion.
+type AnomalyEntity struct {
+	ID         int64           `db:"id" json:"id"`
+	Time       time.Time       `db:"time" json:"time"`
+	Symbol     string          `db:"symbol" json:"symbol"`
+	Open       decimal.Decimal `db:"open" json:"open" swaggertype:"number"`
+	High       decimal.Decimal `db:"high" json:"high" swaggertype:"number"`
+	Low        decimal.Decimal `db:"low" json:"low" swaggertype:"number"`
+	Close      decimal.Decimal `db:"close" json:"close" swaggertype:"number"`
+	Reason     string          `db:"reason" json:"reason"`
+	Status     AnomalyStatus   `db:"status" json:"status"`
+	SourceFile null.String     `db:"source_file" json:"source_file"`
+	CreatedAt  time.Time       `db:"created_at" json:"created_at"`
+}
+
+// MarshalJSON implements the Marshaler interface. The prices are marshalled to JSON numbers with every digit of their
+// value, like the prices of OHLC.
+func (a AnomalyEntity) MarshalJSON() ([]byte, error) {
+	type anomaly AnomalyEntity
+	return json.Marshal(struct {
+		anomaly
+		Open  null.Decimal `json:"open"`
+		High  null.Decimal `json:"high"`
+		Low   null.Decimal `json:"low"`
+		Close null.Decimal `json:"close"`
+	}{
+		anomaly: anomaly(a),
+		Open:    null.NewDecimal(a.Open),
+		High:    null.NewDecimal(a.High),
+		Low:     null.NewDecimal(a.Low),
+		Close:   null.NewDecimal(a.Close),
+	})
+}
+
+// NewAnomalyEntity creates an AnomalyEntity from a suspicious OHLCEntity.
+func NewAnomalyEntity(p OHLCEntity, reason string, status AnomalyStatus) AnomalyEntity {
+	return AnomalyEntity{
+		Time:       p.Time,
+		Symbol:     p.Symbol,
+		Open:       p.Open,
+		High:       p.High,
+		Low:        p.Low,
+		Close:      p.Close,
+		Reason:     reason,
+		Status:     status,
+		SourceFile: p.SourceFile,
+	}
+}
+
+// ToOHLCEntity converts AnomalyEntity to OHLCEntity.
+func (a *AnomalyEntity) ToOHLCEntity() OHLCEntity {
+	return OHLCEntity{
+		Time:       a.Time,
+		Symbol:     a.Symbol,
+		Open:       a.Open,
+		High:       a.High,
+		Low:        a.Low,
+		Close:      a.Close,
+		SourceFile: a.SourceFile,
+	}
+}
+
+// GetAnomaliesRequest defines the get anomalies request.
+type GetAnomaliesRequest struct {
+	Symbol     string        `form:"symbol"`
+	Status     AnomalyStatus `form:"status"`
+	PageNumber null.Int      `form:"page"`
+	PageSize   null.Int      `form:"page_size"`
+}
+
+// GetAnomaliesResponse defines the get anomalies response.
+type GetAnomaliesResponse struct {
+	Anomalies []AnomalyEntity `json:"anomalies"`
+	Page      int             `json:"page"`
+}
+
+// RejectedMessageEntity defines a SQS message rejected by the consumer, kept for inspection.
+type RejectedMessageEntity struct {
+	ID        int64     `db:"id" json:"id"`
+	MessageID string    `db:"message_id" json:"message_id"`
+	Body      string    `db:"body" json:"body"`
+	Reason    string    `db:"reason" json:"reason"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// GetRejectedMessagesRequest defines the get rejected messages request.
+type GetRejectedMessagesRequest struct {
+	PageNumber null.Int `form:"page"`
+	PageSize   null.Int `form:"page_size"`
+}
+
+// GetRejectedMessagesResponse defines the get rejected messages response.
+type GetRejectedMessagesResponse struct {
+	Messages []RejectedMessageEntity `json:"messages"`
+	Page     int                     `json:"page"`
+}
+
+// RollupEntity defines a candle of a symbol downsampled to a coarser interval than the stored data points.
+type RollupEntity struct {
+	ID              int64           `db:"id"`
+	Symbol          string          `db:"symbol"`
+	IntervalSeconds int64           `db:"interval_seconds"`
+	Time            time.Time       `db:"time"`
+	Open            decimal.Decimal `db:"open"`
+	High            decimal.Decimal `db:"high"`
+	Low             decimal.Decimal `db:"low"`
+	Close           decimal.Decimal `db:"close"`
+}
+
+// NewRollupEntity creates a RollupEntity from a candle of the given interval.
+func NewRollupEntity(p OHLCEntity, interval time.Duration) RollupEntity {
+	return RollupEntity{
+		Symbol:          p.Symbol,
+		IntervalSeconds: int64(interval / time.Second),
+		Time:            p.Time,
+		Open:            p.Open,
+		High:            p.High,
+		Low:             p.Low,
+		Close:           p.Close,
+	}
+}
+
+// OHLCEntity converts RollupEntity to OHLCEntity.
+func (r *RollupEntity) OHLCEntity() OHLCEntity {
+	return OHLCEntity{
+		Time:   r.Time,
+		Symbol: r.Symbol,
+		Open:   r.Open,
+		High:   r.High,
+		Low:    r.Low,
+		Close:  r.Close,
+	}
 }
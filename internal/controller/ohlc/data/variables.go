@@ -30,7 +30,44 @@ var (
 		},
 	}
 
-	ProcessingStatusInProgress ProcessingStatus = "IN_PROGRESS"
-	ProcessingStatusCompleted  ProcessingStatus = "COMPLETED"
-	ProcessingStatusFailed     ProcessingStatus = "FAILED"
+	ProcessingStatusAwaitingUpload ProcessingStatus = "AWAITING_UPLOAD"
+	ProcessingStatusQueued         ProcessingStatus = "QUEUED"
+	ProcessingStatusInProgress     ProcessingStatus = "IN_PROGRESS"
+	ProcessingStatusCompleted      ProcessingStatus = "COMPLETED"
+	ProcessingStatusFailed         ProcessingStatus = "FAILED"
+	ProcessingStatusRolledBack     ProcessingStatus = "ROLLED_BACK"
+	ProcessingStatusRetrying       ProcessingStatus = "RETRYING"
+	ProcessingStatusCancelled      ProcessingStatus = "CANCELLED"
+
+	UploadEventTypeStatus   UploadEventType = "status"
+	UploadEventTypeProgress UploadEventType = "progress"
+
+	WebhookEventUploadFinished WebhookEvent = "upload.finished"
+
+	FileFormatCSV     FileFormat = "csv"
+	FileFormatCSVGzip FileFormat = "csv.gz"
+
+	UploadSortFieldCreatedAt UploadSortField = "created_at"
+	UploadSortFieldUpdatedAt UploadSortField = "updated_at"
+	UploadSortFieldFileName  UploadSortField = "file_name"
+	UploadSortFieldStatus    UploadSortField = "status"
+
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+
+	FillModeNone     FillMode = "none"
+	FillModePrevious FillMode = "previous"
+	FillModeNull     FillMode = "null"
+	FillModeLinear   FillMode = "linear"
+
+	IngestionModeTransaction IngestionMode = "transaction"
+	IngestionModeBatch       IngestionMode = "batch"
+
+	AnomalyActionOff        AnomalyAction = "off"
+	AnomalyActionFlag       AnomalyAction = "flag"
+	AnomalyActionQuarantine AnomalyAction = "quarantine"
+	AnomalyActionReject     AnomalyAction = "reject"
+
+	AnomalyStatusFlagged     AnomalyStatus = "FLAGGED"
+	AnomalyStatusQuarantined AnomalyStatus = "QUARANTINED"
 )
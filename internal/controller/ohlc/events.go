@@ -0,0 +1,43 @@
+package ohlc
+
+import (
+	"context"
+
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"go.uber.org/zap"
+)
+
+// uploadEventBufferSize is the number of upload events buffered per watcher.
+const uploadEventBufferSize = 64
+
+// publishUpload publishes the current processing status of a file to the watchers of its uploads.
+// The processing status is only read if someone is watching.
+func (s *DefaultService) publishUpload(ctx context.Context, filename string, eventType data.UploadEventType) {
+	if !s.uploadEvents.HasSubscribers() {
+		return
+	}
+	status, err := s.GetProcessingStatus(uncancelled(ctx), filename)
+	if err != nil {
+		s.logger.Warn("failed to publish upload event", zap.String("filename", filename), zap.Error(err))
+		return
+	}
+	s.uploadEvents.Publish(data.UploadEvent{
+		Type:   eventType,
+		Upload: *status,
+	})
+}
+
+// WatchUploads subscribes to the status transitions and progress of the uploads of a file, or of an uploader
+// if no filename is given, or of every upload if neither is given. Only the uploads processed by this instance
+// are watched. The returned function stops watching.
+func (s *DefaultService) WatchUploads(filename string, uploader string) (<-chan data.UploadEvent, func()) {
+	return s.uploadEvents.Subscribe(func(e data.UploadEvent) bool {
+		if filename != "" && e.Upload.FileName != filename {
+			return false
+		}
+		if uploader != "" && e.Upload.Uploader.String != uploader {
+			return false
+		}
+		return true
+	})
+}
@@ -0,0 +1,52 @@
+package ohlc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+func TestDefaultService_WatchUploads(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		logger         = zap.NewNop()
+		mockS3Client   = &s3.ClientMock{}
+		mockSQSClient  = &sqs.ClientMock{}
+		mockRepository = &repository.RepositoryMock{
+			GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+				return &data.ProcessingStatusEntity{
+					FileName: fileName,
+					Status:   data.ProcessingStatusCompleted,
+					Uploader: null.NewString("alice"),
+				}, nil
+			},
+		}
+	)
+	conf := config.Init()
+
+	s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+	s.publishUpload(ctx, "test.csv", data.UploadEventTypeStatus)
+	assert.Empty(t, mockRepository.GetProcessingStatusCalls())
+
+	fileEvents, stopFile := s.WatchUploads("test.csv", "")
+	defer stopFile()
+	uploaderEvents, stopUploader := s.WatchUploads("", "bob")
+	defer stopUploader()
+
+	s.publishUpload(ctx, "test.csv", data.UploadEventTypeStatus)
+	require.Len(t, fileEvents, 1)
+	e := <-fileEvents
+	assert.Equal(t, data.UploadEventTypeStatus, e.Type)
+	assert.Equal(t, "test.csv", e.Upload.FileName)
+	assert.Empty(t, uploaderEvents)
+}
@@ -0,0 +1,165 @@
+package ohlc
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	E "github.com/teezzan/candles/internal/errors"
+	"go.uber.org/zap"
+)
+
+// jobRegistry keeps track of the files being processed by this instance so that their processing can be cancelled.
+type jobRegistry struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// newJobRegistry initializes an empty job registry.
+func newJobRegistry() *jobRegistry {
+	return &jobRegistry{
+		cancels: map[string]context.CancelFunc{},
+	}
+}
+
+// start registers the processing of a file. It returns false if the file is already being processed.
+func (r *jobRegistry) start(filename string, cancel context.CancelFunc) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.cancels[filename]; ok {
+		return false
+	}
+	r.cancels[filename] = cancel
+	return true
+}
+
+// done unregisters the processing of a file.
+func (r *jobRegistry) done(filename string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.cancels, filename)
+}
+
+// running reports whether a file is being processed.
+func (r *jobRegistry) running(filename string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.cancels[filename]
+	return ok
+}
+
+// cancel cancels the processing of a file. It returns false if the file is not being processed.
+func (r *jobRegistry) cancel(filename string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cancel, ok := r.cancels[filename]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// isActive reports whether a file with the given status is queued or being processed.
+func isActive(status data.ProcessingStatus) bool {
+	switch status {
+	case data.ProcessingStatusQueued, data.ProcessingStatusInProgress, data.ProcessingStatusRetrying:
+		return true
+	}
+	return false
+}
+
+// uncancelled returns ctx, or a background context if ctx is done, so that the outcome of a cancelled job
+// can still be recorded.
+func uncancelled(ctx context.Context) context.Context {
+	if ctx.Err() != nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+// startJob processes a file uploaded to S3 in the background with a cancellable context, the file being
+// recorded with `status` while it is processed. It returns false if the file is already being processed.
+func (s *DefaultService) startJob(filename string, status data.ProcessingStatus) bool {
+	ctx, cancel, ok := s.claimJob(filename)
+	if !ok {
+		return false
+	}
+	go s.runJob(ctx, cancel, filename, status)
+	return true
+}
+
+// claimJob registers the processing of a file with a cancellable context.
+// It returns false if the file is already being processed.
+func (s *DefaultService) claimJob(filename string) (context.Context, context.CancelFunc, bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if !s.jobs.start(filename, cancel) {
+		cancel()
+		return nil, nil, false
+	}
+	return ctx, cancel, true
+}
+
+// runJob processes a claimed file uploaded to S3, the file being recorded with `status` while it is processed.
+func (s *DefaultService) runJob(ctx context.Context, cancel context.CancelFunc, filename string, status data.ProcessingStatus) {
+	defer s.jobs.done(filename)
+	defer cancel()
+
+	err := s.UpdateProcessingStatus(ctx, filename, status, nil)
+	if err != nil {
+		s.logger.Warn("failed to update processing status", zap.String("filename", filename), zap.Error(err))
+	}
+	s.DownloadAndProcessCSV(ctx, filename)
+}
+
+// recordOutcome records the outcome of the processing of a file: completed if it succeeded,
+// cancelled if its context was cancelled and failed otherwise. The webhooks are then notified of the outcome.
+func (s *DefaultService) recordOutcome(ctx context.Context, filename string, err error) error {
+	ctx = uncancelled(ctx)
+	switch {
+	case err == nil:
+		err = s.UpdateProcessingStatus(ctx, filename, data.ProcessingStatusCompleted, nil)
+	case errors.Is(err, context.Canceled):
+		s.logger.Info("processing cancelled", zap.String("filename", filename))
+		err = s.UpdateProcessingStatus(ctx, filename, data.ProcessingStatusCancelled, nil)
+	default:
+		err = s.UpdateProcessingStatus(ctx, filename, data.ProcessingStatusFailed, err)
+	}
+	if err != nil {
+		return err
+	}
+	s.notifyCompletion(ctx, filename)
+	return nil
+}
+
+// RetryUpload re-runs the processing of a failed, cancelled or rolled back file still stored in S3.
+// The file is recorded as retrying while it is processed in the background.
+func (s *DefaultService) RetryUpload(ctx context.Context, filename string) error {
+	status, err := s.repository.GetProcessingStatus(ctx, filename)
+	if err != nil {
+		return err
+	}
+	switch status.Status {
+	case data.ProcessingStatusFailed, data.ProcessingStatusCancelled, data.ProcessingStatusRolledBack:
+	default:
+		return E.NewErrInvalidArgument("only failed, cancelled or rolled back files can be retried")
+	}
+
+	if !s.startJob(filename, data.ProcessingStatusRetrying) {
+		return E.NewErrInvalidArgument("file is already being processed")
+	}
+	return nil
+}
+
+// CancelUpload cancels the processing of a file. The file is recorded as cancelled once its processing stops.
+// Only the files processed by this instance can be cancelled.
+func (s *DefaultService) CancelUpload(ctx context.Context, filename string) error {
+	status, err := s.repository.GetProcessingStatus(ctx, filename)
+	if err != nil {
+		return err
+	}
+	if !isActive(status.Status) || !s.jobs.cancel(filename) {
+		return E.NewErrInvalidArgument("file is not being processed")
+	}
+	return nil
+}
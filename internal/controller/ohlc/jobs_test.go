@@ -0,0 +1,189 @@
+package ohlc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"go.uber.org/zap"
+)
+
+func TestDefaultService_CancelUpload(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     data.ProcessingStatus
+		registered bool
+		wantErr    bool
+	}{
+		{
+			name:       "file being processed",
+			status:     data.ProcessingStatusInProgress,
+			registered: true,
+		},
+		{
+			name:    "file processed by another instance",
+			status:  data.ProcessingStatusInProgress,
+			wantErr: true,
+		},
+		{
+			name:       "completed file",
+			status:     data.ProcessingStatusCompleted,
+			registered: true,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx            = context.Background()
+				logger         = zap.NewNop()
+				mockS3Client   = &s3.ClientMock{}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{
+					GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+						return &data.ProcessingStatusEntity{FileName: fileName, Status: tt.status}, nil
+					},
+				}
+			)
+			conf := config.Init()
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			jobCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			if tt.registered {
+				s.jobs.start("test.csv", cancel)
+			}
+
+			err := s.CancelUpload(ctx, "test.csv")
+			require.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, !tt.wantErr, jobCtx.Err() != nil)
+		})
+	}
+}
+
+func TestDefaultService_RetryUpload(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  data.ProcessingStatus
+		wantErr bool
+	}{
+		{
+			name:   "failed file",
+			status: data.ProcessingStatusFailed,
+		},
+		{
+			name:   "cancelled file",
+			status: data.ProcessingStatusCancelled,
+		},
+		{
+			name:    "file being processed",
+			status:  data.ProcessingStatusInProgress,
+			wantErr: true,
+		},
+		{
+			name:    "completed file",
+			status:  data.ProcessingStatusCompleted,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				logger       = zap.NewNop()
+				mockS3Client = &s3.ClientMock{
+					OpenObjectFunc: func(ctx context.Context, objectKey string) (*s3.Object, error) {
+						return nil, errors.New("object not found")
+					},
+				}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{
+					GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+						return &data.ProcessingStatusEntity{FileName: fileName, Status: tt.status}, nil
+					},
+					UpdateProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+						return nil
+					},
+				}
+			)
+			conf := config.Init()
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			err := s.RetryUpload(ctx, "test.csv")
+			require.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				return
+			}
+
+			// The job is over once it is unregistered, after its outcome is recorded and notified.
+			require.Eventually(t, func() bool {
+				return !s.jobs.running("test.csv")
+			}, 5*time.Second, 10*time.Millisecond)
+			calls := mockRepository.UpdateProcessingStatusCalls()
+			require.Len(t, calls, 2)
+			assert.Equal(t, data.ProcessingStatusRetrying, calls[0].Status.Status)
+			assert.Equal(t, data.ProcessingStatusFailed, calls[1].Status.Status)
+		})
+	}
+}
+
+func TestDefaultService_recordOutcome(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus data.ProcessingStatus
+	}{
+		{
+			name:       "success",
+			wantStatus: data.ProcessingStatusCompleted,
+		},
+		{
+			name:       "cancellation",
+			err:        context.Canceled,
+			wantStatus: data.ProcessingStatusCancelled,
+		},
+		{
+			name:       "failure",
+			err:        errors.New("test error"),
+			wantStatus: data.ProcessingStatusFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				logger         = zap.NewNop()
+				mockS3Client   = &s3.ClientMock{}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{
+					GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+						return &data.ProcessingStatusEntity{FileName: fileName}, nil
+					},
+					UpdateProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+						return ctx.Err()
+					},
+				}
+			)
+			conf := config.Init()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			err := s.recordOutcome(ctx, "test.csv", tt.err)
+			require.NoError(t, err)
+			calls := mockRepository.UpdateProcessingStatusCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, tt.wantStatus, calls[0].Status.Status)
+			assert.True(t, calls[0].Status.FinishedAt.Valid)
+		})
+	}
+}
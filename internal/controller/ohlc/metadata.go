@@ -0,0 +1,223 @@
+package ohlc
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	E "github.com/teezzan/candles/internal/errors"
+)
+
+const (
+	// metadataFormat is the S3 metadata key of the format of an uploaded file.
+	metadataFormat = "candles-format"
+	// metadataExpectedSize is the S3 metadata key of the expected size of an uploaded file.
+	metadataExpectedSize = "candles-expected-size"
+	// metadataColumnMapping is the S3 metadata key of the column mapping of an uploaded file.
+	metadataColumnMapping = "candles-column-mapping"
+
+	// maxMetadataSize is the maximum size in bytes of the metadata S3 stores with an object.
+	maxMetadataSize = 2048
+	// maxTags is the maximum number of tags S3 stores with an object.
+	maxTags = 10
+	// maxTagKeyLength is the maximum length of a tag key.
+	maxTagKeyLength = 128
+	// maxTagValueLength is the maximum length of a tag value.
+	maxTagValueLength = 256
+)
+
+// fileOptions defines how an uploaded file is processed.
+type fileOptions struct {
+	format        data.FileFormat
+	expectedSize  int64
+	columnMapping data.ColumnMapping
+}
+
+// metadata returns the S3 metadata the options are stored as.
+func (o fileOptions) metadata() (map[string]string, error) {
+	metadata := map[string]string{
+		metadataFormat: string(o.format),
+	}
+	if o.expectedSize > 0 {
+		metadata[metadataExpectedSize] = strconv.FormatInt(o.expectedSize, 10)
+	}
+	if len(o.columnMapping) > 0 {
+		b, err := json.Marshal(o.columnMapping)
+		if err != nil {
+			return nil, err
+		}
+		metadata[metadataColumnMapping] = string(b)
+	}
+
+	size := 0
+	for k, v := range metadata {
+		if !isPrintableASCII(v) {
+			return nil, E.NewErrInvalidArgument("column_mapping must only contain printable ASCII characters")
+		}
+		size += len(k) + len(v)
+	}
+	if size > maxMetadataSize {
+		return nil, E.NewErrInvalidArgument("column_mapping is too large")
+	}
+	return metadata, nil
+}
+
+// fileOptionsOf reads the options of an uploaded file back from its S3 metadata. A file uploaded without
+// metadata is processed as CSV, or as gzipped CSV if its name ends with .gz.
+func fileOptionsOf(filename string, metadata map[string]string) (fileOptions, error) {
+	options := fileOptions{
+		format: data.FileFormat(metadata[metadataFormat]),
+	}
+	if options.format == "" {
+		options.format = data.FileFormatCSV
+		if strings.HasSuffix(filename, ".gz") {
+			options.format = data.FileFormatCSVGzip
+		}
+	}
+	if !options.format.IsValid() {
+		return options, E.NewErrInvalidArgument(fmt.Sprintf("unsupported file format %q", options.format))
+	}
+
+	if v, ok := metadata[metadataExpectedSize]; ok {
+		size, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return options, E.NewErrInvalidArgument("invalid expected size metadata")
+		}
+		options.expectedSize = size
+	}
+	if v, ok := metadata[metadataColumnMapping]; ok {
+		err := json.Unmarshal([]byte(v), &options.columnMapping)
+		if err != nil {
+			return options, E.NewErrInvalidArgument("invalid column mapping metadata")
+		}
+	}
+	return options, nil
+}
+
+// uploadConditionsOf validates a presigned URL request and returns the conditions the upload must meet,
+// along with the options the file is processed with.
+func uploadConditionsOf(payload data.GeneratePresignedURLRequest) (s3.UploadConditions, fileOptions, error) {
+	options := fileOptions{
+		format:        payload.Format,
+		expectedSize:  payload.ExpectedSize,
+		columnMapping: payload.ColumnMapping,
+	}
+	if options.format == "" {
+		options.format = data.FileFormatCSV
+	}
+	if !options.format.IsValid() {
+		return s3.UploadConditions{}, options, E.NewErrInvalidArgument("format must be one of csv or csv.gz")
+	}
+	if options.expectedSize < 0 {
+		return s3.UploadConditions{}, options, E.NewErrInvalidArgument("expected_size must be greater than 0")
+	}
+	if err := options.columnMapping.Validate(); err != nil {
+		return s3.UploadConditions{}, options, E.NewErrInvalidArgument(err.Error())
+	}
+	if err := validateChecksum("content_md5", payload.ContentMD5, 16); err != nil {
+		return s3.UploadConditions{}, options, err
+	}
+	if err := validateChecksum("content_sha256", payload.ContentSHA256, 32); err != nil {
+		return s3.UploadConditions{}, options, err
+	}
+	if err := validateTags(payload.Tags); err != nil {
+		return s3.UploadConditions{}, options, err
+	}
+
+	metadata, err := options.metadata()
+	if err != nil {
+		return s3.UploadConditions{}, options, err
+	}
+	return s3.UploadConditions{
+		ContentType:    options.format.ContentType(),
+		ContentLength:  options.expectedSize,
+		ContentMD5:     payload.ContentMD5,
+		ChecksumSHA256: payload.ContentSHA256,
+		Metadata:       metadata,
+		Tags:           payload.Tags,
+	}, options, nil
+}
+
+// validateChecksum returns an error if a checksum is not the base64 encoding of a digest of `size` bytes.
+// An empty checksum is valid.
+func validateChecksum(name string, checksum string, size int) error {
+	if checksum == "" {
+		return nil
+	}
+	b, err := base64.StdEncoding.DecodeString(checksum)
+	if err != nil || len(b) != size {
+		return E.NewErrInvalidArgument(fmt.Sprintf("%s must be a base64 encoded digest of %d bytes", name, size))
+	}
+	return nil
+}
+
+// validateTags returns an error if the tags cannot be stored with an S3 object.
+func validateTags(tags data.Tags) error {
+	if len(tags) > maxTags {
+		return E.NewErrInvalidArgument(fmt.Sprintf("at most %d tags are allowed", maxTags))
+	}
+	for k, v := range tags {
+		if k == "" || len(k) > maxTagKeyLength || len(v) > maxTagValueLength {
+			return E.NewErrInvalidArgument(fmt.Sprintf("tag keys must have 1 to %d characters and tag values at most %d", maxTagKeyLength, maxTagValueLength))
+		}
+	}
+	return nil
+}
+
+// isPrintableASCII reports whether s only contains printable ASCII characters.
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// csvRows returns the CSV rows of a file read from `r` according to its options.
+func csvRows(r io.Reader, options fileOptions) (rowReader, error) {
+	if options.format == data.FileFormatCSVGzip {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("invalid gzip file: %v", err))
+		}
+		r = gz
+	}
+	var rows rowReader = csv.NewReader(r)
+	if len(options.columnMapping) > 0 {
+		rows = &mappedRowReader{rows: rows, mapping: options.columnMapping}
+	}
+	return rows, nil
+}
+
+// mappedRowReader renames the columns of the header of a CSV file according to a column mapping.
+type mappedRowReader struct {
+	rows       rowReader
+	mapping    data.ColumnMapping
+	headerRead bool
+}
+
+// Read implements rowReader.
+func (r *mappedRowReader) Read() ([]string, error) {
+	row, err := r.rows.Read()
+	if err != nil || r.headerRead {
+		return row, err
+	}
+	r.headerRead = true
+
+	header := make([]string, len(row))
+	for i, column := range row {
+		header[i] = column
+		if field, ok := r.mapping[strings.TrimSpace(column)]; ok {
+			header[i] = field.String()
+		}
+	}
+	return header, nil
+}
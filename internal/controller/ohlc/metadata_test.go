@@ -0,0 +1,98 @@
+package ohlc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+)
+
+func Test_fileOptionsOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		metadata map[string]string
+		want     fileOptions
+		wantErr  bool
+	}{
+		{
+			name:     "no metadata",
+			filename: "test.csv",
+			want:     fileOptions{format: data.FileFormatCSV},
+		},
+		{
+			name:     "gzipped file without metadata",
+			filename: "test.csv.gz",
+			want:     fileOptions{format: data.FileFormatCSVGzip},
+		},
+		{
+			name:     "stored options",
+			filename: "test.csv.gz",
+			metadata: map[string]string{
+				"candles-format":         "csv.gz",
+				"candles-expected-size":  "1024",
+				"candles-column-mapping": `{"ts":"UNIX"}`,
+			},
+			want: fileOptions{
+				format:        data.FileFormatCSVGzip,
+				expectedSize:  1024,
+				columnMapping: data.ColumnMapping{"ts": data.UnixFieldName},
+			},
+		},
+		{
+			name:     "unsupported format",
+			filename: "test.xlsx",
+			metadata: map[string]string{"candles-format": "xlsx"},
+			want:     fileOptions{format: "xlsx"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fileOptionsOf(tt.filename, tt.metadata)
+			require.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_csvRows(t *testing.T) {
+	csvData := `ts,pair,o,h,l,c
+1610000000,BTC,100,200,50,150
+`
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err := gz.Write([]byte(csvData))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	rows, err := csvRows(&gzipped, fileOptions{
+		format: data.FileFormatCSVGzip,
+		columnMapping: data.ColumnMapping{
+			"ts":   data.UnixFieldName,
+			"pair": data.SymbolFieldName,
+			"o":    data.OpenFieldName,
+			"h":    data.HighFieldName,
+			"l":    data.LowFieldName,
+			"c":    data.CloseFieldName,
+		},
+	})
+	require.NoError(t, err)
+
+	header, err := rows.Read()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"UNIX", "SYMBOL", "OPEN", "HIGH", "LOW", "CLOSE"}, header)
+	row, err := rows.Read()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1610000000", "BTC", "100", "200", "50", "150"}, row)
+	_, err = rows.Read()
+	assert.Equal(t, io.EOF, err)
+
+	_, err = csvRows(strings.NewReader(csvData), fileOptions{format: data.FileFormatCSVGzip})
+	assert.Error(t, err)
+}
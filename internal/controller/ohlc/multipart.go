@@ -0,0 +1,224 @@
+package ohlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	E "github.com/teezzan/candles/internal/errors"
+	"go.uber.org/zap"
+)
+
+// maxMultipartParts is the maximum number of parts of a multipart upload.
+const maxMultipartParts = 10000
+
+// InitiateMultipartUpload initiates a multipart upload to S3 for files too large for a single presigned URL
+// and hands out the presigned URLs of its first `PartCount` parts.
+// The format and column mapping of the payload are stored as metadata of the file and applied when it is processed.
+// The file is recorded as awaiting upload until the upload is completed or aborted.
+func (s *DefaultService) InitiateMultipartUpload(ctx context.Context, payload data.InitiateMultipartUploadRequest) (*data.MultipartUploadResponse, error) {
+	if payload.PartCount <= 0 || payload.PartCount > maxMultipartParts {
+		return nil, E.NewErrInvalidArgument(fmt.Sprintf("part_count must be between 1 and %d", maxMultipartParts))
+	}
+	callbackURL, err := callbackURLOf(payload.CallbackURL)
+	if err != nil {
+		return nil, err
+	}
+	conditions, options, err := uploadConditionsOf(data.GeneratePresignedURLRequest{
+		Format:        payload.Format,
+		ExpectedSize:  payload.ExpectedSize,
+		ColumnMapping: payload.ColumnMapping,
+		Tags:          payload.Tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkKeySuffix(options.format)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := newFilename(options.format)
+	uploadID, err := s.s3Client.CreateMultipartUpload(ctx, s.objectKey(filename), conditions)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repository.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{
+		FileName:    filename,
+		Status:      data.ProcessingStatusAwaitingUpload,
+		Uploader:    uploaderOf(payload.Uploader),
+		CallbackURL: callbackURL,
+		Tags:        payload.Tags,
+	})
+	if err != nil {
+		if abortErr := s.s3Client.AbortMultipartUpload(uncancelled(ctx), s.objectKey(filename), uploadID); abortErr != nil {
+			s.logger.Error("failed to abort multipart upload", zap.String("filename", filename), zap.Error(abortErr))
+		}
+		return nil, err
+	}
+	s.publishUpload(ctx, filename, data.UploadEventTypeStatus)
+
+	partNumbers := make([]int32, payload.PartCount)
+	for i := range partNumbers {
+		partNumbers[i] = int32(i + 1)
+	}
+	parts, err := s.presignParts(ctx, filename, uploadID, partNumbers)
+	if err != nil {
+		return nil, err
+	}
+	return &data.MultipartUploadResponse{
+		Filename: filename,
+		UploadID: uploadID,
+		Parts:    parts,
+	}, nil
+}
+
+// GetPartURLs hands out the presigned URLs of parts of a multipart upload, so that an interrupted upload can be
+// resumed or more parts can be uploaded.
+func (s *DefaultService) GetPartURLs(ctx context.Context, filename string, payload data.GetPartURLsRequest) (*data.MultipartUploadResponse, error) {
+	err := s.checkAwaitingUpload(ctx, filename, payload.UploadID)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload.PartNumbers) == 0 || len(payload.PartNumbers) > maxMultipartParts {
+		return nil, E.NewErrInvalidArgument(fmt.Sprintf("part_numbers must contain between 1 and %d parts", maxMultipartParts))
+	}
+	for _, partNumber := range payload.PartNumbers {
+		if partNumber <= 0 || partNumber > maxMultipartParts {
+			return nil, E.NewErrInvalidArgument(fmt.Sprintf("part numbers must be between 1 and %d", maxMultipartParts))
+		}
+	}
+
+	parts, err := s.presignParts(ctx, filename, payload.UploadID, payload.PartNumbers)
+	if err != nil {
+		return nil, err
+	}
+	return &data.MultipartUploadResponse{
+		Filename: filename,
+		UploadID: payload.UploadID,
+		Parts:    parts,
+	}, nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts of a multipart upload into the file, which is then processed
+// like any file uploaded to S3.
+func (s *DefaultService) CompleteMultipartUpload(ctx context.Context, filename string, payload data.CompleteMultipartUploadRequest) error {
+	err := s.checkAwaitingUpload(ctx, filename, payload.UploadID)
+	if err != nil {
+		return err
+	}
+	if len(payload.Parts) == 0 {
+		return E.NewErrInvalidArgument("parts is required")
+	}
+
+	parts := make([]s3.CompletedPart, 0, len(payload.Parts))
+	seen := map[int32]bool{}
+	for _, part := range payload.Parts {
+		if part.PartNumber <= 0 || part.PartNumber > maxMultipartParts || part.ETag == "" {
+			return E.NewErrInvalidArgument(fmt.Sprintf("parts must have a part number between 1 and %d and an etag", maxMultipartParts))
+		}
+		if seen[part.PartNumber] {
+			return E.NewErrInvalidArgument(fmt.Sprintf("part %d is listed more than once", part.PartNumber))
+		}
+		seen[part.PartNumber] = true
+		parts = append(parts, s3.CompletedPart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	// S3 expects the parts in ascending order.
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+
+	return s.s3Client.CompleteMultipartUpload(ctx, s.objectKey(filename), payload.UploadID, parts)
+}
+
+// AbortMultipartUpload aborts a multipart upload and records the file as cancelled.
+func (s *DefaultService) AbortMultipartUpload(ctx context.Context, filename string, uploadID string) error {
+	err := s.checkAwaitingUpload(ctx, filename, uploadID)
+	if err != nil {
+		return err
+	}
+	err = s.s3Client.AbortMultipartUpload(ctx, s.objectKey(filename), uploadID)
+	if err != nil {
+		return err
+	}
+	return s.UpdateProcessingStatus(ctx, filename, data.ProcessingStatusCancelled, nil)
+}
+
+// AbortStaleMultipartUploads aborts the multipart uploads initiated more than the specified number of hours ago
+// and not completed since, so that S3 frees the storage of their parts. The files still awaiting upload are recorded as failed.
+// Only the uploads under the upload key prefix are aborted.
+func (s *DefaultService) AbortStaleMultipartUploads(ctx context.Context, hours int) error {
+	uploads, err := s.s3Client.ListMultipartUploads(ctx)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	staleTime := time.Now().Add(-time.Duration(hours) * time.Hour)
+	for _, upload := range uploads {
+		if upload.Initiated.After(staleTime) || !strings.HasPrefix(upload.Key, s.uploadKeyPrefix) {
+			continue
+		}
+		err = s.abortStaleMultipartUpload(ctx, upload)
+		if err != nil {
+			s.logger.Error("failed to abort stale multipart upload", zap.String("filename", upload.Key), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// abortStaleMultipartUpload aborts a stale multipart upload and records its file as failed if it is still awaiting upload.
+func (s *DefaultService) abortStaleMultipartUpload(ctx context.Context, upload s3.MultipartUpload) error {
+	err := s.s3Client.AbortMultipartUpload(ctx, upload.Key, upload.UploadID)
+	if err != nil {
+		return err
+	}
+	filename := strings.TrimPrefix(upload.Key, s.uploadKeyPrefix)
+	s.logger.Info("stale multipart upload aborted", zap.String("filename", filename), zap.Time("initiated", upload.Initiated))
+
+	status, err := s.repository.GetProcessingStatus(ctx, filename)
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if status.Status != data.ProcessingStatusAwaitingUpload {
+		return nil
+	}
+	return s.UpdateProcessingStatus(ctx, filename, data.ProcessingStatusFailed, errors.New("multipart upload expired"))
+}
+
+// checkAwaitingUpload returns an error if no upload ID is given or if the file is not awaiting upload.
+func (s *DefaultService) checkAwaitingUpload(ctx context.Context, filename string, uploadID string) error {
+	if uploadID == "" {
+		return E.NewErrInvalidArgument("upload_id is required")
+	}
+	status, err := s.repository.GetProcessingStatus(ctx, filename)
+	if err != nil {
+		return err
+	}
+	if status.Status != data.ProcessingStatusAwaitingUpload {
+		return E.NewErrInvalidArgument("file is not awaiting upload")
+	}
+	return nil
+}
+
+// presignParts returns the presigned URLs of parts of a multipart upload.
+func (s *DefaultService) presignParts(ctx context.Context, filename string, uploadID string, partNumbers []int32) ([]data.PartURL, error) {
+	parts := make([]data.PartURL, 0, len(partNumbers))
+	for _, partNumber := range partNumbers {
+		url, err := s.s3Client.GeneratePresignedPartURL(ctx, s.objectKey(filename), uploadID, partNumber)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, data.PartURL{PartNumber: partNumber, URL: url})
+	}
+	return parts, nil
+}
@@ -0,0 +1,203 @@
+package ohlc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	E "github.com/teezzan/candles/internal/errors"
+	"go.uber.org/zap"
+)
+
+func TestDefaultService_InitiateMultipartUpload(t *testing.T) {
+	tests := []struct {
+		name      string
+		partCount int
+		wantErr   bool
+	}{
+		{
+			name:      "three parts",
+			partCount: 3,
+		},
+		{
+			name:    "no parts",
+			wantErr: true,
+		},
+		{
+			name:      "too many parts",
+			partCount: maxMultipartParts + 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				logger       = zap.NewNop()
+				mockS3Client = &s3.ClientMock{
+					CreateMultipartUploadFunc: func(ctx context.Context, key string, conditions s3.UploadConditions) (string, error) {
+						return "upload-id", nil
+					},
+					GeneratePresignedPartURLFunc: func(ctx context.Context, key string, uploadID string, partNumber int32) (string, error) {
+						return fmt.Sprintf("https://test.com/%s?partNumber=%d&uploadId=%s", key, partNumber, uploadID), nil
+					},
+				}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{
+					InsertProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+						return nil
+					},
+				}
+			)
+			conf := config.Init()
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			got, err := s.InitiateMultipartUpload(ctx, data.InitiateMultipartUploadRequest{PartCount: tt.partCount})
+			require.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Empty(t, mockS3Client.CreateMultipartUploadCalls())
+				return
+			}
+
+			assert.Equal(t, "upload-id", got.UploadID)
+			require.Len(t, got.Parts, tt.partCount)
+			for i, part := range got.Parts {
+				assert.Equal(t, int32(i+1), part.PartNumber)
+				assert.NotEmpty(t, part.URL)
+			}
+			calls := mockRepository.InsertProcessingStatusCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, got.Filename, calls[0].Status.FileName)
+			assert.Equal(t, data.ProcessingStatusAwaitingUpload, calls[0].Status.Status)
+		})
+	}
+}
+
+func TestDefaultService_CompleteMultipartUpload(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    data.ProcessingStatus
+		parts     []data.CompletedPart
+		wantParts []s3.CompletedPart
+		wantErr   bool
+	}{
+		{
+			name:   "parts are sorted",
+			status: data.ProcessingStatusAwaitingUpload,
+			parts: []data.CompletedPart{
+				{PartNumber: 2, ETag: "b"},
+				{PartNumber: 1, ETag: "a"},
+			},
+			wantParts: []s3.CompletedPart{
+				{PartNumber: 1, ETag: "a"},
+				{PartNumber: 2, ETag: "b"},
+			},
+		},
+		{
+			name:   "duplicate part",
+			status: data.ProcessingStatusAwaitingUpload,
+			parts: []data.CompletedPart{
+				{PartNumber: 1, ETag: "a"},
+				{PartNumber: 1, ETag: "b"},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "file already uploaded",
+			status: data.ProcessingStatusCompleted,
+			parts: []data.CompletedPart{
+				{PartNumber: 1, ETag: "a"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx          = context.Background()
+				logger       = zap.NewNop()
+				mockS3Client = &s3.ClientMock{
+					CompleteMultipartUploadFunc: func(ctx context.Context, key string, uploadID string, parts []s3.CompletedPart) error {
+						return nil
+					},
+				}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{
+					GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+						return &data.ProcessingStatusEntity{FileName: fileName, Status: tt.status}, nil
+					},
+				}
+			)
+			conf := config.Init()
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			err := s.CompleteMultipartUpload(ctx, "test.csv", data.CompleteMultipartUploadRequest{
+				UploadID: "upload-id",
+				Parts:    tt.parts,
+			})
+			require.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Empty(t, mockS3Client.CompleteMultipartUploadCalls())
+				return
+			}
+			calls := mockS3Client.CompleteMultipartUploadCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, tt.wantParts, calls[0].Parts)
+		})
+	}
+}
+
+func TestDefaultService_AbortStaleMultipartUploads(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		logger       = zap.NewNop()
+		now          = time.Now()
+		mockS3Client = &s3.ClientMock{
+			ListMultipartUploadsFunc: func(ctx context.Context) ([]s3.MultipartUpload, error) {
+				return []s3.MultipartUpload{
+					{Key: "stale.csv", UploadID: "1", Initiated: now.Add(-48 * time.Hour)},
+					{Key: "unknown.csv", UploadID: "2", Initiated: now.Add(-48 * time.Hour)},
+					{Key: "recent.csv", UploadID: "3", Initiated: now.Add(-time.Hour)},
+				}, nil
+			},
+			AbortMultipartUploadFunc: func(ctx context.Context, key string, uploadID string) error {
+				return nil
+			},
+		}
+		mockSQSClient  = &sqs.ClientMock{}
+		mockRepository = &repository.RepositoryMock{
+			GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+				if fileName == "unknown.csv" {
+					return nil, E.NewErrEntityNotFound("file", fileName)
+				}
+				return &data.ProcessingStatusEntity{FileName: fileName, Status: data.ProcessingStatusAwaitingUpload}, nil
+			},
+			UpdateProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+				return nil
+			},
+		}
+	)
+	conf := config.Init()
+
+	s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+	err := s.AbortStaleMultipartUploads(ctx, 24)
+	require.NoError(t, err)
+
+	aborted := mockS3Client.AbortMultipartUploadCalls()
+	require.Len(t, aborted, 2)
+	assert.Equal(t, "stale.csv", aborted[0].Key)
+	assert.Equal(t, "unknown.csv", aborted[1].Key)
+	updates := mockRepository.UpdateProcessingStatusCalls()
+	require.Len(t, updates, 1)
+	assert.Equal(t, "stale.csv", updates[0].Status.FileName)
+	assert.Equal(t, data.ProcessingStatusFailed, updates[0].Status.Status)
+}
@@ -0,0 +1,32 @@
+package ohlc
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MaintainPartitions creates the monthly partitions of the data points for the current month and the `monthsAhead`
+// next ones, so that inserts never fall back to the catch-all partition. If `retentionInMonths` is positive, the
+// partitions of the months older than the retention period are dropped, removing their data points.
+func (s *DefaultService) MaintainPartitions(ctx context.Context, monthsAhead int, retentionInMonths int) error {
+	month := time.Now().UTC()
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	err := s.repository.CreatePartitions(ctx, month.AddDate(0, monthsAhead, 0))
+	if err != nil {
+		return err
+	}
+	if retentionInMonths <= 0 {
+		return nil
+	}
+
+	cutoff := month.AddDate(0, -retentionInMonths, 0)
+	err = s.repository.DropPartitions(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	s.logger.Info("expired partitions dropped", zap.Time("before", cutoff))
+	return nil
+}
@@ -0,0 +1,65 @@
+package ohlc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"go.uber.org/zap"
+)
+
+func TestDefaultService_MaintainPartitions(t *testing.T) {
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		retentionInMonths int
+		wantDropped       bool
+	}{
+		{
+			name:              "partitions kept forever",
+			retentionInMonths: 0,
+		},
+		{
+			name:              "expired partitions dropped",
+			retentionInMonths: 12,
+			wantDropped:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepository := &repository.RepositoryMock{
+				CreatePartitionsFunc: func(ctx context.Context, until time.Time) error {
+					return nil
+				},
+				DropPartitionsFunc: func(ctx context.Context, before time.Time) error {
+					return nil
+				},
+			}
+			conf := config.Init()
+
+			s := newService(t, zap.NewNop(), mockRepository, &s3.ClientMock{}, &sqs.ClientMock{}, &webhook.ClientMock{}, conf.OHLCConfig)
+			err := s.MaintainPartitions(context.Background(), 3, tt.retentionInMonths)
+			require.NoError(t, err)
+
+			created := mockRepository.CreatePartitionsCalls()
+			require.Len(t, created, 1)
+			assert.Equal(t, month.AddDate(0, 3, 0), created[0].Until)
+			dropped := mockRepository.DropPartitionsCalls()
+			if !tt.wantDropped {
+				assert.Empty(t, dropped)
+				return
+			}
+			require.Len(t, dropped, 1)
+			assert.Equal(t, month.AddDate(0, -12, 0), dropped[0].Before)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package ohlc
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/null"
+)
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ingestionProgress tracks the progress of a file ingestion. A nil ingestionProgress tracks nothing.
+type ingestionProgress struct {
+	status     data.ProcessingStatusEntity
+	reader     *countingReader
+	symbols    map[string]struct{}
+	lastUpdate time.Time
+}
+
+// newIngestionProgress starts tracking the ingestion of a file of `size` bytes read through `reader`.
+func newIngestionProgress(filename string, reader *countingReader, size int64) *ingestionProgress {
+	now := time.Now()
+	return &ingestionProgress{
+		status: data.ProcessingStatusEntity{
+			FileName:   filename,
+			BytesTotal: size,
+			StartedAt:  null.NewTime(now),
+		},
+		reader:     reader,
+		symbols:    map[string]struct{}{},
+		lastUpdate: now,
+	}
+}
+
+// parsed records a parsed row.
+func (p *ingestionProgress) parsed() {
+	if p == nil {
+		return
+	}
+	p.status.RowsParsed++
+}
+
+// rejected records rows that were not inserted.
+func (p *ingestionProgress) rejected(n int) {
+	if p == nil {
+		return
+	}
+	p.status.RowsRejected += int64(n)
+}
+
+// inserted records inserted data points, the symbols they touch and the time range they cover.
+func (p *ingestionProgress) inserted(points []data.OHLCEntity) {
+	if p == nil {
+		return
+	}
+	p.status.RowsInserted += int64(len(points))
+	for _, point := range points {
+		p.symbols[point.Symbol] = struct{}{}
+		if !p.status.RangeStart.Valid || point.Time.Before(p.status.RangeStart.Time) {
+			p.status.RangeStart = null.NewTime(point.Time)
+		}
+		if !p.status.RangeEnd.Valid || point.Time.After(p.status.RangeEnd.Time) {
+			p.status.RangeEnd = null.NewTime(point.Time)
+		}
+	}
+}
+
+// discardInserted forgets the inserted data points after they were rolled back.
+func (p *ingestionProgress) discardInserted() {
+	p.status.RowsInserted = 0
+	p.status.RangeStart = null.NewInvalidTime()
+	p.status.RangeEnd = null.NewInvalidTime()
+	p.symbols = map[string]struct{}{}
+}
+
+// due reports whether the progress was last persisted at least `interval` before `now`.
+func (p *ingestionProgress) due(now time.Time, interval time.Duration) bool {
+	return now.Sub(p.lastUpdate) >= interval
+}
+
+// snapshot returns the current progress.
+func (p *ingestionProgress) snapshot() data.ProcessingStatusEntity {
+	status := p.status
+	if p.reader != nil {
+		status.BytesRead = p.reader.n
+	}
+	status.Symbols = make(data.Symbols, 0, len(p.symbols))
+	for symbol := range p.symbols {
+		status.Symbols = append(status.Symbols, symbol)
+	}
+	sort.Strings(status.Symbols)
+	return status
+}
+
+// estimateCompletion estimates when the ingestion of a file in progress completes by extrapolating
+// the rate at which its bytes were read until its last progress update.
+// It returns an invalid time if the progress does not allow an estimation.
+func estimateCompletion(status data.ProcessingStatusEntity) null.Time {
+	if !isActive(status.Status) || !status.StartedAt.Valid ||
+		status.BytesRead <= 0 || status.BytesTotal <= 0 || status.BytesRead > status.BytesTotal {
+		return null.NewInvalidTime()
+	}
+	elapsed := status.UpdatedAt.Sub(status.StartedAt.Time)
+	if elapsed < 0 {
+		return null.NewInvalidTime()
+	}
+	remaining := time.Duration(float64(elapsed) * float64(status.BytesTotal-status.BytesRead) / float64(status.BytesRead))
+	return null.NewTime(status.UpdatedAt.Add(remaining))
+}
@@ -0,0 +1,136 @@
+package ohlc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+func TestDefaultService_ingestCSV(t *testing.T) {
+	csvData := `UNIX,SYMBOL,OPEN,HIGH,LOW,CLOSE
+1610000000,BTC,100,200,50,150
+1610000060,ETH,a150,250,100,200
+1610000120,ETH,150,250,100,200
+`
+	tests := []struct {
+		name      string
+		mode      data.IngestionMode
+		wantCalls int
+	}{
+		{
+			name:      "transaction mode only reports the final progress",
+			mode:      data.IngestionModeTransaction,
+			wantCalls: 1,
+		},
+		{
+			name:      "batch mode reports the progress of every batch",
+			mode:      data.IngestionModeBatch,
+			wantCalls: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx            = context.Background()
+				logger         = zap.NewNop()
+				mockS3Client   = &s3.ClientMock{}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{
+					InsertDataPointsFunc: func(ctx context.Context, rows []data.OHLCEntity) error {
+						return nil
+					},
+					UpdateProcessingProgressFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+						return nil
+					},
+				}
+			)
+			mockRepository.WithTransactionFunc = func(ctx context.Context, fn func(repository.Repository) error) error {
+				return fn(mockRepository)
+			}
+			conf := config.Init()
+			conf.OHLCConfig.RollupIntervals = nil
+			conf.OHLCConfig.DiscardInCompleteRow = true
+			conf.OHLCConfig.IngestionMode = string(tt.mode)
+			conf.OHLCConfig.IngestionBatchSize = 1
+			conf.OHLCConfig.ProgressUpdateIntervalInSeconds = 0
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			_, err := s.ingestCSV(ctx, "test.csv", strings.NewReader(csvData), int64(len(csvData)), fileOptions{format: data.FileFormatCSV})
+			require.NoError(t, err)
+
+			calls := mockRepository.UpdateProcessingProgressCalls()
+			require.Len(t, calls, tt.wantCalls)
+			got := calls[len(calls)-1].Status
+			assert.Equal(t, "test.csv", got.FileName)
+			assert.Equal(t, int64(len(csvData)), got.BytesTotal)
+			assert.Equal(t, int64(len(csvData)), got.BytesRead)
+			assert.Equal(t, int64(3), got.RowsParsed)
+			assert.Equal(t, int64(2), got.RowsInserted)
+			assert.Equal(t, int64(1), got.RowsRejected)
+			assert.Equal(t, data.Symbols{"BTC", "ETH"}, got.Symbols)
+			assert.Equal(t, time.Unix(1610000000, 0), got.RangeStart.Time)
+			assert.Equal(t, time.Unix(1610000120, 0), got.RangeEnd.Time)
+			assert.True(t, got.StartedAt.Valid)
+		})
+	}
+}
+
+func Test_estimateCompletion(t *testing.T) {
+	startedAt := time.Unix(1610000000, 0)
+	tests := []struct {
+		name   string
+		status data.ProcessingStatusEntity
+		want   null.Time
+	}{
+		{
+			name: "quarter of the file read",
+			status: data.ProcessingStatusEntity{
+				Status:     data.ProcessingStatusInProgress,
+				BytesTotal: 400,
+				BytesRead:  100,
+				StartedAt:  null.NewTime(startedAt),
+				UpdatedAt:  startedAt.Add(time.Minute),
+			},
+			want: null.NewTime(startedAt.Add(4 * time.Minute)),
+		},
+		{
+			name: "nothing read yet",
+			status: data.ProcessingStatusEntity{
+				Status:     data.ProcessingStatusInProgress,
+				BytesTotal: 400,
+				StartedAt:  null.NewTime(startedAt),
+				UpdatedAt:  startedAt.Add(time.Minute),
+			},
+			want: null.NewInvalidTime(),
+		},
+		{
+			name: "completed",
+			status: data.ProcessingStatusEntity{
+				Status:     data.ProcessingStatusCompleted,
+				BytesTotal: 400,
+				BytesRead:  400,
+				StartedAt:  null.NewTime(startedAt),
+				UpdatedAt:  startedAt.Add(time.Minute),
+			},
+			want: null.NewInvalidTime(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, estimateCompletion(tt.status))
+		})
+	}
+}
@@ -11,10 +11,37 @@ import (
 
 // Repository defines the period repository.
 type Repository interface {
+	WithTransaction(ctx context.Context, fn func(Repository) error) error
 	InsertDataPoints(ctx context.Context, rows []data.OHLCEntity) error
 	GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error)
+	GetDataPointsInRange(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.OHLCEntity, error)
+	GetSymbols(ctx context.Context) ([]string, error)
+	GetLatestDataPoints(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error)
+	DeleteDataPoint(ctx context.Context, symbol string, t time.Time) error
+	CountDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error)
+	DeleteDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error)
+	CreatePartitions(ctx context.Context, until time.Time) error
+	DropPartitions(ctx context.Context, before time.Time) error
+	DeleteDataPointsBefore(ctx context.Context, symbol string, before time.Time) (int64, error)
+	UpsertRollups(ctx context.Context, rows []data.RollupEntity) error
+	GetRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) ([]data.RollupEntity, error)
+	GetOldestRollups(ctx context.Context, symbol string, intervalSeconds int64, limit int) ([]data.RollupEntity, error)
+	DeleteRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) (int64, error)
+	GetRollupSymbols(ctx context.Context) ([]string, error)
 	RemoveStaleProcessingStatus(ctx context.Context, staleTime time.Time) error
 	UpdateProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error
+	UpdateProcessingProgress(ctx context.Context, status data.ProcessingStatusEntity) error
 	InsertProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error
 	GetProcessingStatus(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error)
+	GetProcessingStatuses(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, error)
+	InsertWebhookDelivery(ctx context.Context, delivery data.WebhookDeliveryEntity) error
+	GetWebhookDeliveries(ctx context.Context, fileName string) ([]data.WebhookDeliveryEntity, error)
+	InsertRejectedMessage(ctx context.Context, message data.RejectedMessageEntity) error
+	GetRejectedMessages(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, error)
+	UpsertDataQualityReports(ctx context.Context, reports []data.DataQualityEntity) error
+	GetDataQualityReports(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.DataQualityEntity, error)
+	InsertAnomalies(ctx context.Context, anomalies []data.AnomalyEntity) error
+	GetAnomalies(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, error)
+	GetAnomaly(ctx context.Context, id int64) (*data.AnomalyEntity, error)
+	DeleteAnomaly(ctx context.Context, id int64) error
 }
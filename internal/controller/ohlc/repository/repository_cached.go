@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/teezzan/candles/internal/cache"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+)
+
+var _ Repository = (*CachedRepository)(nil)
+
+// cachedTx tracks the symbols written within a transaction of a cached repository.
+type cachedTx struct {
+	mu      sync.Mutex
+	symbols map[string]bool
+}
+
+// CachedRepository decorates a repository with a cache of the candle queries, such as the recent windows polled by
+// dashboards. The cached queries of a symbol are invalidated whenever its data points or rollups are written.
+// Within a transaction, the queries bypass the cache and the written symbols are invalidated once the transaction ends.
+// A query racing with a write may still cache a result missing the write, until it expires.
+type CachedRepository struct {
+	Repository
+	cache *cache.Cache
+	tx    *cachedTx
+}
+
+// NewCachedRepository initializes a new repository caching the candle queries of another one.
+func NewCachedRepository(repo Repository, c *cache.Cache) *CachedRepository {
+	return &CachedRepository{
+		Repository: repo,
+		cache:      c,
+	}
+}
+
+// WithTransaction runs fn with a repository bound to a new transaction of the decorated repository. The symbols
+// written by fn are invalidated once the transaction ends, whether it is committed or not, since a failed commit may
+// still have been applied.
+func (r *CachedRepository) WithTransaction(ctx context.Context, fn func(Repository) error) error {
+	tx := r.tx
+	if tx == nil {
+		tx = &cachedTx{symbols: map[string]bool{}}
+		defer func() {
+			r.cache.Invalidate(keys(tx.symbols)...)
+		}()
+	}
+	return r.Repository.WithTransaction(ctx, func(repo Repository) error {
+		return fn(&CachedRepository{Repository: repo, cache: r.cache, tx: tx})
+	})
+}
+
+// InsertDataPoints inserts data points and invalidates the cached queries of their symbols.
+func (r *CachedRepository) InsertDataPoints(ctx context.Context, rows []data.OHLCEntity) error {
+	defer r.invalidate(dataPointSymbols(rows)...)
+	return r.Repository.InsertDataPoints(ctx, rows)
+}
+
+// GetDataPoints retrieves a page of data points, from the cache if possible.
+func (r *CachedRepository) GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error) {
+	key := fmt.Sprintf("points:%d:%d:%d:%d", payload.StartTime, payload.EndTime.Int64, payload.PageNumber.Int64, payload.PageSize.Int64)
+	return cachedQuery(ctx, r, payload.Symbol, key, func() ([]data.OHLCEntity, error) {
+		return r.Repository.GetDataPoints(ctx, payload)
+	})
+}
+
+// GetDataPointsInRange retrieves the data points of a symbol between the start and end times, from the cache if possible.
+func (r *CachedRepository) GetDataPointsInRange(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.OHLCEntity, error) {
+	key := fmt.Sprintf("range:%d:%d", startTime.UnixNano(), endTime.UnixNano())
+	return cachedQuery(ctx, r, symbol, key, func() ([]data.OHLCEntity, error) {
+		return r.Repository.GetDataPointsInRange(ctx, symbol, startTime, endTime)
+	})
+}
+
+// GetLatestDataPoints retrieves the latest data points of a symbol before a time, from the cache if possible.
+func (r *CachedRepository) GetLatestDataPoints(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error) {
+	key := fmt.Sprintf("latest:%d:%d", before.UnixNano(), limit)
+	return cachedQuery(ctx, r, symbol, key, func() ([]data.OHLCEntity, error) {
+		return r.Repository.GetLatestDataPoints(ctx, symbol, before, limit)
+	})
+}
+
+// DeleteDataPoint deletes a data point and invalidates the cached queries of its symbol.
+func (r *CachedRepository) DeleteDataPoint(ctx context.Context, symbol string, t time.Time) error {
+	defer r.invalidate(symbol)
+	return r.Repository.DeleteDataPoint(ctx, symbol, t)
+}
+
+// DeleteDataPointsBySourceFile deletes the data points of a file and invalidates the cached queries of every symbol,
+// since the symbols of the file are not known.
+func (r *CachedRepository) DeleteDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	symbols, err := r.Repository.GetSymbols(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer r.invalidate(symbols...)
+	return r.Repository.DeleteDataPointsBySourceFile(ctx, fileName)
+}
+
+// DropPartitions drops the partitions of the data points before a time and invalidates the cached queries of every
+// symbol.
+func (r *CachedRepository) DropPartitions(ctx context.Context, before time.Time) error {
+	symbols, err := r.Repository.GetSymbols(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.invalidate(symbols...)
+	return r.Repository.DropPartitions(ctx, before)
+}
+
+// DeleteDataPointsBefore deletes the data points of a symbol before a time and invalidates its cached queries.
+func (r *CachedRepository) DeleteDataPointsBefore(ctx context.Context, symbol string, before time.Time) (int64, error) {
+	defer r.invalidate(symbol)
+	return r.Repository.DeleteDataPointsBefore(ctx, symbol, before)
+}
+
+// UpsertRollups inserts or replaces rollups and invalidates the cached queries of their symbols.
+func (r *CachedRepository) UpsertRollups(ctx context.Context, rows []data.RollupEntity) error {
+	defer r.invalidate(rollupSymbols(rows)...)
+	return r.Repository.UpsertRollups(ctx, rows)
+}
+
+// GetRollups retrieves the rollups of a symbol and interval between the start and end times, from the cache if possible.
+func (r *CachedRepository) GetRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) ([]data.RollupEntity, error) {
+	key := fmt.Sprintf("rollups:%d:%d:%d", intervalSeconds, startTime.UnixNano(), endTime.UnixNano())
+	return cachedQuery(ctx, r, symbol, key, func() ([]data.RollupEntity, error) {
+		return r.Repository.GetRollups(ctx, symbol, intervalSeconds, startTime, endTime)
+	})
+}
+
+// DeleteRollups deletes the rollups of a symbol and interval between the start and end times and invalidates the cached
+// queries of the symbol.
+func (r *CachedRepository) DeleteRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) (int64, error) {
+	defer r.invalidate(symbol)
+	return r.Repository.DeleteRollups(ctx, symbol, intervalSeconds, startTime, endTime)
+}
+
+// cachedQuery returns the cached result of a query of a symbol. On a miss, the result is retrieved with query,
+// then cached. Within a transaction, the cache is bypassed.
+func cachedQuery[T any](ctx context.Context, r *CachedRepository, symbol string, key string, query func() (T, error)) (T, error) {
+	if r.tx != nil {
+		return query()
+	}
+
+	var result T
+	value, ok := r.cache.Get(ctx, symbol, key)
+	if ok && json.Unmarshal(value, &result) == nil {
+		return result, nil
+	}
+	result, err := query()
+	if err != nil {
+		return result, err
+	}
+	value, err = json.Marshal(result)
+	if err == nil {
+		r.cache.Set(ctx, symbol, key, value)
+	}
+	return result, nil
+}
+
+// invalidate invalidates the cached queries of the symbols, or defers it until the end of the transaction the
+// repository is bound to.
+func (r *CachedRepository) invalidate(symbols ...string) {
+	if r.tx == nil {
+		r.cache.Invalidate(symbols...)
+		return
+	}
+	r.tx.mu.Lock()
+	defer r.tx.mu.Unlock()
+	for _, symbol := range symbols {
+		r.tx.symbols[symbol] = true
+	}
+}
+
+// dataPointSymbols returns the distinct symbols of the data points.
+func dataPointSymbols(points []data.OHLCEntity) []string {
+	symbols := map[string]bool{}
+	for _, p := range points {
+		symbols[p.Symbol] = true
+	}
+	return keys(symbols)
+}
+
+// rollupSymbols returns the distinct symbols of the rollups.
+func rollupSymbols(rollups []data.RollupEntity) []string {
+	symbols := map[string]bool{}
+	for _, rollup := range rollups {
+		symbols[rollup.Symbol] = true
+	}
+	return keys(symbols)
+}
+
+// keys returns the keys of a set.
+func keys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/cache"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+func TestCachedRepository(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock := &RepositoryMock{
+		GetDataPointsFunc: func(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error) {
+			return []data.OHLCEntity{{Time: start, Symbol: payload.Symbol, Open: decimal.RequireFromString("0.3"), SourceFile: null.NewString("a.csv")}}, nil
+		},
+		GetLatestDataPointsFunc: func(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error) {
+			return nil, errors.New("unavailable")
+		},
+		InsertDataPointsFunc: func(ctx context.Context, rows []data.OHLCEntity) error {
+			return nil
+		},
+	}
+	mock.WithTransactionFunc = func(ctx context.Context, fn func(Repository) error) error {
+		return fn(mock)
+	}
+	c := cache.New(zap.NewNop(), nil, cache.NewMemoryStore(100), time.Minute)
+	repo := NewCachedRepository(mock, c)
+
+	query := func(symbol string) []data.OHLCEntity {
+		points, err := repo.GetDataPoints(ctx, data.GetOHLCRequest{Symbol: symbol, StartTime: start.Unix(), EndTime: null.NewInt64(start.Unix() + 60)})
+		require.NoError(t, err)
+		return points
+	}
+
+	// The second query is served from the cache, as it was returned by the repository.
+	query("BTC")
+	points := query("BTC")
+	require.Len(t, points, 1)
+	assert.True(t, points[0].Time.Equal(start))
+	assert.Equal(t, "0.3", points[0].Open.String())
+	assert.Equal(t, "a.csv", points[0].SourceFile.String)
+	query("ETH")
+	assert.Len(t, mock.GetDataPointsCalls(), 2)
+
+	// Inserting data points invalidates the queries of their symbols only.
+	require.NoError(t, repo.InsertDataPoints(ctx, []data.OHLCEntity{{Time: start, Symbol: "BTC"}}))
+	query("BTC")
+	query("ETH")
+	assert.Len(t, mock.GetDataPointsCalls(), 3)
+
+	// Within a transaction, queries bypass the cache and the invalidations are deferred until the transaction ends.
+	err := repo.WithTransaction(ctx, func(tx Repository) error {
+		_, err := tx.GetDataPoints(ctx, data.GetOHLCRequest{Symbol: "ETH", StartTime: start.Unix(), EndTime: null.NewInt64(start.Unix() + 60)})
+		require.NoError(t, err)
+		assert.Len(t, mock.GetDataPointsCalls(), 4)
+		require.NoError(t, tx.InsertDataPoints(ctx, []data.OHLCEntity{{Time: start, Symbol: "ETH"}}))
+		query("ETH")
+		assert.Len(t, mock.GetDataPointsCalls(), 4)
+		return nil
+	})
+	require.NoError(t, err)
+	query("ETH")
+	assert.Len(t, mock.GetDataPointsCalls(), 5)
+
+	// Errors are not cached.
+	for i := 0; i < 2; i++ {
+		_, err = repo.GetLatestDataPoints(ctx, "BTC", start, 10)
+		assert.Error(t, err)
+	}
+	assert.Len(t, mock.GetLatestDataPointsCalls(), 2)
+
+	stats := c.Stats()
+	assert.Equal(t, int64(3), stats.Hits)
+	assert.Equal(t, int64(6), stats.Misses)
+	assert.Equal(t, int64(2), stats.Invalidations)
+}
@@ -0,0 +1,674 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/cache"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	E "github.com/teezzan/candles/internal/errors"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+// conformanceTables are the tables emptied before the conformance suite runs against a database server.
+var conformanceTables = []string{"ohlc_data", "process_status", "data_quality", "ohlc_anomaly", "webhook_delivery", "rejected_message", "ohlc_rollup"}
+
+func TestMemoryRepository_Conformance(t *testing.T) {
+	testRepositoryConformance(t, func(t *testing.T) Repository {
+		return NewMemoryRepository()
+	})
+}
+
+func TestCachedRepository_Conformance(t *testing.T) {
+	testRepositoryConformance(t, func(t *testing.T) Repository {
+		c := cache.New(zap.NewNop(), nil, cache.NewMemoryStore(1000), time.Minute)
+		return NewCachedRepository(NewMemoryRepository(), c)
+	})
+}
+
+func TestSQLiteRepository_Conformance(t *testing.T) {
+	testRepositoryConformance(t, func(t *testing.T) Repository {
+		return newSQLiteRepository(t)
+	})
+}
+
+// TestMySQLRepository_Conformance runs against the migrated MySQL database of TEST_MYSQL_DSN, if set.
+// Its tables are emptied before each test.
+func TestMySQLRepository_Conformance(t *testing.T) {
+	db := openTestDatabase(t, "mysql", "TEST_MYSQL_DSN")
+	testRepositoryConformance(t, func(t *testing.T) Repository {
+		truncateTables(t, db)
+		return NewRepository(db)
+	})
+}
+
+// TestPostgresRepository_Conformance runs against the migrated PostgreSQL database of TEST_POSTGRES_DSN, if set.
+// Its tables are emptied before each test.
+func TestPostgresRepository_Conformance(t *testing.T) {
+	db := openTestDatabase(t, "postgres", "TEST_POSTGRES_DSN")
+	testRepositoryConformance(t, func(t *testing.T) Repository {
+		truncateTables(t, db)
+		return NewPostgresRepository(db)
+	})
+}
+
+// openTestDatabase connects to the database of the DSN held by the environment variable, or skips the test if it is unset.
+func openTestDatabase(t *testing.T, driver string, env string) *sqlx.DB {
+	dsn := os.Getenv(env)
+	if dsn == "" {
+		t.Skipf("%s is not set", env)
+	}
+	db, err := sqlx.Connect(driver, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+func truncateTables(t *testing.T, db *sqlx.DB) {
+	t.Helper()
+	for _, table := range conformanceTables {
+		_, err := db.Exec(fmt.Sprintf("DELETE FROM %s", table))
+		require.NoError(t, err)
+	}
+}
+
+// testRepositoryConformance checks that a repository implementation honours the semantics every implementation shares.
+// newRepository must return an empty repository.
+func testRepositoryConformance(t *testing.T, newRepository func(t *testing.T) Repository) {
+	ctx := context.Background()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	zone := time.FixedZone("UTC+1", 3600)
+	one := decimal.NewFromInt(1)
+	point := func(symbol string, minute int, file string) data.OHLCEntity {
+		return data.OHLCEntity{
+			Time:       start.Add(time.Duration(minute) * time.Minute).In(zone),
+			Symbol:     symbol,
+			Open:       decimal.NewFromInt(int64(minute)),
+			High:       decimal.NewFromInt(int64(minute) + 2),
+			Low:        decimal.NewFromInt(int64(minute) - 1),
+			Close:      decimal.NewFromInt(int64(minute) + 1),
+			SourceFile: null.NewString(file),
+		}
+	}
+
+	t.Run("data points", func(t *testing.T) {
+		r := newRepository(t)
+		points := []data.OHLCEntity{point("BTC", 3, "a.csv"), point("BTC", 0, "a.csv"), point("ETH", 1, "b.csv")}
+		for i := 1; i < 5; i++ {
+			if i != 3 {
+				points = append(points, point("BTC", i, "b.csv"))
+			}
+		}
+		require.NoError(t, r.InsertDataPoints(ctx, points))
+		require.NoError(t, r.InsertDataPoints(ctx, nil))
+
+		tests := []struct {
+			name       string
+			start, end int
+			pageNumber int
+			pageSize   int
+			want       []int
+		}{
+			{name: "inclusive range ordered by time", start: 1, end: 3, pageNumber: 1, pageSize: 10, want: []int{1, 2, 3}},
+			{name: "first page", start: 0, end: 4, pageNumber: 1, pageSize: 2, want: []int{0, 1}},
+			{name: "last page", start: 0, end: 4, pageNumber: 3, pageSize: 2, want: []int{4}},
+			{name: "page past the end", start: 0, end: 4, pageNumber: 4, pageSize: 2, want: []int{}},
+			{name: "empty range", start: 5, end: 10, pageNumber: 1, pageSize: 10, want: []int{}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := r.GetDataPoints(ctx, data.GetOHLCRequest{
+					Symbol:     "BTC",
+					StartTime:  start.Add(time.Duration(tt.start) * time.Minute).Unix(),
+					EndTime:    null.NewInt64(start.Add(time.Duration(tt.end) * time.Minute).Unix()),
+					PageNumber: null.NewInt(tt.pageNumber),
+					PageSize:   null.NewInt(tt.pageSize),
+				})
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, minutes(got, start))
+			})
+		}
+
+		got, err := r.GetDataPointsInRange(ctx, "BTC", start.Add(time.Minute), start.Add(4*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4}, minutes(got, start))
+		require.NotEmpty(t, got)
+		assert.Equal(t, "BTC", got[0].Symbol)
+		assert.Equal(t, []string{"1", "3", "0", "2"}, prices(got[0].Open, got[0].High, got[0].Low, got[0].Close))
+
+		got, err = r.GetLatestDataPoints(ctx, "BTC", start.Add(3*time.Minute), 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, minutes(got, start))
+		got, err = r.GetLatestDataPoints(ctx, "ETH", start.Add(time.Hour), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, minutes(got, start))
+
+		symbols, err := r.GetSymbols(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"BTC", "ETH"}, symbols)
+
+		require.NoError(t, r.DeleteDataPoint(ctx, "BTC", start.Add(3*time.Minute)))
+		got, err = r.GetDataPointsInRange(ctx, "BTC", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 4}, minutes(got, start))
+
+		count, err := r.CountDataPointsBySourceFile(ctx, "b.csv")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+		count, err = r.DeleteDataPointsBySourceFile(ctx, "b.csv")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+		count, err = r.DeleteDataPointsBySourceFile(ctx, "b.csv")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+		symbols, err = r.GetSymbols(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"BTC"}, symbols)
+	})
+
+	t.Run("source file with a long S3 key", func(t *testing.T) {
+		r := newRepository(t)
+		file := "uploads/" + strings.Repeat("a", 300) + "/0b5a6f0e-8f63-4c4e-9b5e-0d1f3c2a1b4d.csv"
+		require.NoError(t, r.InsertDataPoints(ctx, []data.OHLCEntity{point("BTC", 0, file)}))
+
+		count, err := r.CountDataPointsBySourceFile(ctx, file)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		count, err = r.DeleteDataPointsBySourceFile(ctx, file)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("decimal prices", func(t *testing.T) {
+		r := newRepository(t)
+		p := point("BTC", 0, "a.csv")
+		p.Open = decimal.RequireFromString("0.3")
+		p.High = decimal.RequireFromString("68123.12345678")
+		p.Low = decimal.RequireFromString("0.00000001")
+		p.Close = decimal.RequireFromString("12.3456789012")
+		require.NoError(t, r.InsertDataPoints(ctx, []data.OHLCEntity{p}))
+		require.NoError(t, r.InsertAnomalies(ctx, []data.AnomalyEntity{data.NewAnomalyEntity(p, "spike", data.AnomalyStatusFlagged)}))
+		require.NoError(t, r.UpsertRollups(ctx, []data.RollupEntity{data.NewRollupEntity(p, time.Hour)}))
+		want := []string{"0.3", "68123.12345678", "0.00000001", "12.3456789012"}
+
+		got, err := r.GetDataPointsInRange(ctx, "BTC", start, start)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, want, prices(got[0].Open, got[0].High, got[0].Low, got[0].Close))
+		anomalies, err := r.GetAnomalies(ctx, data.GetAnomaliesRequest{PageNumber: null.NewInt(1), PageSize: null.NewInt(10)})
+		require.NoError(t, err)
+		require.Len(t, anomalies, 1)
+		assert.Equal(t, want, prices(anomalies[0].Open, anomalies[0].High, anomalies[0].Low, anomalies[0].Close))
+		rollups, err := r.GetRollups(ctx, "BTC", 3600, start, start)
+		require.NoError(t, err)
+		require.Len(t, rollups, 1)
+		assert.Equal(t, want, prices(rollups[0].Open, rollups[0].High, rollups[0].Low, rollups[0].Close))
+	})
+
+	t.Run("partitions", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.InsertDataPoints(ctx, []data.OHLCEntity{point("BTC", 0, "a.csv")}))
+		require.NoError(t, r.CreatePartitions(ctx, time.Now().AddDate(0, 3, 0)))
+		require.NoError(t, r.CreatePartitions(ctx, time.Now().AddDate(0, 3, 0)))
+		require.NoError(t, r.InsertDataPoints(ctx, []data.OHLCEntity{
+			point("BTC", 31*24*60, "a.csv"),
+			point("BTC", 59*24*60, "a.csv"),
+			{Time: time.Now().AddDate(0, 6, 0).Truncate(time.Second), Symbol: "BTC", Open: one, High: one, Low: one, Close: one},
+		}))
+
+		// Only the data points of the months before February are dropped.
+		require.NoError(t, r.DropPartitions(ctx, start.AddDate(0, 1, 15)))
+		got, err := r.GetDataPointsInRange(ctx, "BTC", start, time.Now().AddDate(1, 0, 0))
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.True(t, got[0].Time.Equal(start.AddDate(0, 1, 0)))
+		assert.True(t, got[1].Time.Equal(start.AddDate(0, 1, 28)))
+	})
+
+	t.Run("rollups", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.InsertDataPoints(ctx, []data.OHLCEntity{point("BTC", 0, "a.csv"), point("BTC", 1, "a.csv"), point("ETH", 0, "a.csv")}))
+		count, err := r.DeleteDataPointsBefore(ctx, "BTC", start.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		got, err := r.GetDataPointsInRange(ctx, "BTC", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, minutes(got, start))
+		count, err = r.CountDataPointsBySourceFile(ctx, "a.csv")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count, "the data points of the other symbols are kept")
+
+		symbols, err := r.GetRollupSymbols(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, symbols)
+
+		rollup := func(symbol string, hour int, open int64) data.RollupEntity {
+			return data.RollupEntity{Symbol: symbol, IntervalSeconds: 3600, Time: start.Add(time.Duration(hour) * time.Hour).In(zone), Open: decimal.NewFromInt(open), High: decimal.NewFromInt(open + 2), Low: decimal.NewFromInt(open - 1), Close: decimal.NewFromInt(open + 1)}
+		}
+		require.NoError(t, r.UpsertRollups(ctx, []data.RollupEntity{rollup("BTC", 1, 10), rollup("BTC", 0, 10), rollup("ETH", 0, 10)}))
+		require.NoError(t, r.UpsertRollups(ctx, []data.RollupEntity{rollup("BTC", 0, 20)}))
+		require.NoError(t, r.UpsertRollups(ctx, []data.RollupEntity{{Symbol: "BTC", IntervalSeconds: 86400, Time: start, Open: one, High: one, Low: one, Close: one}}))
+		require.NoError(t, r.UpsertRollups(ctx, nil))
+
+		rollups, err := r.GetRollups(ctx, "BTC", 3600, start, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, rollups, 2)
+		assert.True(t, rollups[0].Time.Equal(start))
+		assert.Equal(t, []string{"20", "22", "19", "21"}, prices(rollups[0].Open, rollups[0].High, rollups[0].Low, rollups[0].Close), "the rollup is replaced")
+		assert.True(t, rollups[1].Time.Equal(start.Add(time.Hour)))
+		assert.Equal(t, int64(3600), rollups[1].IntervalSeconds)
+		rollups, err = r.GetOldestRollups(ctx, "BTC", 3600, 1)
+		require.NoError(t, err)
+		require.Len(t, rollups, 1)
+		assert.True(t, rollups[0].Time.Equal(start))
+
+		count, err = r.DeleteRollups(ctx, "BTC", 3600, start.Add(time.Hour), start.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		rollups, err = r.GetRollups(ctx, "BTC", 3600, start, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, rollups, 1)
+		assert.True(t, rollups[0].Time.Equal(start))
+		rollups, err = r.GetRollups(ctx, "BTC", 86400, start, start)
+		require.NoError(t, err)
+		assert.Len(t, rollups, 1, "the rollups of the other intervals are kept")
+
+		symbols, err = r.GetRollupSymbols(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"BTC", "ETH"}, symbols)
+	})
+
+	t.Run("processing status", func(t *testing.T) {
+		r := newRepository(t)
+
+		_, err := r.GetProcessingStatus(ctx, "a.csv")
+		assert.True(t, E.IsErrEntityNotFound(err))
+
+		require.NoError(t, r.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{
+			FileName:    "a.csv",
+			Status:      data.ProcessingStatusAwaitingUpload,
+			Uploader:    null.NewString("alice"),
+			CallbackURL: null.NewString("http://example.com"),
+			Tags:        data.Tags{"source": "test"},
+		}))
+		status, err := r.GetProcessingStatus(ctx, "a.csv")
+		require.NoError(t, err)
+		assert.Equal(t, data.ProcessingStatusAwaitingUpload, status.Status)
+		assert.Equal(t, data.Symbols{}, status.Symbols)
+		assert.False(t, status.CreatedAt.IsZero())
+
+		finishedAt := start.Add(time.Hour)
+		require.NoError(t, r.UpdateProcessingStatus(ctx, data.ProcessingStatusEntity{
+			FileName:   "a.csv",
+			Status:     data.ProcessingStatusFailed,
+			Error:      null.NewString("test error"),
+			FinishedAt: null.NewTime(finishedAt),
+		}))
+		require.NoError(t, r.UpdateProcessingStatus(ctx, data.ProcessingStatusEntity{
+			FileName: "a.csv",
+			Status:   data.ProcessingStatusFailed,
+			Error:    null.NewString("test error"),
+		}))
+		status, err = r.GetProcessingStatus(ctx, "a.csv")
+		require.NoError(t, err)
+		assert.Equal(t, "test error", status.Error.String)
+		assert.True(t, status.FinishedAt.Time.Equal(finishedAt), "the finish time is kept")
+
+		// Reprocessing the file replaces its status and clears its error and finish time, keeping what is not given anew.
+		require.NoError(t, r.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{
+			FileName: "a.csv",
+			Status:   data.ProcessingStatusQueued,
+			Tags:     data.Tags{},
+		}))
+		status, err = r.GetProcessingStatus(ctx, "a.csv")
+		require.NoError(t, err)
+		assert.False(t, status.FinishedAt.Valid)
+		require.NoError(t, r.UpdateProcessingProgress(ctx, data.ProcessingStatusEntity{
+			FileName:     "a.csv",
+			BytesTotal:   100,
+			BytesRead:    50,
+			RowsParsed:   12,
+			RowsInserted: 10,
+			RowsRejected: 2,
+			Symbols:      data.Symbols{"BTC", "ETH"},
+			RangeStart:   null.NewTime(start),
+			RangeEnd:     null.NewTime(finishedAt),
+			StartedAt:    null.NewTime(start),
+		}))
+		status, err = r.GetProcessingStatus(ctx, "a.csv")
+		require.NoError(t, err)
+		assert.Equal(t, data.ProcessingStatusQueued, status.Status)
+		assert.False(t, status.Error.Valid)
+		assert.Equal(t, "alice", status.Uploader.String)
+		assert.Equal(t, "http://example.com", status.CallbackURL.String)
+		assert.Equal(t, data.Tags{"source": "test"}, status.Tags)
+		assert.Equal(t, []int64{100, 50, 12, 10, 2}, []int64{status.BytesTotal, status.BytesRead, status.RowsParsed, status.RowsInserted, status.RowsRejected})
+		assert.Equal(t, data.Symbols{"BTC", "ETH"}, status.Symbols)
+		assert.True(t, status.RangeStart.Time.Equal(start))
+		assert.True(t, status.RangeEnd.Time.Equal(finishedAt))
+		assert.True(t, status.StartedAt.Time.Equal(start))
+
+		// A file processed again starts its progress over, whereas its outcome keeps it.
+		require.NoError(t, r.UpdateProcessingStatus(ctx, data.ProcessingStatusEntity{
+			FileName:   "a.csv",
+			Status:     data.ProcessingStatusFailed,
+			FinishedAt: null.NewTime(finishedAt),
+		}))
+		status, err = r.GetProcessingStatus(ctx, "a.csv")
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), status.RowsInserted)
+		assert.True(t, status.FinishedAt.Valid)
+		require.NoError(t, r.UpdateProcessingStatus(ctx, data.ProcessingStatusEntity{
+			FileName: "a.csv",
+			Status:   data.ProcessingStatusRetrying,
+		}))
+		status, err = r.GetProcessingStatus(ctx, "a.csv")
+		require.NoError(t, err)
+		assert.Equal(t, data.ProcessingStatusRetrying, status.Status)
+		assert.Equal(t, []int64{0, 0, 0, 0, 0}, []int64{status.BytesTotal, status.BytesRead, status.RowsParsed, status.RowsInserted, status.RowsRejected})
+		assert.Equal(t, data.Symbols{}, status.Symbols)
+		assert.False(t, status.RangeStart.Valid)
+		assert.False(t, status.RangeEnd.Valid)
+		assert.False(t, status.StartedAt.Valid)
+		assert.False(t, status.FinishedAt.Valid)
+
+		// Updating an unknown file is a no-op.
+		require.NoError(t, r.UpdateProcessingStatus(ctx, data.ProcessingStatusEntity{FileName: "unknown.csv", Status: data.ProcessingStatusFailed}))
+		require.NoError(t, r.UpdateProcessingProgress(ctx, data.ProcessingStatusEntity{FileName: "unknown.csv"}))
+		_, err = r.GetProcessingStatus(ctx, "unknown.csv")
+		assert.True(t, E.IsErrEntityNotFound(err))
+	})
+
+	t.Run("processing statuses", func(t *testing.T) {
+		r := newRepository(t)
+		for _, status := range []data.ProcessingStatusEntity{
+			{FileName: "b.csv", Status: data.ProcessingStatusCompleted, Uploader: null.NewString("alice")},
+			{FileName: "a.csv", Status: data.ProcessingStatusFailed, Uploader: null.NewString("bob")},
+			{FileName: "c.csv", Status: data.ProcessingStatusCompleted},
+		} {
+			require.NoError(t, r.InsertProcessingStatus(ctx, status))
+		}
+		require.NoError(t, r.UpdateProcessingProgress(ctx, data.ProcessingStatusEntity{FileName: "c.csv", Symbols: data.Symbols{"ETH"}}))
+
+		tests := []struct {
+			name    string
+			payload data.GetUploadsRequest
+			want    []string
+		}{
+			{
+				name:    "sorted by file name",
+				payload: data.GetUploadsRequest{SortBy: data.UploadSortFieldFileName, Order: data.SortOrderAsc},
+				want:    []string{"a.csv", "b.csv", "c.csv"},
+			},
+			{
+				name:    "sorted by file name descending",
+				payload: data.GetUploadsRequest{SortBy: data.UploadSortFieldFileName, Order: data.SortOrderDesc},
+				want:    []string{"c.csv", "b.csv", "a.csv"},
+			},
+			{
+				name:    "sorted by status, then in order of creation",
+				payload: data.GetUploadsRequest{SortBy: data.UploadSortFieldStatus, Order: data.SortOrderAsc},
+				want:    []string{"b.csv", "c.csv", "a.csv"},
+			},
+			{
+				name:    "status filter",
+				payload: data.GetUploadsRequest{Status: data.ProcessingStatusCompleted},
+				want:    []string{"b.csv", "c.csv"},
+			},
+			{
+				name:    "uploader filter",
+				payload: data.GetUploadsRequest{Uploader: "bob"},
+				want:    []string{"a.csv"},
+			},
+			{
+				name:    "symbol filter",
+				payload: data.GetUploadsRequest{Symbol: "ETH"},
+				want:    []string{"c.csv"},
+			},
+			{
+				name:    "time range",
+				payload: data.GetUploadsRequest{StartTime: null.NewInt64(time.Now().Add(-time.Hour).Unix()), EndTime: null.NewInt64(time.Now().Add(time.Hour).Unix())},
+				want:    []string{"a.csv", "b.csv", "c.csv"},
+			},
+			{
+				name:    "created after the time range",
+				payload: data.GetUploadsRequest{EndTime: null.NewInt64(time.Now().Add(-time.Hour).Unix())},
+				want:    []string{},
+			},
+			{
+				name:    "second page",
+				payload: data.GetUploadsRequest{PageNumber: null.NewInt(2), PageSize: null.NewInt(2)},
+				want:    []string{"c.csv"},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if tt.payload.SortBy == "" {
+					tt.payload.SortBy = data.UploadSortFieldFileName
+					tt.payload.Order = data.SortOrderAsc
+				}
+				if !tt.payload.PageNumber.Valid {
+					tt.payload.PageNumber = null.NewInt(1)
+					tt.payload.PageSize = null.NewInt(10)
+				}
+				statuses, err := r.GetProcessingStatuses(ctx, tt.payload)
+				require.NoError(t, err)
+				fileNames := []string{}
+				for _, status := range statuses {
+					fileNames = append(fileNames, status.FileName)
+				}
+				assert.Equal(t, tt.want, fileNames)
+			})
+		}
+	})
+
+	t.Run("webhook deliveries and rejected messages", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{FileName: "a.csv", Status: data.ProcessingStatusCompleted}))
+		for attempt := 1; attempt <= 2; attempt++ {
+			require.NoError(t, r.InsertWebhookDelivery(ctx, data.WebhookDeliveryEntity{
+				FileName:   "a.csv",
+				URL:        "http://example.com",
+				Attempt:    attempt,
+				StatusCode: null.NewInt(500),
+			}))
+		}
+		require.NoError(t, r.InsertWebhookDelivery(ctx, data.WebhookDeliveryEntity{FileName: "b.csv", URL: "http://example.com", Attempt: 1, Delivered: true}))
+
+		deliveries, err := r.GetWebhookDeliveries(ctx, "a.csv")
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, 1, deliveries[0].Attempt)
+		assert.Equal(t, 2, deliveries[1].Attempt)
+		assert.Equal(t, int64(500), deliveries[0].StatusCode.Int64)
+		assert.NotZero(t, deliveries[0].ID)
+		assert.False(t, deliveries[0].CreatedAt.IsZero())
+
+		for _, id := range []string{"1", "2", "3"} {
+			require.NoError(t, r.InsertRejectedMessage(ctx, data.RejectedMessageEntity{MessageID: id, Body: "{}", Reason: "invalid"}))
+		}
+		messages, err := r.GetRejectedMessages(ctx, data.GetRejectedMessagesRequest{PageNumber: null.NewInt(1), PageSize: null.NewInt(2)})
+		require.NoError(t, err)
+		require.Len(t, messages, 2)
+		assert.Equal(t, "3", messages[0].MessageID, "most recent first")
+		assert.Equal(t, "2", messages[1].MessageID)
+
+		require.NoError(t, r.RemoveStaleProcessingStatus(ctx, time.Now().Add(-time.Hour)))
+		_, err = r.GetProcessingStatus(ctx, "a.csv")
+		assert.NoError(t, err)
+
+		require.NoError(t, r.RemoveStaleProcessingStatus(ctx, time.Now().Add(time.Hour)))
+		_, err = r.GetProcessingStatus(ctx, "a.csv")
+		assert.True(t, E.IsErrEntityNotFound(err))
+		deliveries, err = r.GetWebhookDeliveries(ctx, "b.csv")
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+		messages, err = r.GetRejectedMessages(ctx, data.GetRejectedMessagesRequest{PageNumber: null.NewInt(1), PageSize: null.NewInt(2)})
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("data quality reports", func(t *testing.T) {
+		r := newRepository(t)
+		day := start.Add(24 * time.Hour)
+		reports := []data.DataQualityEntity{
+			{Symbol: "BTC", Day: day.Add(time.Hour), IntervalSeconds: 3600, ExpectedCount: 24, PresentCount: 24, Coverage: 100, Gaps: data.Gaps{}},
+			{Symbol: "BTC", Day: day, IntervalSeconds: 60, ExpectedCount: 1440, PresentCount: 1000, Coverage: 69.44, Gaps: data.Gaps{}},
+			{Symbol: "BTC", Day: start, IntervalSeconds: 60, ExpectedCount: 1440, PresentCount: 1440, Coverage: 100, Gaps: data.Gaps{}},
+			{Symbol: "ETH", Day: day, IntervalSeconds: 60, ExpectedCount: 1440, PresentCount: 1440, Coverage: 100, Gaps: data.Gaps{}},
+		}
+		require.NoError(t, r.UpsertDataQualityReports(ctx, reports))
+		reports[1].PresentCount = 1440
+		reports[1].Coverage = 100
+		require.NoError(t, r.UpsertDataQualityReports(ctx, reports[1:2]))
+
+		got, err := r.GetDataQualityReports(ctx, "BTC", day.Add(12*time.Hour), day.Add(13*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.True(t, got[0].Day.Equal(day))
+		assert.Equal(t, int64(60), got[0].IntervalSeconds)
+		assert.Equal(t, int64(1440), got[0].PresentCount)
+		assert.Equal(t, float64(100), got[0].Coverage)
+		assert.Equal(t, int64(3600), got[1].IntervalSeconds)
+		assert.Equal(t, data.Gaps{}, got[0].Gaps)
+
+		got, err = r.GetDataQualityReports(ctx, "BTC", start, day)
+		require.NoError(t, err)
+		assert.Len(t, got, 3)
+	})
+
+	t.Run("anomalies", func(t *testing.T) {
+		r := newRepository(t)
+		_, err := r.GetAnomaly(ctx, 1)
+		assert.True(t, E.IsErrEntityNotFound(err))
+
+		require.NoError(t, r.InsertAnomalies(ctx, []data.AnomalyEntity{
+			data.NewAnomalyEntity(point("BTC", 0, "a.csv"), "spike", data.AnomalyStatusFlagged),
+			data.NewAnomalyEntity(point("ETH", 1, "a.csv"), "spike", data.AnomalyStatusQuarantined),
+			data.NewAnomalyEntity(point("BTC", 2, "b.csv"), "spike", data.AnomalyStatusQuarantined),
+		}))
+
+		tests := []struct {
+			name    string
+			payload data.GetAnomaliesRequest
+			want    []string
+		}{
+			{name: "every anomaly", want: []string{"BTC", "ETH", "BTC"}},
+			{name: "symbol filter", payload: data.GetAnomaliesRequest{Symbol: "BTC"}, want: []string{"BTC", "BTC"}},
+			{name: "status filter", payload: data.GetAnomaliesRequest{Status: data.AnomalyStatusQuarantined}, want: []string{"ETH", "BTC"}},
+			{name: "second page", payload: data.GetAnomaliesRequest{PageNumber: null.NewInt(2), PageSize: null.NewInt(2)}, want: []string{"BTC"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if !tt.payload.PageNumber.Valid {
+					tt.payload.PageNumber = null.NewInt(1)
+					tt.payload.PageSize = null.NewInt(10)
+				}
+				anomalies, err := r.GetAnomalies(ctx, tt.payload)
+				require.NoError(t, err)
+				symbols := []string{}
+				for _, anomaly := range anomalies {
+					symbols = append(symbols, anomaly.Symbol)
+				}
+				assert.Equal(t, tt.want, symbols)
+			})
+		}
+
+		anomalies, err := r.GetAnomalies(ctx, data.GetAnomaliesRequest{PageNumber: null.NewInt(1), PageSize: null.NewInt(10)})
+		require.NoError(t, err)
+		require.Len(t, anomalies, 3)
+		anomaly, err := r.GetAnomaly(ctx, anomalies[0].ID)
+		require.NoError(t, err)
+		assert.True(t, anomaly.Time.Equal(start))
+		assert.Equal(t, "spike", anomaly.Reason)
+		assert.Equal(t, data.AnomalyStatusFlagged, anomaly.Status)
+		assert.Equal(t, "a.csv", anomaly.SourceFile.String)
+
+		require.NoError(t, r.DeleteAnomaly(ctx, anomalies[0].ID))
+		_, err = r.GetAnomaly(ctx, anomalies[0].ID)
+		assert.True(t, E.IsErrEntityNotFound(err))
+
+		// The anomalies of a file are removed with its data points.
+		_, err = r.DeleteDataPointsBySourceFile(ctx, "a.csv")
+		require.NoError(t, err)
+		anomalies, err = r.GetAnomalies(ctx, data.GetAnomaliesRequest{PageNumber: null.NewInt(1), PageSize: null.NewInt(10)})
+		require.NoError(t, err)
+		require.Len(t, anomalies, 1)
+		assert.Equal(t, "b.csv", anomalies[0].SourceFile.String)
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		r := newRepository(t)
+		testErr := errors.New("test error")
+
+		err := r.WithTransaction(ctx, func(repo Repository) error {
+			require.NoError(t, repo.InsertDataPoints(ctx, []data.OHLCEntity{point("BTC", 0, "a.csv")}))
+			require.NoError(t, repo.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{FileName: "a.csv", Status: data.ProcessingStatusInProgress}))
+			return testErr
+		})
+		assert.ErrorIs(t, err, testErr)
+		symbols, err := r.GetSymbols(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, symbols, "the rolled back writes are discarded")
+		_, err = r.GetProcessingStatus(ctx, "a.csv")
+		assert.True(t, E.IsErrEntityNotFound(err))
+
+		err = r.WithTransaction(ctx, func(repo Repository) error {
+			require.NoError(t, repo.InsertDataPoints(ctx, []data.OHLCEntity{point("BTC", 0, "a.csv")}))
+			count, err := repo.CountDataPointsBySourceFile(ctx, "a.csv")
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), count, "the writes are visible within the transaction")
+
+			// A nested transaction joins the current one.
+			return repo.WithTransaction(ctx, func(nested Repository) error {
+				return nested.InsertDataPoints(ctx, []data.OHLCEntity{point("ETH", 0, "a.csv")})
+			})
+		})
+		require.NoError(t, err)
+		symbols, err = r.GetSymbols(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"BTC", "ETH"}, symbols, "the committed writes are kept")
+
+		err = r.WithTransaction(ctx, func(repo Repository) error {
+			err := repo.WithTransaction(ctx, func(nested Repository) error {
+				return nested.InsertDataPoints(ctx, []data.OHLCEntity{point("XRP", 0, "a.csv")})
+			})
+			require.NoError(t, err)
+			return testErr
+		})
+		assert.ErrorIs(t, err, testErr)
+		symbols, err = r.GetSymbols(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"BTC", "ETH"}, symbols, "the writes of a nested transaction are rolled back with the outer one")
+	})
+}
+
+// minutes returns the times of the data points as a number of minutes since the start time.
+func minutes(points []data.OHLCEntity, start time.Time) []int {
+	got := []int{}
+	for _, p := range points {
+		got = append(got, int(p.Time.Sub(start)/time.Minute))
+	}
+	return got
+}
+
+// prices returns the prices without trailing zeros, whatever the scale they are read with.
+func prices(values ...decimal.Decimal) []string {
+	got := make([]string, len(values))
+	for i, v := range values {
+		got[i] = v.String()
+	}
+	return got
+}
@@ -0,0 +1,857 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	E "github.com/teezzan/candles/internal/errors"
+	"github.com/teezzan/candles/internal/null"
+)
+
+var _ Repository = (*MemoryRepository)(nil)
+
+// memoryTables holds the rows of an in-memory repository, in insertion order.
+type memoryTables struct {
+	lastID     int64
+	dataPoints []data.OHLCEntity
+	statuses   []data.ProcessingStatusEntity
+	deliveries []data.WebhookDeliveryEntity
+	rejected   []data.RejectedMessageEntity
+	reports    []data.DataQualityEntity
+	anomalies  []data.AnomalyEntity
+	rollups    []data.RollupEntity
+}
+
+// nextID returns a new row ID.
+func (t *memoryTables) nextID() int64 {
+	t.lastID++
+	return t.lastID
+}
+
+// clone returns a copy of the tables. Rows are never modified in place, hence they can be shared by both copies.
+func (t *memoryTables) clone() *memoryTables {
+	return &memoryTables{
+		lastID:     t.lastID,
+		dataPoints: append([]data.OHLCEntity(nil), t.dataPoints...),
+		statuses:   append([]data.ProcessingStatusEntity(nil), t.statuses...),
+		deliveries: append([]data.WebhookDeliveryEntity(nil), t.deliveries...),
+		rejected:   append([]data.RejectedMessageEntity(nil), t.rejected...),
+		reports:    append([]data.DataQualityEntity(nil), t.reports...),
+		anomalies:  append([]data.AnomalyEntity(nil), t.anomalies...),
+		rollups:    append([]data.RollupEntity(nil), t.rollups...),
+	}
+}
+
+// status returns the index of the processing status of a file, or -1 if there is none.
+func (t *memoryTables) status(fileName string) int {
+	for i, status := range t.statuses {
+		if status.FileName == fileName {
+			return i
+		}
+	}
+	return -1
+}
+
+// memoryTx is a transaction of an in-memory repository. Its writes are applied to a private copy of the tables,
+// then replayed on the tables of the repository once the transaction is committed.
+type memoryTx struct {
+	mu     sync.Mutex
+	tables *memoryTables
+	writes []func(*memoryTables)
+}
+
+// MemoryRepository implements a thread-safe in-memory repository with the same semantics as the MySQL repository,
+// meant for tests and demos. Like a MySQL timestamp, a time set by the repository has a precision of one second.
+type MemoryRepository struct {
+	mu     *sync.RWMutex
+	tables *memoryTables
+	tx     *memoryTx
+}
+
+// NewMemoryRepository initializes a new empty in-memory repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		mu:     &sync.RWMutex{},
+		tables: &memoryTables{},
+	}
+}
+
+// read calls fn with the tables visible to the repository.
+func (r *MemoryRepository) read(fn func(t *memoryTables)) {
+	if r.tx != nil {
+		r.tx.mu.Lock()
+		defer r.tx.mu.Unlock()
+		fn(r.tx.tables)
+		return
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn(r.tables)
+}
+
+// write calls fn to modify the tables. Within a transaction, fn is replayed on the tables of the repository
+// once the transaction is committed, hence it must only depend on its arguments.
+func (r *MemoryRepository) write(fn func(t *memoryTables)) {
+	if r.tx != nil {
+		r.tx.mu.Lock()
+		defer r.tx.mu.Unlock()
+		fn(r.tx.tables)
+		r.tx.writes = append(r.tx.writes, fn)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(r.tables)
+}
+
+// now returns the current time with the precision of a MySQL timestamp.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// WithTransaction runs fn with a repository bound to a new transaction. The transaction is committed if fn succeeds
+// and rolled back otherwise. If the repository is already bound to a transaction, fn joins it.
+// The writes of the transaction are not visible outside of it until it is committed.
+func (r *MemoryRepository) WithTransaction(ctx context.Context, fn func(Repository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	r.mu.RLock()
+	tx := &memoryTx{tables: r.tables.clone()}
+	r.mu.RUnlock()
+	err := fn(&MemoryRepository{mu: r.mu, tables: r.tables, tx: tx})
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, write := range tx.writes {
+		write(r.tables)
+	}
+	return nil
+}
+
+// InsertDataPoints inserts a slice of data.OHLCEntity rows into the repository.
+func (r *MemoryRepository) InsertDataPoints(ctx context.Context, rows []data.OHLCEntity) error {
+	rows = append([]data.OHLCEntity(nil), rows...)
+	r.write(func(t *memoryTables) {
+		for _, row := range rows {
+			row.ID = t.nextID()
+			row.Time = row.Time.UTC()
+			t.dataPoints = append(t.dataPoints, row)
+		}
+	})
+	return nil
+}
+
+// dataPointsInRange returns the data points of a symbol between the start and end times (inclusive), ordered by time.
+// Like the rows selected by the MySQL repository, the data points carry neither their ID nor their source file.
+func (t *memoryTables) dataPointsInRange(symbol string, startTime time.Time, endTime time.Time) []data.OHLCEntity {
+	points := []data.OHLCEntity{}
+	for _, p := range t.dataPoints {
+		if p.Symbol == symbol && !p.Time.Before(startTime) && !p.Time.After(endTime) {
+			points = append(points, selectedDataPoint(p))
+		}
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Time.Before(points[j].Time)
+	})
+	return points
+}
+
+// selectedDataPoint returns the columns of a data point selected by the queries.
+func selectedDataPoint(p data.OHLCEntity) data.OHLCEntity {
+	return data.OHLCEntity{
+		Time:   p.Time,
+		Symbol: p.Symbol,
+		Open:   p.Open,
+		High:   p.High,
+		Low:    p.Low,
+		Close:  p.Close,
+	}
+}
+
+// GetDataPoints retrieves OHLC data points for a given symbol and time range
+// It returns a slice of OHLCEntity structs with data for a given symbol between the start and end times
+// The result is paginated with page number and page size parameters
+func (r *MemoryRepository) GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error) {
+	var points []data.OHLCEntity
+	r.read(func(t *memoryTables) {
+		points = t.dataPointsInRange(payload.Symbol, time.Unix(payload.StartTime, 0), time.Unix(payload.EndTime.Int64, 0))
+	})
+	return page(points, payload.PageNumber, payload.PageSize), nil
+}
+
+// GetDataPointsInRange retrieves every OHLC data point of a symbol between the start and end times (inclusive).
+// Unlike GetDataPoints, the result is not paginated and is ordered by time.
+func (r *MemoryRepository) GetDataPointsInRange(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.OHLCEntity, error) {
+	var points []data.OHLCEntity
+	r.read(func(t *memoryTables) {
+		points = t.dataPointsInRange(symbol, startTime, endTime)
+	})
+	return points, nil
+}
+
+// GetSymbols retrieves the distinct symbols of the data points, in alphabetical order.
+func (r *MemoryRepository) GetSymbols(ctx context.Context) ([]string, error) {
+	symbols := []string{}
+	r.read(func(t *memoryTables) {
+		seen := map[string]bool{}
+		for _, p := range t.dataPoints {
+			if !seen[p.Symbol] {
+				seen[p.Symbol] = true
+				symbols = append(symbols, p.Symbol)
+			}
+		}
+	})
+	sort.Strings(symbols)
+	return symbols, nil
+}
+
+// GetLatestDataPoints retrieves the latest OHLC data points of a symbol strictly before the given time.
+// At most `limit` data points are returned, ordered by time.
+func (r *MemoryRepository) GetLatestDataPoints(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error) {
+	points := []data.OHLCEntity{}
+	r.read(func(t *memoryTables) {
+		for _, p := range t.dataPoints {
+			if p.Symbol == symbol && p.Time.Before(before) {
+				points = append(points, selectedDataPoint(p))
+			}
+		}
+	})
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Time.Before(points[j].Time)
+	})
+	if len(points) > limit {
+		points = points[len(points)-limit:]
+	}
+	return points, nil
+}
+
+// DeleteDataPoint removes the OHLC data points of a symbol at the given time.
+func (r *MemoryRepository) DeleteDataPoint(ctx context.Context, symbol string, t time.Time) error {
+	r.write(func(tables *memoryTables) {
+		points := []data.OHLCEntity{}
+		for _, p := range tables.dataPoints {
+			if p.Symbol != symbol || !p.Time.Equal(t) {
+				points = append(points, p)
+			}
+		}
+		tables.dataPoints = points
+	})
+	return nil
+}
+
+// CreatePartitions is a no-op: the in-memory repository is not partitioned.
+func (r *MemoryRepository) CreatePartitions(ctx context.Context, until time.Time) error {
+	return nil
+}
+
+// DropPartitions removes the data points of the months before the month of `before`.
+func (r *MemoryRepository) DropPartitions(ctx context.Context, before time.Time) error {
+	cutoff := monthStart(before)
+	r.write(func(t *memoryTables) {
+		points := []data.OHLCEntity{}
+		for _, p := range t.dataPoints {
+			if !p.Time.Before(cutoff) {
+				points = append(points, p)
+			}
+		}
+		t.dataPoints = points
+	})
+	return nil
+}
+
+// CountDataPointsBySourceFile counts the OHLC data points inserted from the given uploaded file.
+func (r *MemoryRepository) CountDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	var count int64
+	r.read(func(t *memoryTables) {
+		for _, p := range t.dataPoints {
+			if p.SourceFile.Valid && p.SourceFile.String == fileName {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+// DeleteDataPointsBySourceFile removes the OHLC data points inserted from the given uploaded file,
+// as well as the suspicious data points detected in it.
+// It returns the number of OHLC data points removed.
+func (r *MemoryRepository) DeleteDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	count, err := r.CountDataPointsBySourceFile(ctx, fileName)
+	if err != nil {
+		return 0, err
+	}
+	r.write(func(t *memoryTables) {
+		points := []data.OHLCEntity{}
+		for _, p := range t.dataPoints {
+			if !p.SourceFile.Valid || p.SourceFile.String != fileName {
+				points = append(points, p)
+			}
+		}
+		t.dataPoints = points
+
+		anomalies := []data.AnomalyEntity{}
+		for _, a := range t.anomalies {
+			if !a.SourceFile.Valid || a.SourceFile.String != fileName {
+				anomalies = append(anomalies, a)
+			}
+		}
+		t.anomalies = anomalies
+	})
+	return count, nil
+}
+
+// selectedStatus returns a copy of a processing status as selected by the queries, which do not select its ID.
+func selectedStatus(status data.ProcessingStatusEntity) data.ProcessingStatusEntity {
+	status.ID = 0
+	status.Tags = cloneTags(status.Tags)
+	status.Symbols = append(data.Symbols{}, status.Symbols...)
+	return status
+}
+
+// cloneTags returns a copy of the tags. No tags are stored as NULL and read as empty tags.
+func cloneTags(tags data.Tags) data.Tags {
+	clone := data.Tags{}
+	for k, v := range tags {
+		clone[k] = v
+	}
+	return clone
+}
+
+// GetProcessingStatus retrieves the processing status of a file
+// It returns a ProcessingStatusEntity struct with the status of the file
+func (r *MemoryRepository) GetProcessingStatus(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+	var status *data.ProcessingStatusEntity
+	r.read(func(t *memoryTables) {
+		if i := t.status(fileName); i >= 0 {
+			s := selectedStatus(t.statuses[i])
+			status = &s
+		}
+	})
+	if status == nil {
+		return nil, E.NewErrEntityNotFound("file", fileName)
+	}
+	return status, nil
+}
+
+// GetProcessingStatuses retrieves the processing statuses of the files matching the filters of the payload.
+// A file matches the symbol filter if it touched the symbol. The time range applies to the creation time.
+// The result is sorted by the requested field and paginated with page number and page size parameters.
+func (r *MemoryRepository) GetProcessingStatuses(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, error) {
+	statuses := []data.ProcessingStatusEntity{}
+	r.read(func(t *memoryTables) {
+		for _, status := range t.statuses {
+			if matchesUploadFilters(status, payload) {
+				statuses = append(statuses, status)
+			}
+		}
+	})
+
+	less := func(a, b data.ProcessingStatusEntity) bool {
+		switch payload.SortBy {
+		case data.UploadSortFieldUpdatedAt:
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.Before(b.UpdatedAt)
+			}
+		case data.UploadSortFieldFileName:
+			if a.FileName != b.FileName {
+				return a.FileName < b.FileName
+			}
+		case data.UploadSortFieldStatus:
+			if a.Status != b.Status {
+				return a.Status < b.Status
+			}
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if payload.Order == data.SortOrderDesc {
+			return less(statuses[j], statuses[i])
+		}
+		return less(statuses[i], statuses[j])
+	})
+
+	statuses = page(statuses, payload.PageNumber, payload.PageSize)
+	for i := range statuses {
+		statuses[i] = selectedStatus(statuses[i])
+	}
+	return statuses, nil
+}
+
+// matchesUploadFilters returns true if the processing status matches the filters of the payload.
+func matchesUploadFilters(status data.ProcessingStatusEntity, payload data.GetUploadsRequest) bool {
+	if payload.Status != "" && status.Status != payload.Status {
+		return false
+	}
+	if payload.Uploader != "" && (!status.Uploader.Valid || status.Uploader.String != payload.Uploader) {
+		return false
+	}
+	if payload.StartTime.Valid && status.CreatedAt.Unix() < payload.StartTime.Int64 {
+		return false
+	}
+	if payload.EndTime.Valid && status.CreatedAt.Unix() > payload.EndTime.Int64 {
+		return false
+	}
+	if payload.Symbol == "" {
+		return true
+	}
+	for _, symbol := range status.Symbols {
+		if symbol == payload.Symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// InsertProcessingStatus inserts the processing status of a file into the repository.
+// If the file already has a processing status, its status is replaced and its error, progress and finish time cleared,
+// its uploader, callback URL and tags being kept unless new ones are given.
+func (r *MemoryRepository) InsertProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error {
+	status.Tags = cloneTags(status.Tags)
+	r.write(func(t *memoryTables) {
+		now := now()
+		i := t.status(status.FileName)
+		if i < 0 {
+			t.statuses = append(t.statuses, data.ProcessingStatusEntity{
+				ID:          t.nextID(),
+				FileName:    status.FileName,
+				Status:      status.Status,
+				Uploader:    status.Uploader,
+				CallbackURL: status.CallbackURL,
+				Tags:        status.Tags,
+				Symbols:     data.Symbols{},
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			})
+			return
+		}
+
+		existing := t.statuses[i]
+		resetProgress(&existing)
+		existing.Status = status.Status
+		existing.Error = null.String{}
+		if status.Uploader.Valid {
+			existing.Uploader = status.Uploader
+		}
+		if status.CallbackURL.Valid {
+			existing.CallbackURL = status.CallbackURL
+		}
+		if len(status.Tags) > 0 {
+			existing.Tags = status.Tags
+		}
+		existing.UpdatedAt = now
+		t.statuses[i] = existing
+	})
+	return nil
+}
+
+// UpdateProcessingStatus updates the status of a file in the repository.
+// A file processed again, whose status is not terminal, has its progress and finish time cleared.
+func (r *MemoryRepository) UpdateProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error {
+	r.write(func(t *memoryTables) {
+		i := t.status(status.FileName)
+		if i < 0 {
+			return
+		}
+		existing := t.statuses[i]
+		if !status.Status.IsTerminal() {
+			resetProgress(&existing)
+		}
+		existing.Status = status.Status
+		existing.Error = status.Error
+		if status.FinishedAt.Valid {
+			existing.FinishedAt = status.FinishedAt
+		}
+		existing.UpdatedAt = now()
+		t.statuses[i] = existing
+	})
+	return nil
+}
+
+// resetProgress clears the progress and the finish time of a file processed again.
+func resetProgress(status *data.ProcessingStatusEntity) {
+	status.BytesTotal = 0
+	status.BytesRead = 0
+	status.RowsParsed = 0
+	status.RowsInserted = 0
+	status.RowsRejected = 0
+	status.Symbols = data.Symbols{}
+	status.RangeStart = null.Time{}
+	status.RangeEnd = null.Time{}
+	status.StartedAt = null.Time{}
+	status.FinishedAt = null.Time{}
+}
+
+// UpdateProcessingProgress updates the ingestion progress of a file in the repository.
+func (r *MemoryRepository) UpdateProcessingProgress(ctx context.Context, status data.ProcessingStatusEntity) error {
+	symbols := append(data.Symbols{}, status.Symbols...)
+	r.write(func(t *memoryTables) {
+		i := t.status(status.FileName)
+		if i < 0 {
+			return
+		}
+		existing := t.statuses[i]
+		existing.BytesTotal = status.BytesTotal
+		existing.BytesRead = status.BytesRead
+		existing.RowsParsed = status.RowsParsed
+		existing.RowsInserted = status.RowsInserted
+		existing.RowsRejected = status.RowsRejected
+		existing.Symbols = symbols
+		existing.RangeStart = status.RangeStart
+		existing.RangeEnd = status.RangeEnd
+		existing.StartedAt = status.StartedAt
+		existing.UpdatedAt = now()
+		t.statuses[i] = existing
+	})
+	return nil
+}
+
+// RemoveStaleProcessingStatus removes the processing statuses not updated since the stale time,
+// as well as the webhook deliveries and rejected messages created before it.
+func (r *MemoryRepository) RemoveStaleProcessingStatus(ctx context.Context, staleTime time.Time) error {
+	r.write(func(t *memoryTables) {
+		statuses := []data.ProcessingStatusEntity{}
+		for _, status := range t.statuses {
+			if status.UpdatedAt.After(staleTime) {
+				statuses = append(statuses, status)
+			}
+		}
+		t.statuses = statuses
+
+		deliveries := []data.WebhookDeliveryEntity{}
+		for _, delivery := range t.deliveries {
+			if delivery.CreatedAt.After(staleTime) {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+		t.deliveries = deliveries
+
+		rejected := []data.RejectedMessageEntity{}
+		for _, message := range t.rejected {
+			if message.CreatedAt.After(staleTime) {
+				rejected = append(rejected, message)
+			}
+		}
+		t.rejected = rejected
+	})
+	return nil
+}
+
+// InsertWebhookDelivery inserts a webhook delivery attempt into the repository.
+func (r *MemoryRepository) InsertWebhookDelivery(ctx context.Context, delivery data.WebhookDeliveryEntity) error {
+	r.write(func(t *memoryTables) {
+		delivery.ID = t.nextID()
+		delivery.CreatedAt = now()
+		t.deliveries = append(t.deliveries, delivery)
+	})
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the webhook delivery attempts of a file, oldest first.
+func (r *MemoryRepository) GetWebhookDeliveries(ctx context.Context, fileName string) ([]data.WebhookDeliveryEntity, error) {
+	deliveries := []data.WebhookDeliveryEntity{}
+	r.read(func(t *memoryTables) {
+		for _, delivery := range t.deliveries {
+			if delivery.FileName == fileName {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+	})
+	return deliveries, nil
+}
+
+// InsertRejectedMessage inserts a message rejected by the SQS consumer into the repository.
+func (r *MemoryRepository) InsertRejectedMessage(ctx context.Context, message data.RejectedMessageEntity) error {
+	r.write(func(t *memoryTables) {
+		message.ID = t.nextID()
+		message.CreatedAt = now()
+		t.rejected = append(t.rejected, message)
+	})
+	return nil
+}
+
+// GetRejectedMessages retrieves the SQS messages rejected by the consumer, most recent first.
+func (r *MemoryRepository) GetRejectedMessages(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, error) {
+	messages := []data.RejectedMessageEntity{}
+	r.read(func(t *memoryTables) {
+		for i := len(t.rejected) - 1; i >= 0; i-- {
+			messages = append(messages, t.rejected[i])
+		}
+	})
+	return page(messages, payload.PageNumber, payload.PageSize), nil
+}
+
+// UpsertDataQualityReports inserts data quality reports into the repository.
+// A report already stored for the same symbol, day and interval is replaced.
+func (r *MemoryRepository) UpsertDataQualityReports(ctx context.Context, reports []data.DataQualityEntity) error {
+	reports = append([]data.DataQualityEntity(nil), reports...)
+	r.write(func(t *memoryTables) {
+		now := now()
+		for _, report := range reports {
+			report.Day = day(report.Day)
+			report.Gaps = append(data.Gaps{}, report.Gaps...)
+			report.UpdatedAt = now
+
+			i := 0
+			for ; i < len(t.reports); i++ {
+				existing := t.reports[i]
+				if existing.Symbol == report.Symbol && existing.Day.Equal(report.Day) && existing.IntervalSeconds == report.IntervalSeconds {
+					break
+				}
+			}
+			if i < len(t.reports) {
+				report.CreatedAt = t.reports[i].CreatedAt
+				t.reports[i] = report
+				continue
+			}
+			report.CreatedAt = now
+			t.reports = append(t.reports, report)
+		}
+	})
+	return nil
+}
+
+// GetDataQualityReports retrieves the stored data quality reports of a symbol for the days between the start and end times.
+func (r *MemoryRepository) GetDataQualityReports(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.DataQualityEntity, error) {
+	reports := []data.DataQualityEntity{}
+	startDay, endDay := day(startTime), day(endTime)
+	r.read(func(t *memoryTables) {
+		for _, report := range t.reports {
+			if report.Symbol == symbol && !report.Day.Before(startDay) && !report.Day.After(endDay) {
+				report.ID = 0
+				report.Gaps = append(data.Gaps{}, report.Gaps...)
+				reports = append(reports, report)
+			}
+		}
+	})
+	sort.SliceStable(reports, func(i, j int) bool {
+		if !reports[i].Day.Equal(reports[j].Day) {
+			return reports[i].Day.Before(reports[j].Day)
+		}
+		return reports[i].IntervalSeconds < reports[j].IntervalSeconds
+	})
+	return reports, nil
+}
+
+// day returns the UTC day of a time, as stored in a MySQL DATE column.
+func day(t time.Time) time.Time {
+	year, month, d := t.UTC().Date()
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// InsertAnomalies inserts a slice of data.AnomalyEntity rows into the repository.
+func (r *MemoryRepository) InsertAnomalies(ctx context.Context, anomalies []data.AnomalyEntity) error {
+	anomalies = append([]data.AnomalyEntity(nil), anomalies...)
+	r.write(func(t *memoryTables) {
+		now := now()
+		for _, anomaly := range anomalies {
+			anomaly.ID = t.nextID()
+			anomaly.Time = anomaly.Time.UTC()
+			anomaly.CreatedAt = now
+			t.anomalies = append(t.anomalies, anomaly)
+		}
+	})
+	return nil
+}
+
+// GetAnomalies retrieves the suspicious data points awaiting review, optionally filtered by symbol and status.
+// The result is paginated with page number and page size parameters.
+func (r *MemoryRepository) GetAnomalies(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, error) {
+	anomalies := []data.AnomalyEntity{}
+	r.read(func(t *memoryTables) {
+		for _, anomaly := range t.anomalies {
+			if (payload.Symbol == "" || anomaly.Symbol == payload.Symbol) && (payload.Status == "" || anomaly.Status == payload.Status) {
+				anomalies = append(anomalies, anomaly)
+			}
+		}
+	})
+	return page(anomalies, payload.PageNumber, payload.PageSize), nil
+}
+
+// GetAnomaly retrieves a suspicious data point by its ID.
+func (r *MemoryRepository) GetAnomaly(ctx context.Context, id int64) (*data.AnomalyEntity, error) {
+	var anomaly *data.AnomalyEntity
+	r.read(func(t *memoryTables) {
+		for _, a := range t.anomalies {
+			if a.ID == id {
+				anomaly = &a
+				return
+			}
+		}
+	})
+	if anomaly == nil {
+		return nil, E.NewErrEntityNotFound("anomaly", strconv.FormatInt(id, 10))
+	}
+	return anomaly, nil
+}
+
+// DeleteAnomaly removes a suspicious data point from the repository.
+func (r *MemoryRepository) DeleteAnomaly(ctx context.Context, id int64) error {
+	r.write(func(t *memoryTables) {
+		anomalies := []data.AnomalyEntity{}
+		for _, a := range t.anomalies {
+			if a.ID != id {
+				anomalies = append(anomalies, a)
+			}
+		}
+		t.anomalies = anomalies
+	})
+	return nil
+}
+
+// page returns the page of rows of the given number and size, the first page being 1.
+func page[T any](rows []T, pageNumber null.Int, pageSize null.Int) []T {
+	offset := (pageNumber.Int64 - 1) * pageSize.Int64
+	if offset < 0 || offset >= int64(len(rows)) {
+		return []T{}
+	}
+	end := offset + pageSize.Int64
+	if end > int64(len(rows)) {
+		end = int64(len(rows))
+	}
+	return rows[offset:end]
+}
+
+// DeleteDataPointsBefore removes the OHLC data points of a symbol strictly before the given time.
+// It returns the number of data points removed.
+func (r *MemoryRepository) DeleteDataPointsBefore(ctx context.Context, symbol string, before time.Time) (int64, error) {
+	var count int64
+	r.read(func(t *memoryTables) {
+		for _, p := range t.dataPoints {
+			if p.Symbol == symbol && p.Time.Before(before) {
+				count++
+			}
+		}
+	})
+	r.write(func(t *memoryTables) {
+		points := []data.OHLCEntity{}
+		for _, p := range t.dataPoints {
+			if p.Symbol != symbol || !p.Time.Before(before) {
+				points = append(points, p)
+			}
+		}
+		t.dataPoints = points
+	})
+	return count, nil
+}
+
+// UpsertRollups inserts downsampled candles into the repository.
+// A candle already stored for the same symbol, interval and time is replaced.
+func (r *MemoryRepository) UpsertRollups(ctx context.Context, rows []data.RollupEntity) error {
+	rows = append([]data.RollupEntity(nil), rows...)
+	r.write(func(t *memoryTables) {
+		for _, row := range rows {
+			row.Time = row.Time.UTC()
+
+			i := 0
+			for ; i < len(t.rollups); i++ {
+				existing := t.rollups[i]
+				if existing.Symbol == row.Symbol && existing.IntervalSeconds == row.IntervalSeconds && existing.Time.Equal(row.Time) {
+					break
+				}
+			}
+			if i < len(t.rollups) {
+				row.ID = t.rollups[i].ID
+				t.rollups[i] = row
+				continue
+			}
+			row.ID = t.nextID()
+			t.rollups = append(t.rollups, row)
+		}
+	})
+	return nil
+}
+
+// GetRollups retrieves the downsampled candles of a symbol and interval between the start and end times (inclusive),
+// ordered by time.
+func (r *MemoryRepository) GetRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) ([]data.RollupEntity, error) {
+	rollups := []data.RollupEntity{}
+	r.read(func(t *memoryTables) {
+		for _, rollup := range t.rollups {
+			if rollup.Symbol == symbol && rollup.IntervalSeconds == intervalSeconds && !rollup.Time.Before(startTime) && !rollup.Time.After(endTime) {
+				rollups = append(rollups, rollup)
+			}
+		}
+	})
+	sort.SliceStable(rollups, func(i, j int) bool {
+		return rollups[i].Time.Before(rollups[j].Time)
+	})
+	return rollups, nil
+}
+
+// GetOldestRollups retrieves the oldest downsampled candles of a symbol and interval.
+// At most `limit` rollups are returned, ordered by time.
+func (r *MemoryRepository) GetOldestRollups(ctx context.Context, symbol string, intervalSeconds int64, limit int) ([]data.RollupEntity, error) {
+	rollups := []data.RollupEntity{}
+	r.read(func(t *memoryTables) {
+		for _, rollup := range t.rollups {
+			if rollup.Symbol == symbol && rollup.IntervalSeconds == intervalSeconds {
+				rollups = append(rollups, rollup)
+			}
+		}
+	})
+	sort.SliceStable(rollups, func(i, j int) bool {
+		return rollups[i].Time.Before(rollups[j].Time)
+	})
+	if len(rollups) > limit {
+		rollups = rollups[:limit]
+	}
+	return rollups, nil
+}
+
+// DeleteRollups removes the downsampled candles of a symbol and interval between the start and end times (inclusive).
+// It returns the number of candles removed.
+func (r *MemoryRepository) DeleteRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) (int64, error) {
+	deleted := func(rollup data.RollupEntity) bool {
+		return rollup.Symbol == symbol && rollup.IntervalSeconds == intervalSeconds && !rollup.Time.Before(startTime) && !rollup.Time.After(endTime)
+	}
+	var count int64
+	r.read(func(t *memoryTables) {
+		for _, rollup := range t.rollups {
+			if deleted(rollup) {
+				count++
+			}
+		}
+	})
+	r.write(func(t *memoryTables) {
+		rollups := []data.RollupEntity{}
+		for _, rollup := range t.rollups {
+			if !deleted(rollup) {
+				rollups = append(rollups, rollup)
+			}
+		}
+		t.rollups = rollups
+	})
+	return count, nil
+}
+
+// GetRollupSymbols retrieves the distinct symbols of the downsampled candles, in alphabetical order.
+func (r *MemoryRepository) GetRollupSymbols(ctx context.Context) ([]string, error) {
+	symbols := []string{}
+	r.read(func(t *memoryTables) {
+		seen := map[string]bool{}
+		for _, rollup := range t.rollups {
+			if !seen[rollup.Symbol] {
+				seen[rollup.Symbol] = true
+				symbols = append(symbols, rollup.Symbol)
+			}
+		}
+	})
+	sort.Strings(symbols)
+	return symbols, nil
+}
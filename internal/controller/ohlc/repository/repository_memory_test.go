@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+)
+
+func TestMemoryRepository_concurrency(t *testing.T) {
+	ctx := context.Background()
+	r := NewMemoryRepository()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			point := data.OHLCEntity{Time: start.Add(time.Duration(i) * time.Minute), Symbol: "BTC", Open: decimal.NewFromInt(1), High: decimal.NewFromInt(1), Low: decimal.NewFromInt(1), Close: decimal.NewFromInt(1)}
+			err := r.WithTransaction(ctx, func(repo Repository) error {
+				return repo.InsertDataPoints(ctx, []data.OHLCEntity{point})
+			})
+			assert.NoError(t, err)
+			_, err = r.GetDataPointsInRange(ctx, "BTC", start, start.Add(time.Hour))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	points, err := r.GetDataPointsInRange(ctx, "BTC", start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, points, 10)
+}
+
+func TestMemoryRepository_WithTransaction(t *testing.T) {
+	ctx := context.Background()
+	r := NewMemoryRepository()
+	point := data.OHLCEntity{Time: time.Now(), Symbol: "BTC", Open: decimal.NewFromInt(1), High: decimal.NewFromInt(1), Low: decimal.NewFromInt(1), Close: decimal.NewFromInt(1)}
+
+	err := r.WithTransaction(ctx, func(repo Repository) error {
+		require.NoError(t, repo.InsertDataPoints(ctx, []data.OHLCEntity{point}))
+
+		// The writes of the transaction are not visible outside of it until it is committed.
+		symbols, err := r.GetSymbols(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, symbols)
+		return nil
+	})
+	require.NoError(t, err)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	err = r.WithTransaction(cancelled, func(repo Repository) error {
+		cancel()
+		return repo.InsertDataPoints(ctx, []data.OHLCEntity{point})
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	symbols, err := r.GetSymbols(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTC"}, symbols)
+	count, err := r.CountDataPointsBySourceFile(ctx, "")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
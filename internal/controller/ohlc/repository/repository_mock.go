@@ -20,24 +20,105 @@ var _ Repository = &RepositoryMock{}
 //
 //		// make and configure a mocked Repository
 //		mockedRepository := &RepositoryMock{
+//			CountDataPointsBySourceFileFunc: func(ctx context.Context, fileName string) (int64, error) {
+//				panic("mock out the CountDataPointsBySourceFile method")
+//			},
+//			CreatePartitionsFunc: func(ctx context.Context, until time.Time) error {
+//				panic("mock out the CreatePartitions method")
+//			},
+//			DeleteAnomalyFunc: func(ctx context.Context, id int64) error {
+//				panic("mock out the DeleteAnomaly method")
+//			},
+//			DeleteDataPointFunc: func(ctx context.Context, symbol string, t time.Time) error {
+//				panic("mock out the DeleteDataPoint method")
+//			},
+//			DeleteDataPointsBeforeFunc: func(ctx context.Context, symbol string, before time.Time) (int64, error) {
+//				panic("mock out the DeleteDataPointsBefore method")
+//			},
+//			DeleteDataPointsBySourceFileFunc: func(ctx context.Context, fileName string) (int64, error) {
+//				panic("mock out the DeleteDataPointsBySourceFile method")
+//			},
+//			DeleteRollupsFunc: func(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) (int64, error) {
+//				panic("mock out the DeleteRollups method")
+//			},
+//			DropPartitionsFunc: func(ctx context.Context, before time.Time) error {
+//				panic("mock out the DropPartitions method")
+//			},
+//			GetAnomaliesFunc: func(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, error) {
+//				panic("mock out the GetAnomalies method")
+//			},
+//			GetAnomalyFunc: func(ctx context.Context, id int64) (*data.AnomalyEntity, error) {
+//				panic("mock out the GetAnomaly method")
+//			},
 //			GetDataPointsFunc: func(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error) {
 //				panic("mock out the GetDataPoints method")
 //			},
+//			GetDataPointsInRangeFunc: func(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.OHLCEntity, error) {
+//				panic("mock out the GetDataPointsInRange method")
+//			},
+//			GetDataQualityReportsFunc: func(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.DataQualityEntity, error) {
+//				panic("mock out the GetDataQualityReports method")
+//			},
+//			GetLatestDataPointsFunc: func(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error) {
+//				panic("mock out the GetLatestDataPoints method")
+//			},
+//			GetOldestRollupsFunc: func(ctx context.Context, symbol string, intervalSeconds int64, limit int) ([]data.RollupEntity, error) {
+//				panic("mock out the GetOldestRollups method")
+//			},
 //			GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
 //				panic("mock out the GetProcessingStatus method")
 //			},
+//			GetProcessingStatusesFunc: func(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, error) {
+//				panic("mock out the GetProcessingStatuses method")
+//			},
+//			GetRejectedMessagesFunc: func(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, error) {
+//				panic("mock out the GetRejectedMessages method")
+//			},
+//			GetRollupSymbolsFunc: func(ctx context.Context) ([]string, error) {
+//				panic("mock out the GetRollupSymbols method")
+//			},
+//			GetRollupsFunc: func(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) ([]data.RollupEntity, error) {
+//				panic("mock out the GetRollups method")
+//			},
+//			GetSymbolsFunc: func(ctx context.Context) ([]string, error) {
+//				panic("mock out the GetSymbols method")
+//			},
+//			GetWebhookDeliveriesFunc: func(ctx context.Context, fileName string) ([]data.WebhookDeliveryEntity, error) {
+//				panic("mock out the GetWebhookDeliveries method")
+//			},
+//			InsertAnomaliesFunc: func(ctx context.Context, anomalies []data.AnomalyEntity) error {
+//				panic("mock out the InsertAnomalies method")
+//			},
 //			InsertDataPointsFunc: func(ctx context.Context, rows []data.OHLCEntity) error {
 //				panic("mock out the InsertDataPoints method")
 //			},
 //			InsertProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
 //				panic("mock out the InsertProcessingStatus method")
 //			},
+//			InsertRejectedMessageFunc: func(ctx context.Context, message data.RejectedMessageEntity) error {
+//				panic("mock out the InsertRejectedMessage method")
+//			},
+//			InsertWebhookDeliveryFunc: func(ctx context.Context, delivery data.WebhookDeliveryEntity) error {
+//				panic("mock out the InsertWebhookDelivery method")
+//			},
 //			RemoveStaleProcessingStatusFunc: func(ctx context.Context, staleTime time.Time) error {
 //				panic("mock out the RemoveStaleProcessingStatus method")
 //			},
+//			UpdateProcessingProgressFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+//				panic("mock out the UpdateProcessingProgress method")
+//			},
 //			UpdateProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
 //				panic("mock out the UpdateProcessingStatus method")
 //			},
+//			UpsertDataQualityReportsFunc: func(ctx context.Context, reports []data.DataQualityEntity) error {
+//				panic("mock out the UpsertDataQualityReports method")
+//			},
+//			UpsertRollupsFunc: func(ctx context.Context, rows []data.RollupEntity) error {
+//				panic("mock out the UpsertRollups method")
+//			},
+//			WithTransactionFunc: func(ctx context.Context, fn func(Repository) error) error {
+//				panic("mock out the WithTransaction method")
+//			},
 //		}
 //
 //		// use mockedRepository in code that requires Repository
@@ -45,26 +126,187 @@ var _ Repository = &RepositoryMock{}
 //
 //	}
 type RepositoryMock struct {
+	// CountDataPointsBySourceFileFunc mocks the CountDataPointsBySourceFile method.
+	CountDataPointsBySourceFileFunc func(ctx context.Context, fileName string) (int64, error)
+
+	// CreatePartitionsFunc mocks the CreatePartitions method.
+	CreatePartitionsFunc func(ctx context.Context, until time.Time) error
+
+	// DeleteAnomalyFunc mocks the DeleteAnomaly method.
+	DeleteAnomalyFunc func(ctx context.Context, id int64) error
+
+	// DeleteDataPointFunc mocks the DeleteDataPoint method.
+	DeleteDataPointFunc func(ctx context.Context, symbol string, t time.Time) error
+
+	// DeleteDataPointsBeforeFunc mocks the DeleteDataPointsBefore method.
+	DeleteDataPointsBeforeFunc func(ctx context.Context, symbol string, before time.Time) (int64, error)
+
+	// DeleteDataPointsBySourceFileFunc mocks the DeleteDataPointsBySourceFile method.
+	DeleteDataPointsBySourceFileFunc func(ctx context.Context, fileName string) (int64, error)
+
+	// DeleteRollupsFunc mocks the DeleteRollups method.
+	DeleteRollupsFunc func(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) (int64, error)
+
+	// DropPartitionsFunc mocks the DropPartitions method.
+	DropPartitionsFunc func(ctx context.Context, before time.Time) error
+
+	// GetAnomaliesFunc mocks the GetAnomalies method.
+	GetAnomaliesFunc func(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, error)
+
+	// GetAnomalyFunc mocks the GetAnomaly method.
+	GetAnomalyFunc func(ctx context.Context, id int64) (*data.AnomalyEntity, error)
+
 	// GetDataPointsFunc mocks the GetDataPoints method.
 	GetDataPointsFunc func(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error)
 
+	// GetDataPointsInRangeFunc mocks the GetDataPointsInRange method.
+	GetDataPointsInRangeFunc func(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.OHLCEntity, error)
+
+	// GetDataQualityReportsFunc mocks the GetDataQualityReports method.
+	GetDataQualityReportsFunc func(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.DataQualityEntity, error)
+
+	// GetLatestDataPointsFunc mocks the GetLatestDataPoints method.
+	GetLatestDataPointsFunc func(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error)
+
+	// GetOldestRollupsFunc mocks the GetOldestRollups method.
+	GetOldestRollupsFunc func(ctx context.Context, symbol string, intervalSeconds int64, limit int) ([]data.RollupEntity, error)
+
 	// GetProcessingStatusFunc mocks the GetProcessingStatus method.
 	GetProcessingStatusFunc func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error)
 
+	// GetProcessingStatusesFunc mocks the GetProcessingStatuses method.
+	GetProcessingStatusesFunc func(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, error)
+
+	// GetRejectedMessagesFunc mocks the GetRejectedMessages method.
+	GetRejectedMessagesFunc func(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, error)
+
+	// GetRollupSymbolsFunc mocks the GetRollupSymbols method.
+	GetRollupSymbolsFunc func(ctx context.Context) ([]string, error)
+
+	// GetRollupsFunc mocks the GetRollups method.
+	GetRollupsFunc func(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) ([]data.RollupEntity, error)
+
+	// GetSymbolsFunc mocks the GetSymbols method.
+	GetSymbolsFunc func(ctx context.Context) ([]string, error)
+
+	// GetWebhookDeliveriesFunc mocks the GetWebhookDeliveries method.
+	GetWebhookDeliveriesFunc func(ctx context.Context, fileName string) ([]data.WebhookDeliveryEntity, error)
+
+	// InsertAnomaliesFunc mocks the InsertAnomalies method.
+	InsertAnomaliesFunc func(ctx context.Context, anomalies []data.AnomalyEntity) error
+
 	// InsertDataPointsFunc mocks the InsertDataPoints method.
 	InsertDataPointsFunc func(ctx context.Context, rows []data.OHLCEntity) error
 
 	// InsertProcessingStatusFunc mocks the InsertProcessingStatus method.
 	InsertProcessingStatusFunc func(ctx context.Context, status data.ProcessingStatusEntity) error
 
+	// InsertRejectedMessageFunc mocks the InsertRejectedMessage method.
+	InsertRejectedMessageFunc func(ctx context.Context, message data.RejectedMessageEntity) error
+
+	// InsertWebhookDeliveryFunc mocks the InsertWebhookDelivery method.
+	InsertWebhookDeliveryFunc func(ctx context.Context, delivery data.WebhookDeliveryEntity) error
+
 	// RemoveStaleProcessingStatusFunc mocks the RemoveStaleProcessingStatus method.
 	RemoveStaleProcessingStatusFunc func(ctx context.Context, staleTime time.Time) error
 
+	// UpdateProcessingProgressFunc mocks the UpdateProcessingProgress method.
+	UpdateProcessingProgressFunc func(ctx context.Context, status data.ProcessingStatusEntity) error
+
 	// UpdateProcessingStatusFunc mocks the UpdateProcessingStatus method.
 	UpdateProcessingStatusFunc func(ctx context.Context, status data.ProcessingStatusEntity) error
 
+	// UpsertDataQualityReportsFunc mocks the UpsertDataQualityReports method.
+	UpsertDataQualityReportsFunc func(ctx context.Context, reports []data.DataQualityEntity) error
+
+	// UpsertRollupsFunc mocks the UpsertRollups method.
+	UpsertRollupsFunc func(ctx context.Context, rows []data.RollupEntity) error
+
+	// WithTransactionFunc mocks the WithTransaction method.
+	WithTransactionFunc func(ctx context.Context, fn func(Repository) error) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CountDataPointsBySourceFile holds details about calls to the CountDataPointsBySourceFile method.
+		CountDataPointsBySourceFile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FileName is the fileName argument value.
+			FileName string
+		}
+		// CreatePartitions holds details about calls to the CreatePartitions method.
+		CreatePartitions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Until is the until argument value.
+			Until time.Time
+		}
+		// DeleteAnomaly holds details about calls to the DeleteAnomaly method.
+		DeleteAnomaly []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteDataPoint holds details about calls to the DeleteDataPoint method.
+		DeleteDataPoint []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Symbol is the symbol argument value.
+			Symbol string
+			// T is the t argument value.
+			T time.Time
+		}
+		// DeleteDataPointsBefore holds details about calls to the DeleteDataPointsBefore method.
+		DeleteDataPointsBefore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Symbol is the symbol argument value.
+			Symbol string
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// DeleteDataPointsBySourceFile holds details about calls to the DeleteDataPointsBySourceFile method.
+		DeleteDataPointsBySourceFile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FileName is the fileName argument value.
+			FileName string
+		}
+		// DeleteRollups holds details about calls to the DeleteRollups method.
+		DeleteRollups []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Symbol is the symbol argument value.
+			Symbol string
+			// IntervalSeconds is the intervalSeconds argument value.
+			IntervalSeconds int64
+			// StartTime is the startTime argument value.
+			StartTime time.Time
+			// EndTime is the endTime argument value.
+			EndTime time.Time
+		}
+		// DropPartitions holds details about calls to the DropPartitions method.
+		DropPartitions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// GetAnomalies holds details about calls to the GetAnomalies method.
+		GetAnomalies []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.GetAnomaliesRequest
+		}
+		// GetAnomaly holds details about calls to the GetAnomaly method.
+		GetAnomaly []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
 		// GetDataPoints holds details about calls to the GetDataPoints method.
 		GetDataPoints []struct {
 			// Ctx is the ctx argument value.
@@ -72,6 +314,50 @@ type RepositoryMock struct {
 			// Payload is the payload argument value.
 			Payload data.GetOHLCRequest
 		}
+		// GetDataPointsInRange holds details about calls to the GetDataPointsInRange method.
+		GetDataPointsInRange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Symbol is the symbol argument value.
+			Symbol string
+			// StartTime is the startTime argument value.
+			StartTime time.Time
+			// EndTime is the endTime argument value.
+			EndTime time.Time
+		}
+		// GetDataQualityReports holds details about calls to the GetDataQualityReports method.
+		GetDataQualityReports []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Symbol is the symbol argument value.
+			Symbol string
+			// StartTime is the startTime argument value.
+			StartTime time.Time
+			// EndTime is the endTime argument value.
+			EndTime time.Time
+		}
+		// GetLatestDataPoints holds details about calls to the GetLatestDataPoints method.
+		GetLatestDataPoints []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Symbol is the symbol argument value.
+			Symbol string
+			// Before is the before argument value.
+			Before time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetOldestRollups holds details about calls to the GetOldestRollups method.
+		GetOldestRollups []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Symbol is the symbol argument value.
+			Symbol string
+			// IntervalSeconds is the intervalSeconds argument value.
+			IntervalSeconds int64
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetProcessingStatus holds details about calls to the GetProcessingStatus method.
 		GetProcessingStatus []struct {
 			// Ctx is the ctx argument value.
@@ -79,6 +365,57 @@ type RepositoryMock struct {
 			// FileName is the fileName argument value.
 			FileName string
 		}
+		// GetProcessingStatuses holds details about calls to the GetProcessingStatuses method.
+		GetProcessingStatuses []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.GetUploadsRequest
+		}
+		// GetRejectedMessages holds details about calls to the GetRejectedMessages method.
+		GetRejectedMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.GetRejectedMessagesRequest
+		}
+		// GetRollupSymbols holds details about calls to the GetRollupSymbols method.
+		GetRollupSymbols []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetRollups holds details about calls to the GetRollups method.
+		GetRollups []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Symbol is the symbol argument value.
+			Symbol string
+			// IntervalSeconds is the intervalSeconds argument value.
+			IntervalSeconds int64
+			// StartTime is the startTime argument value.
+			StartTime time.Time
+			// EndTime is the endTime argument value.
+			EndTime time.Time
+		}
+		// GetSymbols holds details about calls to the GetSymbols method.
+		GetSymbols []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetWebhookDeliveries holds details about calls to the GetWebhookDeliveries method.
+		GetWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FileName is the fileName argument value.
+			FileName string
+		}
+		// InsertAnomalies holds details about calls to the InsertAnomalies method.
+		InsertAnomalies []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Anomalies is the anomalies argument value.
+			Anomalies []data.AnomalyEntity
+		}
 		// InsertDataPoints holds details about calls to the InsertDataPoints method.
 		InsertDataPoints []struct {
 			// Ctx is the ctx argument value.
@@ -93,6 +430,20 @@ type RepositoryMock struct {
 			// Status is the status argument value.
 			Status data.ProcessingStatusEntity
 		}
+		// InsertRejectedMessage holds details about calls to the InsertRejectedMessage method.
+		InsertRejectedMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message data.RejectedMessageEntity
+		}
+		// InsertWebhookDelivery holds details about calls to the InsertWebhookDelivery method.
+		InsertWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Delivery is the delivery argument value.
+			Delivery data.WebhookDeliveryEntity
+		}
 		// RemoveStaleProcessingStatus holds details about calls to the RemoveStaleProcessingStatus method.
 		RemoveStaleProcessingStatus []struct {
 			// Ctx is the ctx argument value.
@@ -100,6 +451,13 @@ type RepositoryMock struct {
 			// StaleTime is the staleTime argument value.
 			StaleTime time.Time
 		}
+		// UpdateProcessingProgress holds details about calls to the UpdateProcessingProgress method.
+		UpdateProcessingProgress []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Status is the status argument value.
+			Status data.ProcessingStatusEntity
+		}
 		// UpdateProcessingStatus holds details about calls to the UpdateProcessingStatus method.
 		UpdateProcessingStatus []struct {
 			// Ctx is the ctx argument value.
@@ -107,48 +465,652 @@ type RepositoryMock struct {
 			// Status is the status argument value.
 			Status data.ProcessingStatusEntity
 		}
+		// UpsertDataQualityReports holds details about calls to the UpsertDataQualityReports method.
+		UpsertDataQualityReports []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Reports is the reports argument value.
+			Reports []data.DataQualityEntity
+		}
+		// UpsertRollups holds details about calls to the UpsertRollups method.
+		UpsertRollups []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Rows is the rows argument value.
+			Rows []data.RollupEntity
+		}
+		// WithTransaction holds details about calls to the WithTransaction method.
+		WithTransaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Fn is the fn argument value.
+			Fn func(Repository) error
+		}
+	}
+	lockCountDataPointsBySourceFile  sync.RWMutex
+	lockCreatePartitions             sync.RWMutex
+	lockDeleteAnomaly                sync.RWMutex
+	lockDeleteDataPoint              sync.RWMutex
+	lockDeleteDataPointsBefore       sync.RWMutex
+	lockDeleteDataPointsBySourceFile sync.RWMutex
+	lockDeleteRollups                sync.RWMutex
+	lockDropPartitions               sync.RWMutex
+	lockGetAnomalies                 sync.RWMutex
+	lockGetAnomaly                   sync.RWMutex
+	lockGetDataPoints                sync.RWMutex
+	lockGetDataPointsInRange         sync.RWMutex
+	lockGetDataQualityReports        sync.RWMutex
+	lockGetLatestDataPoints          sync.RWMutex
+	lockGetOldestRollups             sync.RWMutex
+	lockGetProcessingStatus          sync.RWMutex
+	lockGetProcessingStatuses        sync.RWMutex
+	lockGetRejectedMessages          sync.RWMutex
+	lockGetRollupSymbols             sync.RWMutex
+	lockGetRollups                   sync.RWMutex
+	lockGetSymbols                   sync.RWMutex
+	lockGetWebhookDeliveries         sync.RWMutex
+	lockInsertAnomalies              sync.RWMutex
+	lockInsertDataPoints             sync.RWMutex
+	lockInsertProcessingStatus       sync.RWMutex
+	lockInsertRejectedMessage        sync.RWMutex
+	lockInsertWebhookDelivery        sync.RWMutex
+	lockRemoveStaleProcessingStatus  sync.RWMutex
+	lockUpdateProcessingProgress     sync.RWMutex
+	lockUpdateProcessingStatus       sync.RWMutex
+	lockUpsertDataQualityReports     sync.RWMutex
+	lockUpsertRollups                sync.RWMutex
+	lockWithTransaction              sync.RWMutex
+}
+
+// CountDataPointsBySourceFile calls CountDataPointsBySourceFileFunc.
+func (mock *RepositoryMock) CountDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	if mock.CountDataPointsBySourceFileFunc == nil {
+		panic("RepositoryMock.CountDataPointsBySourceFileFunc: method is nil but Repository.CountDataPointsBySourceFile was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		FileName string
+	}{
+		Ctx:      ctx,
+		FileName: fileName,
+	}
+	mock.lockCountDataPointsBySourceFile.Lock()
+	mock.calls.CountDataPointsBySourceFile = append(mock.calls.CountDataPointsBySourceFile, callInfo)
+	mock.lockCountDataPointsBySourceFile.Unlock()
+	return mock.CountDataPointsBySourceFileFunc(ctx, fileName)
+}
+
+// CountDataPointsBySourceFileCalls gets all the calls that were made to CountDataPointsBySourceFile.
+// Check the length with:
+//
+//	len(mockedRepository.CountDataPointsBySourceFileCalls())
+func (mock *RepositoryMock) CountDataPointsBySourceFileCalls() []struct {
+	Ctx      context.Context
+	FileName string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		FileName string
+	}
+	mock.lockCountDataPointsBySourceFile.RLock()
+	calls = mock.calls.CountDataPointsBySourceFile
+	mock.lockCountDataPointsBySourceFile.RUnlock()
+	return calls
+}
+
+// CreatePartitions calls CreatePartitionsFunc.
+func (mock *RepositoryMock) CreatePartitions(ctx context.Context, until time.Time) error {
+	if mock.CreatePartitionsFunc == nil {
+		panic("RepositoryMock.CreatePartitionsFunc: method is nil but Repository.CreatePartitions was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Until time.Time
+	}{
+		Ctx:   ctx,
+		Until: until,
+	}
+	mock.lockCreatePartitions.Lock()
+	mock.calls.CreatePartitions = append(mock.calls.CreatePartitions, callInfo)
+	mock.lockCreatePartitions.Unlock()
+	return mock.CreatePartitionsFunc(ctx, until)
+}
+
+// CreatePartitionsCalls gets all the calls that were made to CreatePartitions.
+// Check the length with:
+//
+//	len(mockedRepository.CreatePartitionsCalls())
+func (mock *RepositoryMock) CreatePartitionsCalls() []struct {
+	Ctx   context.Context
+	Until time.Time
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Until time.Time
+	}
+	mock.lockCreatePartitions.RLock()
+	calls = mock.calls.CreatePartitions
+	mock.lockCreatePartitions.RUnlock()
+	return calls
+}
+
+// DeleteAnomaly calls DeleteAnomalyFunc.
+func (mock *RepositoryMock) DeleteAnomaly(ctx context.Context, id int64) error {
+	if mock.DeleteAnomalyFunc == nil {
+		panic("RepositoryMock.DeleteAnomalyFunc: method is nil but Repository.DeleteAnomaly was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteAnomaly.Lock()
+	mock.calls.DeleteAnomaly = append(mock.calls.DeleteAnomaly, callInfo)
+	mock.lockDeleteAnomaly.Unlock()
+	return mock.DeleteAnomalyFunc(ctx, id)
+}
+
+// DeleteAnomalyCalls gets all the calls that were made to DeleteAnomaly.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteAnomalyCalls())
+func (mock *RepositoryMock) DeleteAnomalyCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	mock.lockDeleteAnomaly.RLock()
+	calls = mock.calls.DeleteAnomaly
+	mock.lockDeleteAnomaly.RUnlock()
+	return calls
+}
+
+// DeleteDataPoint calls DeleteDataPointFunc.
+func (mock *RepositoryMock) DeleteDataPoint(ctx context.Context, symbol string, t time.Time) error {
+	if mock.DeleteDataPointFunc == nil {
+		panic("RepositoryMock.DeleteDataPointFunc: method is nil but Repository.DeleteDataPoint was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Symbol string
+		T      time.Time
+	}{
+		Ctx:    ctx,
+		Symbol: symbol,
+		T:      t,
+	}
+	mock.lockDeleteDataPoint.Lock()
+	mock.calls.DeleteDataPoint = append(mock.calls.DeleteDataPoint, callInfo)
+	mock.lockDeleteDataPoint.Unlock()
+	return mock.DeleteDataPointFunc(ctx, symbol, t)
+}
+
+// DeleteDataPointCalls gets all the calls that were made to DeleteDataPoint.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteDataPointCalls())
+func (mock *RepositoryMock) DeleteDataPointCalls() []struct {
+	Ctx    context.Context
+	Symbol string
+	T      time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Symbol string
+		T      time.Time
+	}
+	mock.lockDeleteDataPoint.RLock()
+	calls = mock.calls.DeleteDataPoint
+	mock.lockDeleteDataPoint.RUnlock()
+	return calls
+}
+
+// DeleteDataPointsBefore calls DeleteDataPointsBeforeFunc.
+func (mock *RepositoryMock) DeleteDataPointsBefore(ctx context.Context, symbol string, before time.Time) (int64, error) {
+	if mock.DeleteDataPointsBeforeFunc == nil {
+		panic("RepositoryMock.DeleteDataPointsBeforeFunc: method is nil but Repository.DeleteDataPointsBefore was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Symbol string
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Symbol: symbol,
+		Before: before,
+	}
+	mock.lockDeleteDataPointsBefore.Lock()
+	mock.calls.DeleteDataPointsBefore = append(mock.calls.DeleteDataPointsBefore, callInfo)
+	mock.lockDeleteDataPointsBefore.Unlock()
+	return mock.DeleteDataPointsBeforeFunc(ctx, symbol, before)
+}
+
+// DeleteDataPointsBeforeCalls gets all the calls that were made to DeleteDataPointsBefore.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteDataPointsBeforeCalls())
+func (mock *RepositoryMock) DeleteDataPointsBeforeCalls() []struct {
+	Ctx    context.Context
+	Symbol string
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Symbol string
+		Before time.Time
+	}
+	mock.lockDeleteDataPointsBefore.RLock()
+	calls = mock.calls.DeleteDataPointsBefore
+	mock.lockDeleteDataPointsBefore.RUnlock()
+	return calls
+}
+
+// DeleteDataPointsBySourceFile calls DeleteDataPointsBySourceFileFunc.
+func (mock *RepositoryMock) DeleteDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	if mock.DeleteDataPointsBySourceFileFunc == nil {
+		panic("RepositoryMock.DeleteDataPointsBySourceFileFunc: method is nil but Repository.DeleteDataPointsBySourceFile was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		FileName string
+	}{
+		Ctx:      ctx,
+		FileName: fileName,
+	}
+	mock.lockDeleteDataPointsBySourceFile.Lock()
+	mock.calls.DeleteDataPointsBySourceFile = append(mock.calls.DeleteDataPointsBySourceFile, callInfo)
+	mock.lockDeleteDataPointsBySourceFile.Unlock()
+	return mock.DeleteDataPointsBySourceFileFunc(ctx, fileName)
+}
+
+// DeleteDataPointsBySourceFileCalls gets all the calls that were made to DeleteDataPointsBySourceFile.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteDataPointsBySourceFileCalls())
+func (mock *RepositoryMock) DeleteDataPointsBySourceFileCalls() []struct {
+	Ctx      context.Context
+	FileName string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		FileName string
+	}
+	mock.lockDeleteDataPointsBySourceFile.RLock()
+	calls = mock.calls.DeleteDataPointsBySourceFile
+	mock.lockDeleteDataPointsBySourceFile.RUnlock()
+	return calls
+}
+
+// DeleteRollups calls DeleteRollupsFunc.
+func (mock *RepositoryMock) DeleteRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) (int64, error) {
+	if mock.DeleteRollupsFunc == nil {
+		panic("RepositoryMock.DeleteRollupsFunc: method is nil but Repository.DeleteRollups was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		Symbol          string
+		IntervalSeconds int64
+		StartTime       time.Time
+		EndTime         time.Time
+	}{
+		Ctx:             ctx,
+		Symbol:          symbol,
+		IntervalSeconds: intervalSeconds,
+		StartTime:       startTime,
+		EndTime:         endTime,
+	}
+	mock.lockDeleteRollups.Lock()
+	mock.calls.DeleteRollups = append(mock.calls.DeleteRollups, callInfo)
+	mock.lockDeleteRollups.Unlock()
+	return mock.DeleteRollupsFunc(ctx, symbol, intervalSeconds, startTime, endTime)
+}
+
+// DeleteRollupsCalls gets all the calls that were made to DeleteRollups.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteRollupsCalls())
+func (mock *RepositoryMock) DeleteRollupsCalls() []struct {
+	Ctx             context.Context
+	Symbol          string
+	IntervalSeconds int64
+	StartTime       time.Time
+	EndTime         time.Time
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Symbol          string
+		IntervalSeconds int64
+		StartTime       time.Time
+		EndTime         time.Time
+	}
+	mock.lockDeleteRollups.RLock()
+	calls = mock.calls.DeleteRollups
+	mock.lockDeleteRollups.RUnlock()
+	return calls
+}
+
+// DropPartitions calls DropPartitionsFunc.
+func (mock *RepositoryMock) DropPartitions(ctx context.Context, before time.Time) error {
+	if mock.DropPartitionsFunc == nil {
+		panic("RepositoryMock.DropPartitionsFunc: method is nil but Repository.DropPartitions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Before: before,
+	}
+	mock.lockDropPartitions.Lock()
+	mock.calls.DropPartitions = append(mock.calls.DropPartitions, callInfo)
+	mock.lockDropPartitions.Unlock()
+	return mock.DropPartitionsFunc(ctx, before)
+}
+
+// DropPartitionsCalls gets all the calls that were made to DropPartitions.
+// Check the length with:
+//
+//	len(mockedRepository.DropPartitionsCalls())
+func (mock *RepositoryMock) DropPartitionsCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+	}
+	mock.lockDropPartitions.RLock()
+	calls = mock.calls.DropPartitions
+	mock.lockDropPartitions.RUnlock()
+	return calls
+}
+
+// GetAnomalies calls GetAnomaliesFunc.
+func (mock *RepositoryMock) GetAnomalies(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, error) {
+	if mock.GetAnomaliesFunc == nil {
+		panic("RepositoryMock.GetAnomaliesFunc: method is nil but Repository.GetAnomalies was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.GetAnomaliesRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGetAnomalies.Lock()
+	mock.calls.GetAnomalies = append(mock.calls.GetAnomalies, callInfo)
+	mock.lockGetAnomalies.Unlock()
+	return mock.GetAnomaliesFunc(ctx, payload)
+}
+
+// GetAnomaliesCalls gets all the calls that were made to GetAnomalies.
+// Check the length with:
+//
+//	len(mockedRepository.GetAnomaliesCalls())
+func (mock *RepositoryMock) GetAnomaliesCalls() []struct {
+	Ctx     context.Context
+	Payload data.GetAnomaliesRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.GetAnomaliesRequest
+	}
+	mock.lockGetAnomalies.RLock()
+	calls = mock.calls.GetAnomalies
+	mock.lockGetAnomalies.RUnlock()
+	return calls
+}
+
+// GetAnomaly calls GetAnomalyFunc.
+func (mock *RepositoryMock) GetAnomaly(ctx context.Context, id int64) (*data.AnomalyEntity, error) {
+	if mock.GetAnomalyFunc == nil {
+		panic("RepositoryMock.GetAnomalyFunc: method is nil but Repository.GetAnomaly was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetAnomaly.Lock()
+	mock.calls.GetAnomaly = append(mock.calls.GetAnomaly, callInfo)
+	mock.lockGetAnomaly.Unlock()
+	return mock.GetAnomalyFunc(ctx, id)
+}
+
+// GetAnomalyCalls gets all the calls that were made to GetAnomaly.
+// Check the length with:
+//
+//	len(mockedRepository.GetAnomalyCalls())
+func (mock *RepositoryMock) GetAnomalyCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	mock.lockGetAnomaly.RLock()
+	calls = mock.calls.GetAnomaly
+	mock.lockGetAnomaly.RUnlock()
+	return calls
+}
+
+// GetDataPoints calls GetDataPointsFunc.
+func (mock *RepositoryMock) GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error) {
+	if mock.GetDataPointsFunc == nil {
+		panic("RepositoryMock.GetDataPointsFunc: method is nil but Repository.GetDataPoints was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.GetOHLCRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGetDataPoints.Lock()
+	mock.calls.GetDataPoints = append(mock.calls.GetDataPoints, callInfo)
+	mock.lockGetDataPoints.Unlock()
+	return mock.GetDataPointsFunc(ctx, payload)
+}
+
+// GetDataPointsCalls gets all the calls that were made to GetDataPoints.
+// Check the length with:
+//
+//	len(mockedRepository.GetDataPointsCalls())
+func (mock *RepositoryMock) GetDataPointsCalls() []struct {
+	Ctx     context.Context
+	Payload data.GetOHLCRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.GetOHLCRequest
+	}
+	mock.lockGetDataPoints.RLock()
+	calls = mock.calls.GetDataPoints
+	mock.lockGetDataPoints.RUnlock()
+	return calls
+}
+
+// GetDataPointsInRange calls GetDataPointsInRangeFunc.
+func (mock *RepositoryMock) GetDataPointsInRange(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.OHLCEntity, error) {
+	if mock.GetDataPointsInRangeFunc == nil {
+		panic("RepositoryMock.GetDataPointsInRangeFunc: method is nil but Repository.GetDataPointsInRange was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Symbol    string
+		StartTime time.Time
+		EndTime   time.Time
+	}{
+		Ctx:       ctx,
+		Symbol:    symbol,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+	mock.lockGetDataPointsInRange.Lock()
+	mock.calls.GetDataPointsInRange = append(mock.calls.GetDataPointsInRange, callInfo)
+	mock.lockGetDataPointsInRange.Unlock()
+	return mock.GetDataPointsInRangeFunc(ctx, symbol, startTime, endTime)
+}
+
+// GetDataPointsInRangeCalls gets all the calls that were made to GetDataPointsInRange.
+// Check the length with:
+//
+//	len(mockedRepository.GetDataPointsInRangeCalls())
+func (mock *RepositoryMock) GetDataPointsInRangeCalls() []struct {
+	Ctx       context.Context
+	Symbol    string
+	StartTime time.Time
+	EndTime   time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Symbol    string
+		StartTime time.Time
+		EndTime   time.Time
+	}
+	mock.lockGetDataPointsInRange.RLock()
+	calls = mock.calls.GetDataPointsInRange
+	mock.lockGetDataPointsInRange.RUnlock()
+	return calls
+}
+
+// GetDataQualityReports calls GetDataQualityReportsFunc.
+func (mock *RepositoryMock) GetDataQualityReports(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.DataQualityEntity, error) {
+	if mock.GetDataQualityReportsFunc == nil {
+		panic("RepositoryMock.GetDataQualityReportsFunc: method is nil but Repository.GetDataQualityReports was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Symbol    string
+		StartTime time.Time
+		EndTime   time.Time
+	}{
+		Ctx:       ctx,
+		Symbol:    symbol,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+	mock.lockGetDataQualityReports.Lock()
+	mock.calls.GetDataQualityReports = append(mock.calls.GetDataQualityReports, callInfo)
+	mock.lockGetDataQualityReports.Unlock()
+	return mock.GetDataQualityReportsFunc(ctx, symbol, startTime, endTime)
+}
+
+// GetDataQualityReportsCalls gets all the calls that were made to GetDataQualityReports.
+// Check the length with:
+//
+//	len(mockedRepository.GetDataQualityReportsCalls())
+func (mock *RepositoryMock) GetDataQualityReportsCalls() []struct {
+	Ctx       context.Context
+	Symbol    string
+	StartTime time.Time
+	EndTime   time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Symbol    string
+		StartTime time.Time
+		EndTime   time.Time
+	}
+	mock.lockGetDataQualityReports.RLock()
+	calls = mock.calls.GetDataQualityReports
+	mock.lockGetDataQualityReports.RUnlock()
+	return calls
+}
+
+// GetLatestDataPoints calls GetLatestDataPointsFunc.
+func (mock *RepositoryMock) GetLatestDataPoints(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error) {
+	if mock.GetLatestDataPointsFunc == nil {
+		panic("RepositoryMock.GetLatestDataPointsFunc: method is nil but Repository.GetLatestDataPoints was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Symbol string
+		Before time.Time
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Symbol: symbol,
+		Before: before,
+		Limit:  limit,
+	}
+	mock.lockGetLatestDataPoints.Lock()
+	mock.calls.GetLatestDataPoints = append(mock.calls.GetLatestDataPoints, callInfo)
+	mock.lockGetLatestDataPoints.Unlock()
+	return mock.GetLatestDataPointsFunc(ctx, symbol, before, limit)
+}
+
+// GetLatestDataPointsCalls gets all the calls that were made to GetLatestDataPoints.
+// Check the length with:
+//
+//	len(mockedRepository.GetLatestDataPointsCalls())
+func (mock *RepositoryMock) GetLatestDataPointsCalls() []struct {
+	Ctx    context.Context
+	Symbol string
+	Before time.Time
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Symbol string
+		Before time.Time
+		Limit  int
 	}
-	lockGetDataPoints               sync.RWMutex
-	lockGetProcessingStatus         sync.RWMutex
-	lockInsertDataPoints            sync.RWMutex
-	lockInsertProcessingStatus      sync.RWMutex
-	lockRemoveStaleProcessingStatus sync.RWMutex
-	lockUpdateProcessingStatus      sync.RWMutex
+	mock.lockGetLatestDataPoints.RLock()
+	calls = mock.calls.GetLatestDataPoints
+	mock.lockGetLatestDataPoints.RUnlock()
+	return calls
 }
 
-// GetDataPoints calls GetDataPointsFunc.
-func (mock *RepositoryMock) GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error) {
-	if mock.GetDataPointsFunc == nil {
-		panic("RepositoryMock.GetDataPointsFunc: method is nil but Repository.GetDataPoints was just called")
+// GetOldestRollups calls GetOldestRollupsFunc.
+func (mock *RepositoryMock) GetOldestRollups(ctx context.Context, symbol string, intervalSeconds int64, limit int) ([]data.RollupEntity, error) {
+	if mock.GetOldestRollupsFunc == nil {
+		panic("RepositoryMock.GetOldestRollupsFunc: method is nil but Repository.GetOldestRollups was just called")
 	}
 	callInfo := struct {
-		Ctx     context.Context
-		Payload data.GetOHLCRequest
+		Ctx             context.Context
+		Symbol          string
+		IntervalSeconds int64
+		Limit           int
 	}{
-		Ctx:     ctx,
-		Payload: payload,
+		Ctx:             ctx,
+		Symbol:          symbol,
+		IntervalSeconds: intervalSeconds,
+		Limit:           limit,
 	}
-	mock.lockGetDataPoints.Lock()
-	mock.calls.GetDataPoints = append(mock.calls.GetDataPoints, callInfo)
-	mock.lockGetDataPoints.Unlock()
-	return mock.GetDataPointsFunc(ctx, payload)
+	mock.lockGetOldestRollups.Lock()
+	mock.calls.GetOldestRollups = append(mock.calls.GetOldestRollups, callInfo)
+	mock.lockGetOldestRollups.Unlock()
+	return mock.GetOldestRollupsFunc(ctx, symbol, intervalSeconds, limit)
 }
 
-// GetDataPointsCalls gets all the calls that were made to GetDataPoints.
+// GetOldestRollupsCalls gets all the calls that were made to GetOldestRollups.
 // Check the length with:
 //
-//	len(mockedRepository.GetDataPointsCalls())
-func (mock *RepositoryMock) GetDataPointsCalls() []struct {
-	Ctx     context.Context
-	Payload data.GetOHLCRequest
+//	len(mockedRepository.GetOldestRollupsCalls())
+func (mock *RepositoryMock) GetOldestRollupsCalls() []struct {
+	Ctx             context.Context
+	Symbol          string
+	IntervalSeconds int64
+	Limit           int
 } {
 	var calls []struct {
-		Ctx     context.Context
-		Payload data.GetOHLCRequest
+		Ctx             context.Context
+		Symbol          string
+		IntervalSeconds int64
+		Limit           int
 	}
-	mock.lockGetDataPoints.RLock()
-	calls = mock.calls.GetDataPoints
-	mock.lockGetDataPoints.RUnlock()
+	mock.lockGetOldestRollups.RLock()
+	calls = mock.calls.GetOldestRollups
+	mock.lockGetOldestRollups.RUnlock()
 	return calls
 }
 
@@ -188,6 +1150,262 @@ func (mock *RepositoryMock) GetProcessingStatusCalls() []struct {
 	return calls
 }
 
+// GetProcessingStatuses calls GetProcessingStatusesFunc.
+func (mock *RepositoryMock) GetProcessingStatuses(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, error) {
+	if mock.GetProcessingStatusesFunc == nil {
+		panic("RepositoryMock.GetProcessingStatusesFunc: method is nil but Repository.GetProcessingStatuses was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.GetUploadsRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGetProcessingStatuses.Lock()
+	mock.calls.GetProcessingStatuses = append(mock.calls.GetProcessingStatuses, callInfo)
+	mock.lockGetProcessingStatuses.Unlock()
+	return mock.GetProcessingStatusesFunc(ctx, payload)
+}
+
+// GetProcessingStatusesCalls gets all the calls that were made to GetProcessingStatuses.
+// Check the length with:
+//
+//	len(mockedRepository.GetProcessingStatusesCalls())
+func (mock *RepositoryMock) GetProcessingStatusesCalls() []struct {
+	Ctx     context.Context
+	Payload data.GetUploadsRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.GetUploadsRequest
+	}
+	mock.lockGetProcessingStatuses.RLock()
+	calls = mock.calls.GetProcessingStatuses
+	mock.lockGetProcessingStatuses.RUnlock()
+	return calls
+}
+
+// GetRejectedMessages calls GetRejectedMessagesFunc.
+func (mock *RepositoryMock) GetRejectedMessages(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, error) {
+	if mock.GetRejectedMessagesFunc == nil {
+		panic("RepositoryMock.GetRejectedMessagesFunc: method is nil but Repository.GetRejectedMessages was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.GetRejectedMessagesRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGetRejectedMessages.Lock()
+	mock.calls.GetRejectedMessages = append(mock.calls.GetRejectedMessages, callInfo)
+	mock.lockGetRejectedMessages.Unlock()
+	return mock.GetRejectedMessagesFunc(ctx, payload)
+}
+
+// GetRejectedMessagesCalls gets all the calls that were made to GetRejectedMessages.
+// Check the length with:
+//
+//	len(mockedRepository.GetRejectedMessagesCalls())
+func (mock *RepositoryMock) GetRejectedMessagesCalls() []struct {
+	Ctx     context.Context
+	Payload data.GetRejectedMessagesRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.GetRejectedMessagesRequest
+	}
+	mock.lockGetRejectedMessages.RLock()
+	calls = mock.calls.GetRejectedMessages
+	mock.lockGetRejectedMessages.RUnlock()
+	return calls
+}
+
+// GetRollupSymbols calls GetRollupSymbolsFunc.
+func (mock *RepositoryMock) GetRollupSymbols(ctx context.Context) ([]string, error) {
+	if mock.GetRollupSymbolsFunc == nil {
+		panic("RepositoryMock.GetRollupSymbolsFunc: method is nil but Repository.GetRollupSymbols was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetRollupSymbols.Lock()
+	mock.calls.GetRollupSymbols = append(mock.calls.GetRollupSymbols, callInfo)
+	mock.lockGetRollupSymbols.Unlock()
+	return mock.GetRollupSymbolsFunc(ctx)
+}
+
+// GetRollupSymbolsCalls gets all the calls that were made to GetRollupSymbols.
+// Check the length with:
+//
+//	len(mockedRepository.GetRollupSymbolsCalls())
+func (mock *RepositoryMock) GetRollupSymbolsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetRollupSymbols.RLock()
+	calls = mock.calls.GetRollupSymbols
+	mock.lockGetRollupSymbols.RUnlock()
+	return calls
+}
+
+// GetRollups calls GetRollupsFunc.
+func (mock *RepositoryMock) GetRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) ([]data.RollupEntity, error) {
+	if mock.GetRollupsFunc == nil {
+		panic("RepositoryMock.GetRollupsFunc: method is nil but Repository.GetRollups was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		Symbol          string
+		IntervalSeconds int64
+		StartTime       time.Time
+		EndTime         time.Time
+	}{
+		Ctx:             ctx,
+		Symbol:          symbol,
+		IntervalSeconds: intervalSeconds,
+		StartTime:       startTime,
+		EndTime:         endTime,
+	}
+	mock.lockGetRollups.Lock()
+	mock.calls.GetRollups = append(mock.calls.GetRollups, callInfo)
+	mock.lockGetRollups.Unlock()
+	return mock.GetRollupsFunc(ctx, symbol, intervalSeconds, startTime, endTime)
+}
+
+// GetRollupsCalls gets all the calls that were made to GetRollups.
+// Check the length with:
+//
+//	len(mockedRepository.GetRollupsCalls())
+func (mock *RepositoryMock) GetRollupsCalls() []struct {
+	Ctx             context.Context
+	Symbol          string
+	IntervalSeconds int64
+	StartTime       time.Time
+	EndTime         time.Time
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Symbol          string
+		IntervalSeconds int64
+		StartTime       time.Time
+		EndTime         time.Time
+	}
+	mock.lockGetRollups.RLock()
+	calls = mock.calls.GetRollups
+	mock.lockGetRollups.RUnlock()
+	return calls
+}
+
+// GetSymbols calls GetSymbolsFunc.
+func (mock *RepositoryMock) GetSymbols(ctx context.Context) ([]string, error) {
+	if mock.GetSymbolsFunc == nil {
+		panic("RepositoryMock.GetSymbolsFunc: method is nil but Repository.GetSymbols was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetSymbols.Lock()
+	mock.calls.GetSymbols = append(mock.calls.GetSymbols, callInfo)
+	mock.lockGetSymbols.Unlock()
+	return mock.GetSymbolsFunc(ctx)
+}
+
+// GetSymbolsCalls gets all the calls that were made to GetSymbols.
+// Check the length with:
+//
+//	len(mockedRepository.GetSymbolsCalls())
+func (mock *RepositoryMock) GetSymbolsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetSymbols.RLock()
+	calls = mock.calls.GetSymbols
+	mock.lockGetSymbols.RUnlock()
+	return calls
+}
+
+// GetWebhookDeliveries calls GetWebhookDeliveriesFunc.
+func (mock *RepositoryMock) GetWebhookDeliveries(ctx context.Context, fileName string) ([]data.WebhookDeliveryEntity, error) {
+	if mock.GetWebhookDeliveriesFunc == nil {
+		panic("RepositoryMock.GetWebhookDeliveriesFunc: method is nil but Repository.GetWebhookDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		FileName string
+	}{
+		Ctx:      ctx,
+		FileName: fileName,
+	}
+	mock.lockGetWebhookDeliveries.Lock()
+	mock.calls.GetWebhookDeliveries = append(mock.calls.GetWebhookDeliveries, callInfo)
+	mock.lockGetWebhookDeliveries.Unlock()
+	return mock.GetWebhookDeliveriesFunc(ctx, fileName)
+}
+
+// GetWebhookDeliveriesCalls gets all the calls that were made to GetWebhookDeliveries.
+// Check the length with:
+//
+//	len(mockedRepository.GetWebhookDeliveriesCalls())
+func (mock *RepositoryMock) GetWebhookDeliveriesCalls() []struct {
+	Ctx      context.Context
+	FileName string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		FileName string
+	}
+	mock.lockGetWebhookDeliveries.RLock()
+	calls = mock.calls.GetWebhookDeliveries
+	mock.lockGetWebhookDeliveries.RUnlock()
+	return calls
+}
+
+// InsertAnomalies calls InsertAnomaliesFunc.
+func (mock *RepositoryMock) InsertAnomalies(ctx context.Context, anomalies []data.AnomalyEntity) error {
+	if mock.InsertAnomaliesFunc == nil {
+		panic("RepositoryMock.InsertAnomaliesFunc: method is nil but Repository.InsertAnomalies was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Anomalies []data.AnomalyEntity
+	}{
+		Ctx:       ctx,
+		Anomalies: anomalies,
+	}
+	mock.lockInsertAnomalies.Lock()
+	mock.calls.InsertAnomalies = append(mock.calls.InsertAnomalies, callInfo)
+	mock.lockInsertAnomalies.Unlock()
+	return mock.InsertAnomaliesFunc(ctx, anomalies)
+}
+
+// InsertAnomaliesCalls gets all the calls that were made to InsertAnomalies.
+// Check the length with:
+//
+//	len(mockedRepository.InsertAnomaliesCalls())
+func (mock *RepositoryMock) InsertAnomaliesCalls() []struct {
+	Ctx       context.Context
+	Anomalies []data.AnomalyEntity
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Anomalies []data.AnomalyEntity
+	}
+	mock.lockInsertAnomalies.RLock()
+	calls = mock.calls.InsertAnomalies
+	mock.lockInsertAnomalies.RUnlock()
+	return calls
+}
+
 // InsertDataPoints calls InsertDataPointsFunc.
 func (mock *RepositoryMock) InsertDataPoints(ctx context.Context, rows []data.OHLCEntity) error {
 	if mock.InsertDataPointsFunc == nil {
@@ -260,6 +1478,78 @@ func (mock *RepositoryMock) InsertProcessingStatusCalls() []struct {
 	return calls
 }
 
+// InsertRejectedMessage calls InsertRejectedMessageFunc.
+func (mock *RepositoryMock) InsertRejectedMessage(ctx context.Context, message data.RejectedMessageEntity) error {
+	if mock.InsertRejectedMessageFunc == nil {
+		panic("RepositoryMock.InsertRejectedMessageFunc: method is nil but Repository.InsertRejectedMessage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message data.RejectedMessageEntity
+	}{
+		Ctx:     ctx,
+		Message: message,
+	}
+	mock.lockInsertRejectedMessage.Lock()
+	mock.calls.InsertRejectedMessage = append(mock.calls.InsertRejectedMessage, callInfo)
+	mock.lockInsertRejectedMessage.Unlock()
+	return mock.InsertRejectedMessageFunc(ctx, message)
+}
+
+// InsertRejectedMessageCalls gets all the calls that were made to InsertRejectedMessage.
+// Check the length with:
+//
+//	len(mockedRepository.InsertRejectedMessageCalls())
+func (mock *RepositoryMock) InsertRejectedMessageCalls() []struct {
+	Ctx     context.Context
+	Message data.RejectedMessageEntity
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message data.RejectedMessageEntity
+	}
+	mock.lockInsertRejectedMessage.RLock()
+	calls = mock.calls.InsertRejectedMessage
+	mock.lockInsertRejectedMessage.RUnlock()
+	return calls
+}
+
+// InsertWebhookDelivery calls InsertWebhookDeliveryFunc.
+func (mock *RepositoryMock) InsertWebhookDelivery(ctx context.Context, delivery data.WebhookDeliveryEntity) error {
+	if mock.InsertWebhookDeliveryFunc == nil {
+		panic("RepositoryMock.InsertWebhookDeliveryFunc: method is nil but Repository.InsertWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Delivery data.WebhookDeliveryEntity
+	}{
+		Ctx:      ctx,
+		Delivery: delivery,
+	}
+	mock.lockInsertWebhookDelivery.Lock()
+	mock.calls.InsertWebhookDelivery = append(mock.calls.InsertWebhookDelivery, callInfo)
+	mock.lockInsertWebhookDelivery.Unlock()
+	return mock.InsertWebhookDeliveryFunc(ctx, delivery)
+}
+
+// InsertWebhookDeliveryCalls gets all the calls that were made to InsertWebhookDelivery.
+// Check the length with:
+//
+//	len(mockedRepository.InsertWebhookDeliveryCalls())
+func (mock *RepositoryMock) InsertWebhookDeliveryCalls() []struct {
+	Ctx      context.Context
+	Delivery data.WebhookDeliveryEntity
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Delivery data.WebhookDeliveryEntity
+	}
+	mock.lockInsertWebhookDelivery.RLock()
+	calls = mock.calls.InsertWebhookDelivery
+	mock.lockInsertWebhookDelivery.RUnlock()
+	return calls
+}
+
 // RemoveStaleProcessingStatus calls RemoveStaleProcessingStatusFunc.
 func (mock *RepositoryMock) RemoveStaleProcessingStatus(ctx context.Context, staleTime time.Time) error {
 	if mock.RemoveStaleProcessingStatusFunc == nil {
@@ -296,6 +1586,42 @@ func (mock *RepositoryMock) RemoveStaleProcessingStatusCalls() []struct {
 	return calls
 }
 
+// UpdateProcessingProgress calls UpdateProcessingProgressFunc.
+func (mock *RepositoryMock) UpdateProcessingProgress(ctx context.Context, status data.ProcessingStatusEntity) error {
+	if mock.UpdateProcessingProgressFunc == nil {
+		panic("RepositoryMock.UpdateProcessingProgressFunc: method is nil but Repository.UpdateProcessingProgress was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Status data.ProcessingStatusEntity
+	}{
+		Ctx:    ctx,
+		Status: status,
+	}
+	mock.lockUpdateProcessingProgress.Lock()
+	mock.calls.UpdateProcessingProgress = append(mock.calls.UpdateProcessingProgress, callInfo)
+	mock.lockUpdateProcessingProgress.Unlock()
+	return mock.UpdateProcessingProgressFunc(ctx, status)
+}
+
+// UpdateProcessingProgressCalls gets all the calls that were made to UpdateProcessingProgress.
+// Check the length with:
+//
+//	len(mockedRepository.UpdateProcessingProgressCalls())
+func (mock *RepositoryMock) UpdateProcessingProgressCalls() []struct {
+	Ctx    context.Context
+	Status data.ProcessingStatusEntity
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Status data.ProcessingStatusEntity
+	}
+	mock.lockUpdateProcessingProgress.RLock()
+	calls = mock.calls.UpdateProcessingProgress
+	mock.lockUpdateProcessingProgress.RUnlock()
+	return calls
+}
+
 // UpdateProcessingStatus calls UpdateProcessingStatusFunc.
 func (mock *RepositoryMock) UpdateProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error {
 	if mock.UpdateProcessingStatusFunc == nil {
@@ -331,3 +1657,111 @@ func (mock *RepositoryMock) UpdateProcessingStatusCalls() []struct {
 	mock.lockUpdateProcessingStatus.RUnlock()
 	return calls
 }
+
+// UpsertDataQualityReports calls UpsertDataQualityReportsFunc.
+func (mock *RepositoryMock) UpsertDataQualityReports(ctx context.Context, reports []data.DataQualityEntity) error {
+	if mock.UpsertDataQualityReportsFunc == nil {
+		panic("RepositoryMock.UpsertDataQualityReportsFunc: method is nil but Repository.UpsertDataQualityReports was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Reports []data.DataQualityEntity
+	}{
+		Ctx:     ctx,
+		Reports: reports,
+	}
+	mock.lockUpsertDataQualityReports.Lock()
+	mock.calls.UpsertDataQualityReports = append(mock.calls.UpsertDataQualityReports, callInfo)
+	mock.lockUpsertDataQualityReports.Unlock()
+	return mock.UpsertDataQualityReportsFunc(ctx, reports)
+}
+
+// UpsertDataQualityReportsCalls gets all the calls that were made to UpsertDataQualityReports.
+// Check the length with:
+//
+//	len(mockedRepository.UpsertDataQualityReportsCalls())
+func (mock *RepositoryMock) UpsertDataQualityReportsCalls() []struct {
+	Ctx     context.Context
+	Reports []data.DataQualityEntity
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Reports []data.DataQualityEntity
+	}
+	mock.lockUpsertDataQualityReports.RLock()
+	calls = mock.calls.UpsertDataQualityReports
+	mock.lockUpsertDataQualityReports.RUnlock()
+	return calls
+}
+
+// UpsertRollups calls UpsertRollupsFunc.
+func (mock *RepositoryMock) UpsertRollups(ctx context.Context, rows []data.RollupEntity) error {
+	if mock.UpsertRollupsFunc == nil {
+		panic("RepositoryMock.UpsertRollupsFunc: method is nil but Repository.UpsertRollups was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Rows []data.RollupEntity
+	}{
+		Ctx:  ctx,
+		Rows: rows,
+	}
+	mock.lockUpsertRollups.Lock()
+	mock.calls.UpsertRollups = append(mock.calls.UpsertRollups, callInfo)
+	mock.lockUpsertRollups.Unlock()
+	return mock.UpsertRollupsFunc(ctx, rows)
+}
+
+// UpsertRollupsCalls gets all the calls that were made to UpsertRollups.
+// Check the length with:
+//
+//	len(mockedRepository.UpsertRollupsCalls())
+func (mock *RepositoryMock) UpsertRollupsCalls() []struct {
+	Ctx  context.Context
+	Rows []data.RollupEntity
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Rows []data.RollupEntity
+	}
+	mock.lockUpsertRollups.RLock()
+	calls = mock.calls.UpsertRollups
+	mock.lockUpsertRollups.RUnlock()
+	return calls
+}
+
+// WithTransaction calls WithTransactionFunc.
+func (mock *RepositoryMock) WithTransaction(ctx context.Context, fn func(Repository) error) error {
+	if mock.WithTransactionFunc == nil {
+		panic("RepositoryMock.WithTransactionFunc: method is nil but Repository.WithTransaction was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Fn  func(Repository) error
+	}{
+		Ctx: ctx,
+		Fn:  fn,
+	}
+	mock.lockWithTransaction.Lock()
+	mock.calls.WithTransaction = append(mock.calls.WithTransaction, callInfo)
+	mock.lockWithTransaction.Unlock()
+	return mock.WithTransactionFunc(ctx, fn)
+}
+
+// WithTransactionCalls gets all the calls that were made to WithTransaction.
+// Check the length with:
+//
+//	len(mockedRepository.WithTransactionCalls())
+func (mock *RepositoryMock) WithTransactionCalls() []struct {
+	Ctx context.Context
+	Fn  func(Repository) error
+} {
+	var calls []struct {
+		Ctx context.Context
+		Fn  func(Repository) error
+	}
+	mock.lockWithTransaction.RLock()
+	calls = mock.calls.WithTransaction
+	mock.lockWithTransaction.RUnlock()
+	return calls
+}
@@ -3,6 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -12,20 +15,70 @@ import (
 
 var _ Repository = (*MySQLRepository)(nil)
 
+// uploadSortColumns maps the supported sort fields of the uploads to their column.
+var uploadSortColumns = map[data.UploadSortField]string{
+	data.UploadSortFieldCreatedAt: "created_at",
+	data.UploadSortFieldUpdatedAt: "updated_at",
+	data.UploadSortFieldFileName:  "file_name",
+	data.UploadSortFieldStatus:    "status",
+}
+
+// uploadSortOrders maps the supported sort orders to their SQL keyword.
+var uploadSortOrders = map[data.SortOrder]string{
+	data.SortOrderAsc:  "ASC",
+	data.SortOrderDesc: "DESC",
+}
+
+// executor runs the repository queries. It is implemented by both *sqlx.DB and *sqlx.Tx.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
 // MySQLRepository implements a MySQL repository.
 type MySQLRepository struct {
-	*sqlx.DB
+	executor
+	db *sqlx.DB
 }
 
 // NewRepository initializes a new MySQL repository.
 func NewRepository(db *sqlx.DB) *MySQLRepository {
-	return &MySQLRepository{db}
+	return &MySQLRepository{
+		executor: db,
+		db:       db,
+	}
+}
+
+// WithTransaction runs fn with a repository bound to a new transaction. The transaction is committed if fn succeeds
+// and rolled back otherwise. If the repository is already bound to a transaction, fn joins it.
+func (r *MySQLRepository) WithTransaction(ctx context.Context, fn func(Repository) error) error {
+	if r.db == nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = fn(&MySQLRepository{executor: tx})
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("rollback failed: %v: %w", rbErr, err)
+		}
+		return err
+	}
+	return tx.Commit()
 }
 
 // InsertDataPoints inserts a slice of data.OHLCEntity rows into the ohlc_data table of the MySQL repository.
 // It uses NamedExecContext to bind the values in the sql statement.
 // It returns an error if it failed to insert the data into the table.
 func (r *MySQLRepository) InsertDataPoints(ctx context.Context, rows []data.OHLCEntity) error {
+	if len(rows) == 0 {
+		return nil
+	}
 	stmt := `
 	INSERT INTO ohlc_data
 		(
@@ -34,14 +87,16 @@ func (r *MySQLRepository) InsertDataPoints(ctx context.Context, rows []data.OHLC
 			open,
 			high,
 			low,
-			close
+			close,
+			source_file
 		) VALUES (
 			:time,
 			:symbol,
 			:open,
 			:high,
 			:low,
-			:close
+			:close,
+			:source_file
 		);
 	`
 	_, err := r.NamedExecContext(ctx, stmt, rows)
@@ -86,6 +141,160 @@ func (r *MySQLRepository) GetDataPoints(ctx context.Context, payload data.GetOHL
 	return ohlcPoints, nil
 }
 
+// GetDataPointsInRange retrieves every OHLC data point of a symbol between the start and end times (inclusive).
+// Unlike GetDataPoints, the result is not paginated and is ordered by time.
+func (r *MySQLRepository) GetDataPointsInRange(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.OHLCEntity, error) {
+	stmt := `
+	SELECT
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_data
+	WHERE
+		symbol = ?
+		AND time >= ?
+		AND time <= ?
+	ORDER BY time ASC
+	`
+	var ohlcPoints []data.OHLCEntity
+
+	err := r.SelectContext(ctx, &ohlcPoints, stmt, symbol, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return ohlcPoints, nil
+}
+
+// GetSymbols retrieves the distinct symbols stored in the ohlc_data table.
+func (r *MySQLRepository) GetSymbols(ctx context.Context) ([]string, error) {
+	stmt := `
+	SELECT DISTINCT
+		symbol
+	FROM
+		ohlc_data
+	ORDER BY symbol ASC
+	`
+	var symbols []string
+
+	err := r.SelectContext(ctx, &symbols, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return symbols, nil
+}
+
+// GetLatestDataPoints retrieves the latest OHLC data points of a symbol strictly before the given time.
+// At most `limit` data points are returned, ordered by time.
+func (r *MySQLRepository) GetLatestDataPoints(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error) {
+	stmt := `
+	SELECT
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close
+	FROM
+		(
+			SELECT
+				time,
+				symbol,
+				open,
+				high,
+				low,
+				close
+			FROM
+				ohlc_data
+			WHERE
+				symbol = ?
+				AND time < ?
+			ORDER BY time DESC
+			LIMIT ?
+		) AS latest
+	ORDER BY time ASC
+	`
+	var ohlcPoints []data.OHLCEntity
+
+	err := r.SelectContext(ctx, &ohlcPoints, stmt, symbol, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	return ohlcPoints, nil
+}
+
+// DeleteDataPoint removes the OHLC data points of a symbol at the given time from the ohlc_data table.
+func (r *MySQLRepository) DeleteDataPoint(ctx context.Context, symbol string, t time.Time) error {
+	stmt := `
+	DELETE FROM ohlc_data
+	WHERE
+		symbol = ?
+		AND time = ?
+	`
+	_, err := r.ExecContext(ctx, stmt, symbol, t)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountDataPointsBySourceFile counts the OHLC data points inserted from the given uploaded file.
+func (r *MySQLRepository) CountDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	stmt := `
+	SELECT
+		COUNT(*)
+	FROM
+		ohlc_data
+	WHERE
+		source_file = ?
+	`
+	var count int64
+
+	err := r.GetContext(ctx, &count, stmt, fileName)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteDataPointsBySourceFile removes the OHLC data points inserted from the given uploaded file,
+// as well as the suspicious data points detected in it, within a single transaction.
+// It returns the number of OHLC data points removed.
+func (r *MySQLRepository) DeleteDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	var count int64
+	err := r.WithTransaction(ctx, func(repo Repository) error {
+		tx := repo.(*MySQLRepository)
+		stmt := `
+		DELETE FROM ohlc_data
+		WHERE
+			source_file = ?
+		`
+		result, err := tx.ExecContext(ctx, stmt, fileName)
+		if err != nil {
+			return err
+		}
+		count, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		stmt = `
+		DELETE FROM ohlc_anomaly
+		WHERE
+			source_file = ?
+		`
+		_, err = tx.ExecContext(ctx, stmt, fileName)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // GetProcessingStatus retrieves the processing status of a file from the database
 // It returns a ProcessingStatusEntity struct with the status of the file
 func (r *MySQLRepository) GetProcessingStatus(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
@@ -94,6 +303,19 @@ func (r *MySQLRepository) GetProcessingStatus(ctx context.Context, fileName stri
 		file_name,
 		status,
 		error,
+		uploader,
+		callback_url,
+		tags,
+		bytes_total,
+		bytes_read,
+		rows_parsed,
+		rows_inserted,
+		rows_rejected,
+		symbols,
+		range_start,
+		range_end,
+		started_at,
+		finished_at,
 		created_at,
 		updated_at
 	FROM
@@ -113,7 +335,62 @@ func (r *MySQLRepository) GetProcessingStatus(ctx context.Context, fileName stri
 	return &status, nil
 }
 
+// GetProcessingStatuses retrieves the processing statuses of the files matching the filters of the payload.
+// A file matches the symbol filter if it touched the symbol. The time range applies to the creation time.
+// The result is sorted by the requested field and paginated with page number and page size parameters.
+func (r *MySQLRepository) GetProcessingStatuses(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, error) {
+	stmt := fmt.Sprintf(`
+	SELECT
+		file_name,
+		status,
+		error,
+		uploader,
+		callback_url,
+		tags,
+		bytes_total,
+		bytes_read,
+		rows_parsed,
+		rows_inserted,
+		rows_rejected,
+		symbols,
+		range_start,
+		range_end,
+		started_at,
+		finished_at,
+		created_at,
+		updated_at
+	FROM
+		process_status
+	WHERE
+		(? = '' OR status = ?)
+		AND (? = '' OR uploader = ?)
+		AND (? = '' OR JSON_CONTAINS(symbols, JSON_QUOTE(?)))
+		AND (? IS NULL OR created_at >= FROM_UNIXTIME(?))
+		AND (? IS NULL OR created_at <= FROM_UNIXTIME(?))
+	ORDER BY %s %s, id %s
+	LIMIT ?
+	OFFSET ?
+	`, uploadSortColumns[payload.SortBy], uploadSortOrders[payload.Order], uploadSortOrders[payload.Order])
+	var statuses []data.ProcessingStatusEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	err := r.SelectContext(ctx, &statuses, stmt,
+		payload.Status, payload.Status,
+		payload.Uploader, payload.Uploader,
+		payload.Symbol, payload.Symbol,
+		payload.StartTime, payload.StartTime,
+		payload.EndTime, payload.EndTime,
+		payload.PageSize, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
 // InsertProcessingStatus inserts a ProcessingStatusEntity struct into the process_status table of the MySQL repository.
+// If the file already has a processing status, its status is replaced and its error, progress and finish time cleared,
+// its uploader, callback URL and tags being kept unless new ones are given.
 // It uses NamedExecContext to bind the values in the sql statement.
 // It returns an error if it failed to insert the data into the table.
 func (r *MySQLRepository) InsertProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error {
@@ -121,11 +398,33 @@ func (r *MySQLRepository) InsertProcessingStatus(ctx context.Context, status dat
 	INSERT INTO process_status
 		(
 			file_name,
-			status
+			status,
+			uploader,
+			callback_url,
+			tags
 		) VALUES (
 			:file_name,
-			:status
-		);
+			:status,
+			:uploader,
+			:callback_url,
+			:tags
+		)
+	ON DUPLICATE KEY UPDATE
+		status = VALUES(status),
+		error = NULL,
+		uploader = COALESCE(VALUES(uploader), uploader),
+		callback_url = COALESCE(VALUES(callback_url), callback_url),
+		tags = COALESCE(VALUES(tags), tags),
+		bytes_total = 0,
+		bytes_read = 0,
+		rows_parsed = 0,
+		rows_inserted = 0,
+		rows_rejected = 0,
+		symbols = NULL,
+		range_start = NULL,
+		range_end = NULL,
+		started_at = NULL,
+		finished_at = NULL;
 	`
 	_, err := r.NamedExecContext(ctx, stmt, status)
 	if err != nil {
@@ -135,6 +434,7 @@ func (r *MySQLRepository) InsertProcessingStatus(ctx context.Context, status dat
 }
 
 // UpdateProcessingStatus updates the status of a file in the process_status table of the MySQL repository.
+// A file processed again, whose status is not terminal, has its progress and finish time cleared.
 // It uses NamedExecContext to bind the values in the sql statement.
 // It returns an error if it failed to update the status of the file in the table.
 func (r *MySQLRepository) UpdateProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error {
@@ -142,7 +442,54 @@ func (r *MySQLRepository) UpdateProcessingStatus(ctx context.Context, status dat
 	UPDATE process_status
 	SET
 		status = :status,
-		error = :error
+		error = :error,
+		finished_at = COALESCE(:finished_at, finished_at)
+	WHERE
+		file_name = :file_name
+	`
+	if !status.Status.IsTerminal() {
+		stmt = `
+		UPDATE process_status
+		SET
+			status = :status,
+			error = :error,
+			bytes_total = 0,
+			bytes_read = 0,
+			rows_parsed = 0,
+			rows_inserted = 0,
+			rows_rejected = 0,
+			symbols = NULL,
+			range_start = NULL,
+			range_end = NULL,
+			started_at = NULL,
+			finished_at = NULL
+		WHERE
+			file_name = :file_name
+		`
+	}
+	_, err := r.NamedExecContext(ctx, stmt, status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateProcessingProgress updates the ingestion progress of a file in the process_status table of the MySQL repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to update the progress of the file in the table.
+func (r *MySQLRepository) UpdateProcessingProgress(ctx context.Context, status data.ProcessingStatusEntity) error {
+	stmt := `
+	UPDATE process_status
+	SET
+		bytes_total = :bytes_total,
+		bytes_read = :bytes_read,
+		rows_parsed = :rows_parsed,
+		rows_inserted = :rows_inserted,
+		rows_rejected = :rows_rejected,
+		symbols = :symbols,
+		range_start = :range_start,
+		range_end = :range_end,
+		started_at = :started_at
 	WHERE
 		file_name = :file_name
 	`
@@ -153,7 +500,7 @@ func (r *MySQLRepository) UpdateProcessingStatus(ctx context.Context, status dat
 	return nil
 }
 
-// RemoveStaleProcessingStatus removes stale entries from the process_status table of the MySQL repository.
+// RemoveStaleProcessingStatus removes stale entries from the process_status, webhook_delivery and rejected_message tables of the MySQL repository.
 // It uses NamedExecContext to bind the values in the sql statement.
 // It returns an error if it failed to remove the stale entries from the table.
 func (r *MySQLRepository) RemoveStaleProcessingStatus(ctx context.Context, staleTime time.Time) error {
@@ -166,5 +513,601 @@ func (r *MySQLRepository) RemoveStaleProcessingStatus(ctx context.Context, stale
 	if err != nil {
 		return err
 	}
+
+	stmt = `
+	DELETE FROM webhook_delivery
+	WHERE
+		created_at <= ?
+	`
+	_, err = r.ExecContext(ctx, stmt, staleTime)
+	if err != nil {
+		return err
+	}
+
+	stmt = `
+	DELETE FROM rejected_message
+	WHERE
+		created_at <= ?
+	`
+	_, err = r.ExecContext(ctx, stmt, staleTime)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// InsertWebhookDelivery inserts a WebhookDeliveryEntity struct into the webhook_delivery table of the MySQL repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to insert the data into the table.
+func (r *MySQLRepository) InsertWebhookDelivery(ctx context.Context, delivery data.WebhookDeliveryEntity) error {
+	stmt := `
+	INSERT INTO webhook_delivery
+		(
+			file_name,
+			url,
+			attempt,
+			status_code,
+			error,
+			delivered
+		) VALUES (
+			:file_name,
+			:url,
+			:attempt,
+			:status_code,
+			:error,
+			:delivered
+		)
+	`
+	_, err := r.NamedExecContext(ctx, stmt, delivery)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the webhook delivery attempts of a file, oldest first.
+func (r *MySQLRepository) GetWebhookDeliveries(ctx context.Context, fileName string) ([]data.WebhookDeliveryEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		file_name,
+		url,
+		attempt,
+		status_code,
+		error,
+		delivered,
+		created_at
+	FROM
+		webhook_delivery
+	WHERE
+		file_name = ?
+	ORDER BY id ASC
+	`
+	var deliveries []data.WebhookDeliveryEntity
+
+	err := r.SelectContext(ctx, &deliveries, stmt, fileName)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// InsertRejectedMessage inserts a RejectedMessageEntity struct into the rejected_message table of the MySQL repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to insert the data into the table.
+func (r *MySQLRepository) InsertRejectedMessage(ctx context.Context, message data.RejectedMessageEntity) error {
+	stmt := `
+	INSERT INTO rejected_message
+		(
+			message_id,
+			body,
+			reason
+		) VALUES (
+			:message_id,
+			:body,
+			:reason
+		)
+	`
+	_, err := r.NamedExecContext(ctx, stmt, message)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetRejectedMessages retrieves the SQS messages rejected by the consumer, most recent first.
+func (r *MySQLRepository) GetRejectedMessages(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		message_id,
+		body,
+		reason,
+		created_at
+	FROM
+		rejected_message
+	ORDER BY id DESC
+	LIMIT ?
+	OFFSET ?
+	`
+	var messages []data.RejectedMessageEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	err := r.SelectContext(ctx, &messages, stmt, payload.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// UpsertDataQualityReports inserts data quality reports into the data_quality table of the MySQL repository.
+// A report already stored for the same symbol, day and interval is replaced.
+// It returns an error if it failed to insert the data into the table.
+func (r *MySQLRepository) UpsertDataQualityReports(ctx context.Context, reports []data.DataQualityEntity) error {
+	if len(reports) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO data_quality
+		(
+			symbol,
+			day,
+			interval_seconds,
+			expected_count,
+			present_count,
+			missing_count,
+			duplicate_count,
+			coverage,
+			gaps
+		) VALUES (
+			:symbol,
+			:day,
+			:interval_seconds,
+			:expected_count,
+			:present_count,
+			:missing_count,
+			:duplicate_count,
+			:coverage,
+			:gaps
+		)
+	ON DUPLICATE KEY UPDATE
+		expected_count = VALUES(expected_count),
+		present_count = VALUES(present_count),
+		missing_count = VALUES(missing_count),
+		duplicate_count = VALUES(duplicate_count),
+		coverage = VALUES(coverage),
+		gaps = VALUES(gaps);
+	`
+	_, err := r.NamedExecContext(ctx, stmt, reports)
+	if err != nil {
+		return err
+	}
 	return nil
 }
+
+// GetDataQualityReports retrieves the stored data quality reports of a symbol for the days between the start and end times.
+func (r *MySQLRepository) GetDataQualityReports(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.DataQualityEntity, error) {
+	stmt := `
+	SELECT
+		symbol,
+		day,
+		interval_seconds,
+		expected_count,
+		present_count,
+		missing_count,
+		duplicate_count,
+		coverage,
+		gaps,
+		created_at,
+		updated_at
+	FROM
+		data_quality
+	WHERE
+		symbol = ?
+		AND day >= DATE(?)
+		AND day <= DATE(?)
+	ORDER BY day ASC, interval_seconds ASC
+	`
+	var reports []data.DataQualityEntity
+
+	err := r.SelectContext(ctx, &reports, stmt, symbol, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// InsertAnomalies inserts a slice of data.AnomalyEntity rows into the ohlc_anomaly table of the MySQL repository.
+// It returns an error if it failed to insert the data into the table.
+func (r *MySQLRepository) InsertAnomalies(ctx context.Context, anomalies []data.AnomalyEntity) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO ohlc_anomaly
+		(
+			time,
+			symbol,
+			open,
+			high,
+			low,
+			close,
+			reason,
+			status,
+			source_file
+		) VALUES (
+			:time,
+			:symbol,
+			:open,
+			:high,
+			:low,
+			:close,
+			:reason,
+			:status,
+			:source_file
+		);
+	`
+	_, err := r.NamedExecContext(ctx, stmt, anomalies)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetAnomalies retrieves the suspicious data points awaiting review, optionally filtered by symbol and status.
+// The result is paginated with page number and page size parameters.
+func (r *MySQLRepository) GetAnomalies(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close,
+		reason,
+		status,
+		source_file,
+		created_at
+	FROM
+		ohlc_anomaly
+	WHERE
+		(? = '' OR symbol = ?)
+		AND (? = '' OR status = ?)
+	ORDER BY id ASC
+	LIMIT ?
+	OFFSET ?
+	`
+	var anomalies []data.AnomalyEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	err := r.SelectContext(ctx, &anomalies, stmt, payload.Symbol, payload.Symbol, payload.Status, payload.Status, payload.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return anomalies, nil
+}
+
+// GetAnomaly retrieves a suspicious data point by its ID.
+func (r *MySQLRepository) GetAnomaly(ctx context.Context, id int64) (*data.AnomalyEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close,
+		reason,
+		status,
+		source_file,
+		created_at
+	FROM
+		ohlc_anomaly
+	WHERE
+		id = ?
+	`
+	var anomaly data.AnomalyEntity
+
+	err := r.GetContext(ctx, &anomaly, stmt, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, E.NewErrEntityNotFound("anomaly", strconv.FormatInt(id, 10))
+		}
+		return nil, err
+	}
+	return &anomaly, nil
+}
+
+// DeleteAnomaly removes a suspicious data point from the ohlc_anomaly table.
+func (r *MySQLRepository) DeleteAnomaly(ctx context.Context, id int64) error {
+	stmt := `
+	DELETE FROM ohlc_anomaly
+	WHERE
+		id = ?
+	`
+	_, err := r.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// maxPartition is the partition of the ohlc_data table holding the data points past the monthly partitions.
+const maxPartition = "p_max"
+
+// partition is a monthly partition of the ohlc_data table, holding the data points before its upper bound.
+// The bound of the last partition is MAXVALUE.
+type partition struct {
+	Name  string `db:"name"`
+	Bound string `db:"bound"`
+}
+
+// monthStart returns the start of the UTC month of a time.
+func monthStart(t time.Time) time.Time {
+	year, month, _ := t.UTC().Date()
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// partitionName returns the name of the partition of the ohlc_data table holding the data points of a month.
+func partitionName(month time.Time) string {
+	return month.Format("p200601")
+}
+
+// getPartitions retrieves the partitions of the ohlc_data table in order. It is empty if the table is not partitioned.
+func (r *MySQLRepository) getPartitions(ctx context.Context) ([]partition, error) {
+	stmt := `
+	SELECT
+		PARTITION_NAME AS name,
+		PARTITION_DESCRIPTION AS bound
+	FROM
+		information_schema.PARTITIONS
+	WHERE
+		TABLE_SCHEMA = DATABASE()
+		AND TABLE_NAME = 'ohlc_data'
+		AND PARTITION_NAME IS NOT NULL
+	ORDER BY
+		PARTITION_ORDINAL_POSITION
+	`
+	partitions := []partition{}
+
+	err := r.SelectContext(ctx, &partitions, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return partitions, nil
+}
+
+// CreatePartitions creates the monthly partitions of the ohlc_data table up to the month of `until`.
+// The first monthly partition created is the one of the oldest data point, or of the current month if there is none,
+// and holds every older data point as well.
+func (r *MySQLRepository) CreatePartitions(ctx context.Context, until time.Time) error {
+	partitions, err := r.getPartitions(ctx)
+	if err != nil {
+		return err
+	}
+	if len(partitions) == 0 {
+		return nil
+	}
+
+	var next time.Time
+	for _, p := range partitions {
+		bound, err := strconv.ParseInt(p.Bound, 10, 64)
+		if err == nil {
+			next = time.Unix(bound, 0).UTC()
+		}
+	}
+	if next.IsZero() {
+		var oldest sql.NullTime
+		err = r.GetContext(ctx, &oldest, `SELECT MIN(time) FROM ohlc_data`)
+		if err != nil {
+			return err
+		}
+		next = monthStart(time.Now())
+		if oldest.Valid && oldest.Time.Before(next) {
+			next = monthStart(oldest.Time)
+		}
+	}
+
+	definitions := []string{}
+	for month := next; !month.After(monthStart(until)); month = month.AddDate(0, 1, 0) {
+		definitions = append(definitions, fmt.Sprintf("PARTITION %s VALUES LESS THAN (%d)", partitionName(month), month.AddDate(0, 1, 0).Unix()))
+	}
+	if len(definitions) == 0 {
+		return nil
+	}
+	definitions = append(definitions, fmt.Sprintf("PARTITION %s VALUES LESS THAN MAXVALUE", maxPartition))
+
+	stmt := fmt.Sprintf(`
+	ALTER TABLE ohlc_data
+	REORGANIZE PARTITION %s INTO (%s)
+	`, maxPartition, strings.Join(definitions, ", "))
+	_, err = r.ExecContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DropPartitions drops the monthly partitions of the ohlc_data table of the months before the month of `before`,
+// removing their data points.
+func (r *MySQLRepository) DropPartitions(ctx context.Context, before time.Time) error {
+	partitions, err := r.getPartitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	expired := []string{}
+	for _, p := range partitions {
+		bound, err := strconv.ParseInt(p.Bound, 10, 64)
+		if err == nil && bound <= monthStart(before).Unix() {
+			expired = append(expired, p.Name)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	stmt := fmt.Sprintf(`
+	ALTER TABLE ohlc_data
+	DROP PARTITION %s
+	`, strings.Join(expired, ", "))
+	_, err = r.ExecContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteDataPointsBefore removes the OHLC data points of a symbol strictly before the given time.
+// It returns the number of data points removed.
+func (r *MySQLRepository) DeleteDataPointsBefore(ctx context.Context, symbol string, before time.Time) (int64, error) {
+	stmt := `
+	DELETE FROM ohlc_data
+	WHERE
+		symbol = ?
+		AND time < ?
+	`
+	result, err := r.ExecContext(ctx, stmt, symbol, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// UpsertRollups inserts downsampled candles into the ohlc_rollup table.
+// A candle already stored for the same symbol, interval and time is replaced.
+func (r *MySQLRepository) UpsertRollups(ctx context.Context, rows []data.RollupEntity) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO ohlc_rollup
+		(
+			symbol,
+			interval_seconds,
+			time,
+			open,
+			high,
+			low,
+			close
+		) VALUES (
+			:symbol,
+			:interval_seconds,
+			:time,
+			:open,
+			:high,
+			:low,
+			:close
+		)
+	ON DUPLICATE KEY UPDATE
+		open = VALUES(open),
+		high = VALUES(high),
+		low = VALUES(low),
+		close = VALUES(close);
+	`
+	_, err := r.NamedExecContext(ctx, stmt, rows)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetRollups retrieves the downsampled candles of a symbol and interval between the start and end times (inclusive),
+// ordered by time.
+func (r *MySQLRepository) GetRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) ([]data.RollupEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		symbol,
+		interval_seconds,
+		time,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_rollup
+	WHERE
+		symbol = ?
+		AND interval_seconds = ?
+		AND time >= ?
+		AND time <= ?
+	ORDER BY time ASC
+	`
+	rollups := []data.RollupEntity{}
+
+	err := r.SelectContext(ctx, &rollups, stmt, symbol, intervalSeconds, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return rollups, nil
+}
+
+// GetOldestRollups retrieves the oldest downsampled candles of a symbol and interval.
+// At most `limit` rollups are returned, ordered by time.
+func (r *MySQLRepository) GetOldestRollups(ctx context.Context, symbol string, intervalSeconds int64, limit int) ([]data.RollupEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		symbol,
+		interval_seconds,
+		time,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_rollup
+	WHERE
+		symbol = ?
+		AND interval_seconds = ?
+	ORDER BY time ASC
+	LIMIT ?
+	`
+	rollups := []data.RollupEntity{}
+
+	err := r.SelectContext(ctx, &rollups, stmt, symbol, intervalSeconds, limit)
+	if err != nil {
+		return nil, err
+	}
+	return rollups, nil
+}
+
+// DeleteRollups removes the downsampled candles of a symbol and interval between the start and end times (inclusive).
+// It returns the number of candles removed.
+func (r *MySQLRepository) DeleteRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) (int64, error) {
+	stmt := `
+	DELETE FROM ohlc_rollup
+	WHERE
+		symbol = ?
+		AND interval_seconds = ?
+		AND time >= ?
+		AND time <= ?
+	`
+	result, err := r.ExecContext(ctx, stmt, symbol, intervalSeconds, startTime, endTime)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetRollupSymbols retrieves the distinct symbols stored in the ohlc_rollup table.
+func (r *MySQLRepository) GetRollupSymbols(ctx context.Context) ([]string, error) {
+	stmt := `
+	SELECT DISTINCT
+		symbol
+	FROM
+		ohlc_rollup
+	ORDER BY symbol ASC
+	`
+	symbols := []string{}
+
+	err := r.SelectContext(ctx, &symbols, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return symbols, nil
+}
@@ -0,0 +1,1037 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	E "github.com/teezzan/candles/internal/errors"
+)
+
+var _ Repository = (*PostgresRepository)(nil)
+
+// PostgresRepository implements a PostgreSQL repository.
+// When the TimescaleDB extension is installed, SetupTimescale turns the ohlc_data table into a hypertable.
+type PostgresRepository struct {
+	executor
+	db *sqlx.DB
+}
+
+// NewPostgresRepository initializes a new PostgreSQL repository.
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{
+		executor: db,
+		db:       db,
+	}
+}
+
+// SetupTimescale turns the ohlc_data table into a hypertable partitioned by time, if the TimescaleDB extension is
+// installed in the database. The resampled candles are served from the rollups, like with the other databases.
+// It is idempotent and returns whether TimescaleDB is available.
+func (r *PostgresRepository) SetupTimescale(ctx context.Context) (bool, error) {
+	available, err := r.timescaleAvailable(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !available {
+		return false, nil
+	}
+
+	stmt := `
+	SELECT create_hypertable('ohlc_data', 'time', if_not_exists => TRUE, migrate_data => TRUE)
+	`
+	_, err = r.ExecContext(ctx, stmt)
+	if err != nil {
+		return true, fmt.Errorf("failed to create the ohlc_data hypertable: %w", err)
+	}
+	return true, nil
+}
+
+// timescaleAvailable returns whether the TimescaleDB extension is installed in the database.
+func (r *PostgresRepository) timescaleAvailable(ctx context.Context) (bool, error) {
+	stmt := `
+	SELECT EXISTS (
+		SELECT 1 FROM pg_extension WHERE extname = 'timescaledb'
+	)
+	`
+	var available bool
+
+	err := r.GetContext(ctx, &available, stmt)
+	if err != nil {
+		return false, err
+	}
+	return available, nil
+}
+
+// WithTransaction runs fn with a repository bound to a new transaction. The transaction is committed if fn succeeds
+// and rolled back otherwise. If the repository is already bound to a transaction, fn joins it.
+func (r *PostgresRepository) WithTransaction(ctx context.Context, fn func(Repository) error) error {
+	if r.db == nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = fn(&PostgresRepository{executor: tx})
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("rollback failed: %v: %w", rbErr, err)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// InsertDataPoints inserts a slice of data.OHLCEntity rows into the ohlc_data table of the PostgreSQL repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to insert the data into the table.
+func (r *PostgresRepository) InsertDataPoints(ctx context.Context, rows []data.OHLCEntity) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO ohlc_data
+		(
+			time,
+			symbol,
+			open,
+			high,
+			low,
+			close,
+			source_file
+		) VALUES (
+			:time,
+			:symbol,
+			:open,
+			:high,
+			:low,
+			:close,
+			:source_file
+		);
+	`
+	_, err := r.NamedExecContext(ctx, stmt, rows)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDataPoints retrieves OHLC data points from the database for a given symbol and time range
+// It returns a slice of OHLCEntity structs with data for a given symbol between the start and end times
+// The result is paginated with page number and page size parameters
+func (r *PostgresRepository) GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error) {
+	stmt := `
+	SELECT
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_data
+	WHERE
+		symbol = $1
+		AND time >= $2
+		AND time <= $3
+	ORDER BY time ASC
+	LIMIT $4
+	OFFSET $5
+	`
+	var ohlcPoints []data.OHLCEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	startTime := time.Unix(payload.StartTime, 0)
+	endTime := time.Unix(payload.EndTime.Int64, 0)
+
+	err := r.SelectContext(ctx, &ohlcPoints, stmt, payload.Symbol, startTime, endTime, payload.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return ohlcPoints, nil
+}
+
+// GetDataPointsInRange retrieves every OHLC data point of a symbol between the start and end times (inclusive).
+// Unlike GetDataPoints, the result is not paginated and is ordered by time.
+func (r *PostgresRepository) GetDataPointsInRange(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.OHLCEntity, error) {
+	stmt := `
+	SELECT
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_data
+	WHERE
+		symbol = $1
+		AND time >= $2
+		AND time <= $3
+	ORDER BY time ASC
+	`
+	var ohlcPoints []data.OHLCEntity
+
+	err := r.SelectContext(ctx, &ohlcPoints, stmt, symbol, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return ohlcPoints, nil
+}
+
+// GetSymbols retrieves the distinct symbols stored in the ohlc_data table.
+func (r *PostgresRepository) GetSymbols(ctx context.Context) ([]string, error) {
+	stmt := `
+	SELECT DISTINCT
+		symbol
+	FROM
+		ohlc_data
+	ORDER BY symbol ASC
+	`
+	var symbols []string
+
+	err := r.SelectContext(ctx, &symbols, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return symbols, nil
+}
+
+// GetLatestDataPoints retrieves the latest OHLC data points of a symbol strictly before the given time.
+// At most `limit` data points are returned, ordered by time.
+func (r *PostgresRepository) GetLatestDataPoints(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error) {
+	stmt := `
+	SELECT
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close
+	FROM
+		(
+			SELECT
+				time,
+				symbol,
+				open,
+				high,
+				low,
+				close
+			FROM
+				ohlc_data
+			WHERE
+				symbol = $1
+				AND time < $2
+			ORDER BY time DESC
+			LIMIT $3
+		) AS latest
+	ORDER BY time ASC
+	`
+	var ohlcPoints []data.OHLCEntity
+
+	err := r.SelectContext(ctx, &ohlcPoints, stmt, symbol, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	return ohlcPoints, nil
+}
+
+// DeleteDataPoint removes the OHLC data points of a symbol at the given time from the ohlc_data table.
+func (r *PostgresRepository) DeleteDataPoint(ctx context.Context, symbol string, t time.Time) error {
+	stmt := `
+	DELETE FROM ohlc_data
+	WHERE
+		symbol = $1
+		AND time = $2
+	`
+	_, err := r.ExecContext(ctx, stmt, symbol, t)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountDataPointsBySourceFile counts the OHLC data points inserted from the given uploaded file.
+func (r *PostgresRepository) CountDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	stmt := `
+	SELECT
+		COUNT(*)
+	FROM
+		ohlc_data
+	WHERE
+		source_file = $1
+	`
+	var count int64
+
+	err := r.GetContext(ctx, &count, stmt, fileName)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteDataPointsBySourceFile removes the OHLC data points inserted from the given uploaded file,
+// as well as the suspicious data points detected in it, within a single transaction.
+// It returns the number of OHLC data points removed.
+func (r *PostgresRepository) DeleteDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	var count int64
+	err := r.WithTransaction(ctx, func(repo Repository) error {
+		tx := repo.(*PostgresRepository)
+		stmt := `
+		DELETE FROM ohlc_data
+		WHERE
+			source_file = $1
+		`
+		result, err := tx.ExecContext(ctx, stmt, fileName)
+		if err != nil {
+			return err
+		}
+		count, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		stmt = `
+		DELETE FROM ohlc_anomaly
+		WHERE
+			source_file = $1
+		`
+		_, err = tx.ExecContext(ctx, stmt, fileName)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetProcessingStatus retrieves the processing status of a file from the database
+// It returns a ProcessingStatusEntity struct with the status of the file
+func (r *PostgresRepository) GetProcessingStatus(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+	stmt := `
+	SELECT
+		file_name,
+		status,
+		error,
+		uploader,
+		callback_url,
+		tags,
+		bytes_total,
+		bytes_read,
+		rows_parsed,
+		rows_inserted,
+		rows_rejected,
+		symbols,
+		range_start,
+		range_end,
+		started_at,
+		finished_at,
+		created_at,
+		updated_at
+	FROM
+		process_status
+	WHERE
+		file_name = $1
+	`
+	var status data.ProcessingStatusEntity
+
+	err := r.GetContext(ctx, &status, stmt, fileName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, E.NewErrEntityNotFound("file", fileName)
+		}
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetProcessingStatuses retrieves the processing statuses of the files matching the filters of the payload.
+// A file matches the symbol filter if it touched the symbol. The time range applies to the creation time.
+// The result is sorted by the requested field and paginated with page number and page size parameters.
+func (r *PostgresRepository) GetProcessingStatuses(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, error) {
+	stmt := fmt.Sprintf(`
+	SELECT
+		file_name,
+		status,
+		error,
+		uploader,
+		callback_url,
+		tags,
+		bytes_total,
+		bytes_read,
+		rows_parsed,
+		rows_inserted,
+		rows_rejected,
+		symbols,
+		range_start,
+		range_end,
+		started_at,
+		finished_at,
+		created_at,
+		updated_at
+	FROM
+		process_status
+	WHERE
+		($1 = '' OR status = $1)
+		AND ($2 = '' OR uploader = $2)
+		AND ($3 = '' OR symbols @> jsonb_build_array(CAST($3 AS text)))
+		AND (CAST($4 AS bigint) IS NULL OR created_at >= to_timestamp($4))
+		AND (CAST($5 AS bigint) IS NULL OR created_at <= to_timestamp($5))
+	ORDER BY %s %s, id %s
+	LIMIT $6
+	OFFSET $7
+	`, uploadSortColumns[payload.SortBy], uploadSortOrders[payload.Order], uploadSortOrders[payload.Order])
+	var statuses []data.ProcessingStatusEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	err := r.SelectContext(ctx, &statuses, stmt,
+		payload.Status,
+		payload.Uploader,
+		payload.Symbol,
+		payload.StartTime,
+		payload.EndTime,
+		payload.PageSize, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// InsertProcessingStatus inserts a ProcessingStatusEntity struct into the process_status table of the PostgreSQL repository.
+// If the file already has a processing status, its status is replaced and its error, progress and finish time cleared,
+// its uploader, callback URL and tags being kept unless new ones are given.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to insert the data into the table.
+func (r *PostgresRepository) InsertProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error {
+	stmt := `
+	INSERT INTO process_status
+		(
+			file_name,
+			status,
+			uploader,
+			callback_url,
+			tags
+		) VALUES (
+			:file_name,
+			:status,
+			:uploader,
+			:callback_url,
+			:tags
+		)
+	ON CONFLICT (file_name) DO UPDATE SET
+		status = EXCLUDED.status,
+		error = NULL,
+		uploader = COALESCE(EXCLUDED.uploader, process_status.uploader),
+		callback_url = COALESCE(EXCLUDED.callback_url, process_status.callback_url),
+		tags = COALESCE(EXCLUDED.tags, process_status.tags),
+		bytes_total = 0,
+		bytes_read = 0,
+		rows_parsed = 0,
+		rows_inserted = 0,
+		rows_rejected = 0,
+		symbols = NULL,
+		range_start = NULL,
+		range_end = NULL,
+		started_at = NULL,
+		finished_at = NULL;
+	`
+	_, err := r.NamedExecContext(ctx, stmt, status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateProcessingStatus updates the status of a file in the process_status table of the PostgreSQL repository.
+// A file processed again, whose status is not terminal, has its progress and finish time cleared.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to update the status of the file in the table.
+func (r *PostgresRepository) UpdateProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error {
+	stmt := `
+	UPDATE process_status
+	SET
+		status = :status,
+		error = :error,
+		finished_at = COALESCE(:finished_at, finished_at)
+	WHERE
+		file_name = :file_name
+	`
+	if !status.Status.IsTerminal() {
+		stmt = `
+		UPDATE process_status
+		SET
+			status = :status,
+			error = :error,
+			bytes_total = 0,
+			bytes_read = 0,
+			rows_parsed = 0,
+			rows_inserted = 0,
+			rows_rejected = 0,
+			symbols = NULL,
+			range_start = NULL,
+			range_end = NULL,
+			started_at = NULL,
+			finished_at = NULL
+		WHERE
+			file_name = :file_name
+		`
+	}
+	_, err := r.NamedExecContext(ctx, stmt, status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateProcessingProgress updates the ingestion progress of a file in the process_status table of the PostgreSQL repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to update the progress of the file in the table.
+func (r *PostgresRepository) UpdateProcessingProgress(ctx context.Context, status data.ProcessingStatusEntity) error {
+	stmt := `
+	UPDATE process_status
+	SET
+		bytes_total = :bytes_total,
+		bytes_read = :bytes_read,
+		rows_parsed = :rows_parsed,
+		rows_inserted = :rows_inserted,
+		rows_rejected = :rows_rejected,
+		symbols = :symbols,
+		range_start = :range_start,
+		range_end = :range_end,
+		started_at = :started_at
+	WHERE
+		file_name = :file_name
+	`
+	_, err := r.NamedExecContext(ctx, stmt, status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RemoveStaleProcessingStatus removes stale entries from the process_status, webhook_delivery and rejected_message tables of the PostgreSQL repository.
+// It returns an error if it failed to remove the stale entries from the table.
+func (r *PostgresRepository) RemoveStaleProcessingStatus(ctx context.Context, staleTime time.Time) error {
+	stmt := `
+	DELETE FROM process_status
+	WHERE
+		updated_at <= $1
+	`
+	_, err := r.ExecContext(ctx, stmt, staleTime)
+	if err != nil {
+		return err
+	}
+
+	stmt = `
+	DELETE FROM webhook_delivery
+	WHERE
+		created_at <= $1
+	`
+	_, err = r.ExecContext(ctx, stmt, staleTime)
+	if err != nil {
+		return err
+	}
+
+	stmt = `
+	DELETE FROM rejected_message
+	WHERE
+		created_at <= $1
+	`
+	_, err = r.ExecContext(ctx, stmt, staleTime)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// InsertWebhookDelivery inserts a WebhookDeliveryEntity struct into the webhook_delivery table of the PostgreSQL repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to insert the data into the table.
+func (r *PostgresRepository) InsertWebhookDelivery(ctx context.Context, delivery data.WebhookDeliveryEntity) error {
+	stmt := `
+	INSERT INTO webhook_delivery
+		(
+			file_name,
+			url,
+			attempt,
+			status_code,
+			error,
+			delivered
+		) VALUES (
+			:file_name,
+			:url,
+			:attempt,
+			:status_code,
+			:error,
+			:delivered
+		)
+	`
+	_, err := r.NamedExecContext(ctx, stmt, delivery)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the webhook delivery attempts of a file, oldest first.
+func (r *PostgresRepository) GetWebhookDeliveries(ctx context.Context, fileName string) ([]data.WebhookDeliveryEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		file_name,
+		url,
+		attempt,
+		status_code,
+		error,
+		delivered,
+		created_at
+	FROM
+		webhook_delivery
+	WHERE
+		file_name = $1
+	ORDER BY id ASC
+	`
+	var deliveries []data.WebhookDeliveryEntity
+
+	err := r.SelectContext(ctx, &deliveries, stmt, fileName)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// InsertRejectedMessage inserts a RejectedMessageEntity struct into the rejected_message table of the PostgreSQL repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to insert the data into the table.
+func (r *PostgresRepository) InsertRejectedMessage(ctx context.Context, message data.RejectedMessageEntity) error {
+	stmt := `
+	INSERT INTO rejected_message
+		(
+			message_id,
+			body,
+			reason
+		) VALUES (
+			:message_id,
+			:body,
+			:reason
+		)
+	`
+	_, err := r.NamedExecContext(ctx, stmt, message)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetRejectedMessages retrieves the SQS messages rejected by the consumer, most recent first.
+func (r *PostgresRepository) GetRejectedMessages(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		message_id,
+		body,
+		reason,
+		created_at
+	FROM
+		rejected_message
+	ORDER BY id DESC
+	LIMIT $1
+	OFFSET $2
+	`
+	var messages []data.RejectedMessageEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	err := r.SelectContext(ctx, &messages, stmt, payload.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// UpsertDataQualityReports inserts data quality reports into the data_quality table of the PostgreSQL repository.
+// A report already stored for the same symbol, day and interval is replaced.
+// It returns an error if it failed to insert the data into the table.
+func (r *PostgresRepository) UpsertDataQualityReports(ctx context.Context, reports []data.DataQualityEntity) error {
+	if len(reports) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO data_quality
+		(
+			symbol,
+			day,
+			interval_seconds,
+			expected_count,
+			present_count,
+			missing_count,
+			duplicate_count,
+			coverage,
+			gaps
+		) VALUES (
+			:symbol,
+			:day,
+			:interval_seconds,
+			:expected_count,
+			:present_count,
+			:missing_count,
+			:duplicate_count,
+			:coverage,
+			:gaps
+		)
+	ON CONFLICT (symbol, day, interval_seconds) DO UPDATE SET
+		expected_count = EXCLUDED.expected_count,
+		present_count = EXCLUDED.present_count,
+		missing_count = EXCLUDED.missing_count,
+		duplicate_count = EXCLUDED.duplicate_count,
+		coverage = EXCLUDED.coverage,
+		gaps = EXCLUDED.gaps;
+	`
+	_, err := r.NamedExecContext(ctx, stmt, reports)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDataQualityReports retrieves the stored data quality reports of a symbol for the days between the start and end times.
+func (r *PostgresRepository) GetDataQualityReports(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.DataQualityEntity, error) {
+	stmt := `
+	SELECT
+		symbol,
+		day,
+		interval_seconds,
+		expected_count,
+		present_count,
+		missing_count,
+		duplicate_count,
+		coverage,
+		gaps,
+		created_at,
+		updated_at
+	FROM
+		data_quality
+	WHERE
+		symbol = $1
+		AND day >= CAST($2 AS date)
+		AND day <= CAST($3 AS date)
+	ORDER BY day ASC, interval_seconds ASC
+	`
+	var reports []data.DataQualityEntity
+
+	err := r.SelectContext(ctx, &reports, stmt, symbol, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// InsertAnomalies inserts a slice of data.AnomalyEntity rows into the ohlc_anomaly table of the PostgreSQL repository.
+// It returns an error if it failed to insert the data into the table.
+func (r *PostgresRepository) InsertAnomalies(ctx context.Context, anomalies []data.AnomalyEntity) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO ohlc_anomaly
+		(
+			time,
+			symbol,
+			open,
+			high,
+			low,
+			close,
+			reason,
+			status,
+			source_file
+		) VALUES (
+			:time,
+			:symbol,
+			:open,
+			:high,
+			:low,
+			:close,
+			:reason,
+			:status,
+			:source_file
+		);
+	`
+	_, err := r.NamedExecContext(ctx, stmt, anomalies)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetAnomalies retrieves the suspicious data points awaiting review, optionally filtered by symbol and status.
+// The result is paginated with page number and page size parameters.
+func (r *PostgresRepository) GetAnomalies(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close,
+		reason,
+		status,
+		source_file,
+		created_at
+	FROM
+		ohlc_anomaly
+	WHERE
+		($1 = '' OR symbol = $1)
+		AND ($2 = '' OR status = $2)
+	ORDER BY id ASC
+	LIMIT $3
+	OFFSET $4
+	`
+	var anomalies []data.AnomalyEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	err := r.SelectContext(ctx, &anomalies, stmt, payload.Symbol, payload.Status, payload.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return anomalies, nil
+}
+
+// GetAnomaly retrieves a suspicious data point by its ID.
+func (r *PostgresRepository) GetAnomaly(ctx context.Context, id int64) (*data.AnomalyEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close,
+		reason,
+		status,
+		source_file,
+		created_at
+	FROM
+		ohlc_anomaly
+	WHERE
+		id = $1
+	`
+	var anomaly data.AnomalyEntity
+
+	err := r.GetContext(ctx, &anomaly, stmt, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, E.NewErrEntityNotFound("anomaly", strconv.FormatInt(id, 10))
+		}
+		return nil, err
+	}
+	return &anomaly, nil
+}
+
+// DeleteAnomaly removes a suspicious data point from the ohlc_anomaly table.
+func (r *PostgresRepository) DeleteAnomaly(ctx context.Context, id int64) error {
+	stmt := `
+	DELETE FROM ohlc_anomaly
+	WHERE
+		id = $1
+	`
+	_, err := r.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreatePartitions is a no-op: the chunks of the ohlc_data hypertable are created by TimescaleDB as data points are
+// inserted, and the table is not partitioned without it.
+func (r *PostgresRepository) CreatePartitions(ctx context.Context, until time.Time) error {
+	return nil
+}
+
+// DropPartitions removes the data points of the ohlc_data table of the months before the month of `before`.
+// With TimescaleDB, the chunks of the hypertable holding only such data points are dropped first.
+func (r *PostgresRepository) DropPartitions(ctx context.Context, before time.Time) error {
+	available, err := r.timescaleAvailable(ctx)
+	if err != nil {
+		return err
+	}
+	if available {
+		stmt := `
+		SELECT drop_chunks('ohlc_data', older_than => CAST($1 AS timestamptz))
+		`
+		_, err = r.ExecContext(ctx, stmt, monthStart(before))
+		if err != nil {
+			return err
+		}
+	}
+
+	stmt := `
+	DELETE FROM ohlc_data
+	WHERE
+		time < $1
+	`
+	_, err = r.ExecContext(ctx, stmt, monthStart(before))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteDataPointsBefore removes the OHLC data points of a symbol strictly before the given time.
+// It returns the number of data points removed.
+func (r *PostgresRepository) DeleteDataPointsBefore(ctx context.Context, symbol string, before time.Time) (int64, error) {
+	stmt := `
+	DELETE FROM ohlc_data
+	WHERE
+		symbol = $1
+		AND time < $2
+	`
+	result, err := r.ExecContext(ctx, stmt, symbol, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// UpsertRollups inserts downsampled candles into the ohlc_rollup table.
+// A candle already stored for the same symbol, interval and time is replaced.
+func (r *PostgresRepository) UpsertRollups(ctx context.Context, rows []data.RollupEntity) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO ohlc_rollup
+		(
+			symbol,
+			interval_seconds,
+			time,
+			open,
+			high,
+			low,
+			close
+		) VALUES (
+			:symbol,
+			:interval_seconds,
+			:time,
+			:open,
+			:high,
+			:low,
+			:close
+		)
+	ON CONFLICT (symbol, interval_seconds, time) DO UPDATE SET
+		open = EXCLUDED.open,
+		high = EXCLUDED.high,
+		low = EXCLUDED.low,
+		close = EXCLUDED.close;
+	`
+	_, err := r.NamedExecContext(ctx, stmt, rows)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetRollups retrieves the downsampled candles of a symbol and interval between the start and end times (inclusive),
+// ordered by time.
+func (r *PostgresRepository) GetRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) ([]data.RollupEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		symbol,
+		interval_seconds,
+		time,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_rollup
+	WHERE
+		symbol = $1
+		AND interval_seconds = $2
+		AND time >= $3
+		AND time <= $4
+	ORDER BY time ASC
+	`
+	rollups := []data.RollupEntity{}
+
+	err := r.SelectContext(ctx, &rollups, stmt, symbol, intervalSeconds, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return rollups, nil
+}
+
+// GetOldestRollups retrieves the oldest downsampled candles of a symbol and interval.
+// At most `limit` rollups are returned, ordered by time.
+func (r *PostgresRepository) GetOldestRollups(ctx context.Context, symbol string, intervalSeconds int64, limit int) ([]data.RollupEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		symbol,
+		interval_seconds,
+		time,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_rollup
+	WHERE
+		symbol = $1
+		AND interval_seconds = $2
+	ORDER BY time ASC
+	LIMIT $3
+	`
+	rollups := []data.RollupEntity{}
+
+	err := r.SelectContext(ctx, &rollups, stmt, symbol, intervalSeconds, limit)
+	if err != nil {
+		return nil, err
+	}
+	return rollups, nil
+}
+
+// DeleteRollups removes the downsampled candles of a symbol and interval between the start and end times (inclusive).
+// It returns the number of candles removed.
+func (r *PostgresRepository) DeleteRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) (int64, error) {
+	stmt := `
+	DELETE FROM ohlc_rollup
+	WHERE
+		symbol = $1
+		AND interval_seconds = $2
+		AND time >= $3
+		AND time <= $4
+	`
+	result, err := r.ExecContext(ctx, stmt, symbol, intervalSeconds, startTime, endTime)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetRollupSymbols retrieves the distinct symbols stored in the ohlc_rollup table.
+func (r *PostgresRepository) GetRollupSymbols(ctx context.Context) ([]string, error) {
+	stmt := `
+	SELECT DISTINCT
+		symbol
+	FROM
+		ohlc_rollup
+	ORDER BY symbol ASC
+	`
+	symbols := []string{}
+
+	err := r.SelectContext(ctx, &symbols, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return symbols, nil
+}
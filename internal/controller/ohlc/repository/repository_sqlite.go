@@ -0,0 +1,1084 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	sqlite "github.com/glebarez/go-sqlite"
+	"github.com/jmoiron/sqlx"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	E "github.com/teezzan/candles/internal/errors"
+)
+
+var _ Repository = (*SQLiteRepository)(nil)
+
+const (
+	// sqliteBusy is the result code of a SQLite query rejected because another connection locks the database.
+	sqliteBusy = 5
+	// sqliteRetryDelay is the delay before retrying a SQLite query rejected because the database is locked.
+	sqliteRetryDelay = 10 * time.Millisecond
+)
+
+// sqliteDB is the executor of a SQLite repository outside of transactions.
+// SQLite allows a single writer at a time, hence a query rejected because another connection locks the database
+// is retried until its context is done.
+type sqliteDB struct {
+	*sqlx.DB
+}
+
+func (db sqliteDB) ExecContext(ctx context.Context, query string, args ...interface{}) (result sql.Result, err error) {
+	err = retryLocked(ctx, func() error {
+		result, err = db.DB.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (db sqliteDB) NamedExecContext(ctx context.Context, query string, arg interface{}) (result sql.Result, err error) {
+	err = retryLocked(ctx, func() error {
+		result, err = db.DB.NamedExecContext(ctx, query, arg)
+		return err
+	})
+	return result, err
+}
+
+func (db sqliteDB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return retryLocked(ctx, func() error {
+		return db.DB.SelectContext(ctx, dest, query, args...)
+	})
+}
+
+func (db sqliteDB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return retryLocked(ctx, func() error {
+		return db.DB.GetContext(ctx, dest, query, args...)
+	})
+}
+
+// retryLocked calls fn until it is not rejected because the database is locked, or ctx is done.
+func retryLocked(ctx context.Context, fn func() error) error {
+	for {
+		err := fn()
+		var sqliteErr *sqlite.Error
+		if !errors.As(err, &sqliteErr) || sqliteErr.Code()&0xff != sqliteBusy {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(sqliteRetryDelay):
+		}
+	}
+}
+
+// SQLiteRepository implements a SQLite repository, meant for single-node deployments and tests.
+// Since SQLite compares times as text, the times are stored in UTC.
+type SQLiteRepository struct {
+	executor
+	db *sqlx.DB
+}
+
+// NewSQLiteRepository initializes a new SQLite repository.
+func NewSQLiteRepository(db *sqlx.DB) *SQLiteRepository {
+	return &SQLiteRepository{
+		executor: sqliteDB{db},
+		db:       db,
+	}
+}
+
+// WithTransaction runs fn with a repository bound to a new transaction. The transaction is committed if fn succeeds
+// and rolled back otherwise. If the repository is already bound to a transaction, fn joins it.
+// The transaction waits for the database to be unlocked by the other transactions before starting.
+func (r *SQLiteRepository) WithTransaction(ctx context.Context, fn func(Repository) error) error {
+	if r.db == nil {
+		return fn(r)
+	}
+
+	var tx *sqlx.Tx
+	err := retryLocked(ctx, func() (err error) {
+		tx, err = r.db.BeginTxx(ctx, nil)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	err = fn(&SQLiteRepository{executor: tx})
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("rollback failed: %v: %w", rbErr, err)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// InsertDataPoints inserts a slice of data.OHLCEntity rows into the ohlc_data table of the SQLite repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to insert the data into the table.
+func (r *SQLiteRepository) InsertDataPoints(ctx context.Context, rows []data.OHLCEntity) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO ohlc_data
+		(
+			time,
+			symbol,
+			open,
+			high,
+			low,
+			close,
+			source_file
+		) VALUES (
+			:time,
+			:symbol,
+			:open,
+			:high,
+			:low,
+			:close,
+			:source_file
+		);
+	`
+	_, err := r.NamedExecContext(ctx, stmt, utcDataPoints(rows))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDataPoints retrieves OHLC data points from the database for a given symbol and time range
+// It returns a slice of OHLCEntity structs with data for a given symbol between the start and end times
+// The result is paginated with page number and page size parameters
+func (r *SQLiteRepository) GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error) {
+	stmt := `
+	SELECT
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_data
+	WHERE
+		symbol = ?
+		AND time >= ?
+		AND time <= ?
+	ORDER BY time ASC
+	LIMIT ?
+	OFFSET ?
+	`
+	var ohlcPoints []data.OHLCEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	startTime := time.Unix(payload.StartTime, 0).UTC()
+	endTime := time.Unix(payload.EndTime.Int64, 0).UTC()
+
+	err := r.SelectContext(ctx, &ohlcPoints, stmt, payload.Symbol, startTime, endTime, payload.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return ohlcPoints, nil
+}
+
+// GetDataPointsInRange retrieves every OHLC data point of a symbol between the start and end times (inclusive).
+// Unlike GetDataPoints, the result is not paginated and is ordered by time.
+func (r *SQLiteRepository) GetDataPointsInRange(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.OHLCEntity, error) {
+	stmt := `
+	SELECT
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_data
+	WHERE
+		symbol = ?
+		AND time >= ?
+		AND time <= ?
+	ORDER BY time ASC
+	`
+	var ohlcPoints []data.OHLCEntity
+
+	err := r.SelectContext(ctx, &ohlcPoints, stmt, symbol, startTime.UTC(), endTime.UTC())
+	if err != nil {
+		return nil, err
+	}
+	return ohlcPoints, nil
+}
+
+// GetSymbols retrieves the distinct symbols stored in the ohlc_data table.
+func (r *SQLiteRepository) GetSymbols(ctx context.Context) ([]string, error) {
+	stmt := `
+	SELECT DISTINCT
+		symbol
+	FROM
+		ohlc_data
+	ORDER BY symbol ASC
+	`
+	var symbols []string
+
+	err := r.SelectContext(ctx, &symbols, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return symbols, nil
+}
+
+// GetLatestDataPoints retrieves the latest OHLC data points of a symbol strictly before the given time.
+// At most `limit` data points are returned, ordered by time.
+func (r *SQLiteRepository) GetLatestDataPoints(ctx context.Context, symbol string, before time.Time, limit int) ([]data.OHLCEntity, error) {
+	stmt := `
+	SELECT
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close
+	FROM
+		(
+			SELECT
+				time,
+				symbol,
+				open,
+				high,
+				low,
+				close
+			FROM
+				ohlc_data
+			WHERE
+				symbol = ?
+				AND time < ?
+			ORDER BY time DESC
+			LIMIT ?
+		) AS latest
+	ORDER BY time ASC
+	`
+	var ohlcPoints []data.OHLCEntity
+
+	err := r.SelectContext(ctx, &ohlcPoints, stmt, symbol, before.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return ohlcPoints, nil
+}
+
+// DeleteDataPoint removes the OHLC data points of a symbol at the given time from the ohlc_data table.
+func (r *SQLiteRepository) DeleteDataPoint(ctx context.Context, symbol string, t time.Time) error {
+	stmt := `
+	DELETE FROM ohlc_data
+	WHERE
+		symbol = ?
+		AND time = ?
+	`
+	_, err := r.ExecContext(ctx, stmt, symbol, t.UTC())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountDataPointsBySourceFile counts the OHLC data points inserted from the given uploaded file.
+func (r *SQLiteRepository) CountDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	stmt := `
+	SELECT
+		COUNT(*)
+	FROM
+		ohlc_data
+	WHERE
+		source_file = ?
+	`
+	var count int64
+
+	err := r.GetContext(ctx, &count, stmt, fileName)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteDataPointsBySourceFile removes the OHLC data points inserted from the given uploaded file,
+// as well as the suspicious data points detected in it, within a single transaction.
+// It returns the number of OHLC data points removed.
+func (r *SQLiteRepository) DeleteDataPointsBySourceFile(ctx context.Context, fileName string) (int64, error) {
+	var count int64
+	err := r.WithTransaction(ctx, func(repo Repository) error {
+		tx := repo.(*SQLiteRepository)
+		stmt := `
+		DELETE FROM ohlc_data
+		WHERE
+			source_file = ?
+		`
+		result, err := tx.ExecContext(ctx, stmt, fileName)
+		if err != nil {
+			return err
+		}
+		count, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		stmt = `
+		DELETE FROM ohlc_anomaly
+		WHERE
+			source_file = ?
+		`
+		_, err = tx.ExecContext(ctx, stmt, fileName)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetProcessingStatus retrieves the processing status of a file from the database
+// It returns a ProcessingStatusEntity struct with the status of the file
+func (r *SQLiteRepository) GetProcessingStatus(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+	stmt := `
+	SELECT
+		file_name,
+		status,
+		error,
+		uploader,
+		callback_url,
+		tags,
+		bytes_total,
+		bytes_read,
+		rows_parsed,
+		rows_inserted,
+		rows_rejected,
+		symbols,
+		range_start,
+		range_end,
+		started_at,
+		finished_at,
+		created_at,
+		updated_at
+	FROM
+		process_status
+	WHERE
+		file_name = ?
+	`
+	var status data.ProcessingStatusEntity
+
+	err := r.GetContext(ctx, &status, stmt, fileName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, E.NewErrEntityNotFound("file", fileName)
+		}
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetProcessingStatuses retrieves the processing statuses of the files matching the filters of the payload.
+// A file matches the symbol filter if it touched the symbol. The time range applies to the creation time.
+// The result is sorted by the requested field and paginated with page number and page size parameters.
+func (r *SQLiteRepository) GetProcessingStatuses(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, error) {
+	stmt := fmt.Sprintf(`
+	SELECT
+		file_name,
+		status,
+		error,
+		uploader,
+		callback_url,
+		tags,
+		bytes_total,
+		bytes_read,
+		rows_parsed,
+		rows_inserted,
+		rows_rejected,
+		symbols,
+		range_start,
+		range_end,
+		started_at,
+		finished_at,
+		created_at,
+		updated_at
+	FROM
+		process_status
+	WHERE
+		(? = '' OR status = ?)
+		AND (? = '' OR uploader = ?)
+		AND (? = '' OR EXISTS (SELECT 1 FROM json_each(symbols) WHERE json_each.value = ?))
+		AND (? IS NULL OR created_at >= datetime(?, 'unixepoch'))
+		AND (? IS NULL OR created_at <= datetime(?, 'unixepoch'))
+	ORDER BY %s %s, id %s
+	LIMIT ?
+	OFFSET ?
+	`, uploadSortColumns[payload.SortBy], uploadSortOrders[payload.Order], uploadSortOrders[payload.Order])
+	var statuses []data.ProcessingStatusEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	err := r.SelectContext(ctx, &statuses, stmt,
+		payload.Status, payload.Status,
+		payload.Uploader, payload.Uploader,
+		payload.Symbol, payload.Symbol,
+		payload.StartTime, payload.StartTime,
+		payload.EndTime, payload.EndTime,
+		payload.PageSize, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// InsertProcessingStatus inserts a ProcessingStatusEntity struct into the process_status table of the SQLite repository.
+// If the file already has a processing status, its status is replaced and its error, progress and finish time cleared,
+// its uploader, callback URL and tags being kept unless new ones are given.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to insert the data into the table.
+func (r *SQLiteRepository) InsertProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error {
+	stmt := `
+	INSERT INTO process_status
+		(
+			file_name,
+			status,
+			uploader,
+			callback_url,
+			tags
+		) VALUES (
+			:file_name,
+			:status,
+			:uploader,
+			:callback_url,
+			:tags
+		)
+	ON CONFLICT (file_name) DO UPDATE SET
+		status = EXCLUDED.status,
+		error = NULL,
+		uploader = COALESCE(EXCLUDED.uploader, process_status.uploader),
+		callback_url = COALESCE(EXCLUDED.callback_url, process_status.callback_url),
+		tags = COALESCE(EXCLUDED.tags, process_status.tags),
+		bytes_total = 0,
+		bytes_read = 0,
+		rows_parsed = 0,
+		rows_inserted = 0,
+		rows_rejected = 0,
+		symbols = NULL,
+		range_start = NULL,
+		range_end = NULL,
+		started_at = NULL,
+		finished_at = NULL;
+	`
+	_, err := r.NamedExecContext(ctx, stmt, status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateProcessingStatus updates the status of a file in the process_status table of the SQLite repository.
+// A file processed again, whose status is not terminal, has its progress and finish time cleared.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to update the status of the file in the table.
+func (r *SQLiteRepository) UpdateProcessingStatus(ctx context.Context, status data.ProcessingStatusEntity) error {
+	stmt := `
+	UPDATE process_status
+	SET
+		status = :status,
+		error = :error,
+		finished_at = COALESCE(:finished_at, finished_at)
+	WHERE
+		file_name = :file_name
+	`
+	if !status.Status.IsTerminal() {
+		stmt = `
+		UPDATE process_status
+		SET
+			status = :status,
+			error = :error,
+			bytes_total = 0,
+			bytes_read = 0,
+			rows_parsed = 0,
+			rows_inserted = 0,
+			rows_rejected = 0,
+			symbols = NULL,
+			range_start = NULL,
+			range_end = NULL,
+			started_at = NULL,
+			finished_at = NULL
+		WHERE
+			file_name = :file_name
+		`
+	}
+	_, err := r.NamedExecContext(ctx, stmt, status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateProcessingProgress updates the ingestion progress of a file in the process_status table of the SQLite repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// The progress is recorded on a best-effort basis, without waiting for the database to be unlocked, as the progress of a file
+// ingested within a transaction would otherwise wait for the transaction to be committed.
+// It returns an error if it failed to update the progress of the file in the table.
+func (r *SQLiteRepository) UpdateProcessingProgress(ctx context.Context, status data.ProcessingStatusEntity) error {
+	stmt := `
+	UPDATE process_status
+	SET
+		bytes_total = :bytes_total,
+		bytes_read = :bytes_read,
+		rows_parsed = :rows_parsed,
+		rows_inserted = :rows_inserted,
+		rows_rejected = :rows_rejected,
+		symbols = :symbols,
+		range_start = :range_start,
+		range_end = :range_end,
+		started_at = :started_at
+	WHERE
+		file_name = :file_name
+	`
+	var exec executor = r.executor
+	if db, ok := exec.(sqliteDB); ok {
+		exec = db.DB
+	}
+	_, err := exec.NamedExecContext(ctx, stmt, status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RemoveStaleProcessingStatus removes stale entries from the process_status, webhook_delivery and rejected_message tables of the SQLite repository.
+// It returns an error if it failed to remove the stale entries from the table.
+func (r *SQLiteRepository) RemoveStaleProcessingStatus(ctx context.Context, staleTime time.Time) error {
+	stmt := `
+	DELETE FROM process_status
+	WHERE
+		updated_at <= datetime(?)
+	`
+	_, err := r.ExecContext(ctx, stmt, staleTime.UTC())
+	if err != nil {
+		return err
+	}
+
+	stmt = `
+	DELETE FROM webhook_delivery
+	WHERE
+		created_at <= datetime(?)
+	`
+	_, err = r.ExecContext(ctx, stmt, staleTime.UTC())
+	if err != nil {
+		return err
+	}
+
+	stmt = `
+	DELETE FROM rejected_message
+	WHERE
+		created_at <= datetime(?)
+	`
+	_, err = r.ExecContext(ctx, stmt, staleTime.UTC())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// InsertWebhookDelivery inserts a WebhookDeliveryEntity struct into the webhook_delivery table of the SQLite repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to insert the data into the table.
+func (r *SQLiteRepository) InsertWebhookDelivery(ctx context.Context, delivery data.WebhookDeliveryEntity) error {
+	stmt := `
+	INSERT INTO webhook_delivery
+		(
+			file_name,
+			url,
+			attempt,
+			status_code,
+			error,
+			delivered
+		) VALUES (
+			:file_name,
+			:url,
+			:attempt,
+			:status_code,
+			:error,
+			:delivered
+		)
+	`
+	_, err := r.NamedExecContext(ctx, stmt, delivery)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the webhook delivery attempts of a file, oldest first.
+func (r *SQLiteRepository) GetWebhookDeliveries(ctx context.Context, fileName string) ([]data.WebhookDeliveryEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		file_name,
+		url,
+		attempt,
+		status_code,
+		error,
+		delivered,
+		created_at
+	FROM
+		webhook_delivery
+	WHERE
+		file_name = ?
+	ORDER BY id ASC
+	`
+	var deliveries []data.WebhookDeliveryEntity
+
+	err := r.SelectContext(ctx, &deliveries, stmt, fileName)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// InsertRejectedMessage inserts a RejectedMessageEntity struct into the rejected_message table of the SQLite repository.
+// It uses NamedExecContext to bind the values in the sql statement.
+// It returns an error if it failed to insert the data into the table.
+func (r *SQLiteRepository) InsertRejectedMessage(ctx context.Context, message data.RejectedMessageEntity) error {
+	stmt := `
+	INSERT INTO rejected_message
+		(
+			message_id,
+			body,
+			reason
+		) VALUES (
+			:message_id,
+			:body,
+			:reason
+		)
+	`
+	_, err := r.NamedExecContext(ctx, stmt, message)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetRejectedMessages retrieves the SQS messages rejected by the consumer, most recent first.
+func (r *SQLiteRepository) GetRejectedMessages(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		message_id,
+		body,
+		reason,
+		created_at
+	FROM
+		rejected_message
+	ORDER BY id DESC
+	LIMIT ?
+	OFFSET ?
+	`
+	var messages []data.RejectedMessageEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	err := r.SelectContext(ctx, &messages, stmt, payload.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// UpsertDataQualityReports inserts data quality reports into the data_quality table of the SQLite repository.
+// A report already stored for the same symbol, day and interval is replaced.
+// It returns an error if it failed to insert the data into the table.
+func (r *SQLiteRepository) UpsertDataQualityReports(ctx context.Context, reports []data.DataQualityEntity) error {
+	if len(reports) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO data_quality
+		(
+			symbol,
+			day,
+			interval_seconds,
+			expected_count,
+			present_count,
+			missing_count,
+			duplicate_count,
+			coverage,
+			gaps
+		) VALUES (
+			:symbol,
+			DATE(:day),
+			:interval_seconds,
+			:expected_count,
+			:present_count,
+			:missing_count,
+			:duplicate_count,
+			:coverage,
+			:gaps
+		)
+	ON CONFLICT (symbol, day, interval_seconds) DO UPDATE SET
+		expected_count = EXCLUDED.expected_count,
+		present_count = EXCLUDED.present_count,
+		missing_count = EXCLUDED.missing_count,
+		duplicate_count = EXCLUDED.duplicate_count,
+		coverage = EXCLUDED.coverage,
+		gaps = EXCLUDED.gaps;
+	`
+	_, err := r.NamedExecContext(ctx, stmt, reports)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDataQualityReports retrieves the stored data quality reports of a symbol for the days between the start and end times.
+func (r *SQLiteRepository) GetDataQualityReports(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.DataQualityEntity, error) {
+	stmt := `
+	SELECT
+		symbol,
+		day,
+		interval_seconds,
+		expected_count,
+		present_count,
+		missing_count,
+		duplicate_count,
+		coverage,
+		gaps,
+		created_at,
+		updated_at
+	FROM
+		data_quality
+	WHERE
+		symbol = ?
+		AND day >= DATE(?)
+		AND day <= DATE(?)
+	ORDER BY day ASC, interval_seconds ASC
+	`
+	var reports []data.DataQualityEntity
+
+	err := r.SelectContext(ctx, &reports, stmt, symbol, startTime.UTC(), endTime.UTC())
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// InsertAnomalies inserts a slice of data.AnomalyEntity rows into the ohlc_anomaly table of the SQLite repository.
+// It returns an error if it failed to insert the data into the table.
+func (r *SQLiteRepository) InsertAnomalies(ctx context.Context, anomalies []data.AnomalyEntity) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO ohlc_anomaly
+		(
+			time,
+			symbol,
+			open,
+			high,
+			low,
+			close,
+			reason,
+			status,
+			source_file
+		) VALUES (
+			:time,
+			:symbol,
+			:open,
+			:high,
+			:low,
+			:close,
+			:reason,
+			:status,
+			:source_file
+		);
+	`
+	_, err := r.NamedExecContext(ctx, stmt, utcAnomalies(anomalies))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetAnomalies retrieves the suspicious data points awaiting review, optionally filtered by symbol and status.
+// The result is paginated with page number and page size parameters.
+func (r *SQLiteRepository) GetAnomalies(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close,
+		reason,
+		status,
+		source_file,
+		created_at
+	FROM
+		ohlc_anomaly
+	WHERE
+		(? = '' OR symbol = ?)
+		AND (? = '' OR status = ?)
+	ORDER BY id ASC
+	LIMIT ?
+	OFFSET ?
+	`
+	var anomalies []data.AnomalyEntity
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	err := r.SelectContext(ctx, &anomalies, stmt, payload.Symbol, payload.Symbol, payload.Status, payload.Status, payload.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return anomalies, nil
+}
+
+// GetAnomaly retrieves a suspicious data point by its ID.
+func (r *SQLiteRepository) GetAnomaly(ctx context.Context, id int64) (*data.AnomalyEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		time,
+		symbol,
+		open,
+		high,
+		low,
+		close,
+		reason,
+		status,
+		source_file,
+		created_at
+	FROM
+		ohlc_anomaly
+	WHERE
+		id = ?
+	`
+	var anomaly data.AnomalyEntity
+
+	err := r.GetContext(ctx, &anomaly, stmt, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, E.NewErrEntityNotFound("anomaly", strconv.FormatInt(id, 10))
+		}
+		return nil, err
+	}
+	return &anomaly, nil
+}
+
+// DeleteAnomaly removes a suspicious data point from the ohlc_anomaly table.
+func (r *SQLiteRepository) DeleteAnomaly(ctx context.Context, id int64) error {
+	stmt := `
+	DELETE FROM ohlc_anomaly
+	WHERE
+		id = ?
+	`
+	_, err := r.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// utcDataPoints returns a copy of the data points with their time in UTC.
+func utcDataPoints(rows []data.OHLCEntity) []data.OHLCEntity {
+	utc := make([]data.OHLCEntity, len(rows))
+	for i, row := range rows {
+		row.Time = row.Time.UTC()
+		utc[i] = row
+	}
+	return utc
+}
+
+// utcAnomalies returns a copy of the suspicious data points with their time in UTC.
+func utcAnomalies(anomalies []data.AnomalyEntity) []data.AnomalyEntity {
+	utc := make([]data.AnomalyEntity, len(anomalies))
+	for i, anomaly := range anomalies {
+		anomaly.Time = anomaly.Time.UTC()
+		utc[i] = anomaly
+	}
+	return utc
+}
+
+// CreatePartitions is a no-op: SQLite does not support partitioning.
+func (r *SQLiteRepository) CreatePartitions(ctx context.Context, until time.Time) error {
+	return nil
+}
+
+// DropPartitions removes the data points of the ohlc_data table of the months before the month of `before`.
+func (r *SQLiteRepository) DropPartitions(ctx context.Context, before time.Time) error {
+	stmt := `
+	DELETE FROM ohlc_data
+	WHERE
+		time < ?
+	`
+	_, err := r.ExecContext(ctx, stmt, monthStart(before))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteDataPointsBefore removes the OHLC data points of a symbol strictly before the given time.
+// It returns the number of data points removed.
+func (r *SQLiteRepository) DeleteDataPointsBefore(ctx context.Context, symbol string, before time.Time) (int64, error) {
+	stmt := `
+	DELETE FROM ohlc_data
+	WHERE
+		symbol = ?
+		AND time < ?
+	`
+	result, err := r.ExecContext(ctx, stmt, symbol, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// UpsertRollups inserts downsampled candles into the ohlc_rollup table.
+// A candle already stored for the same symbol, interval and time is replaced.
+func (r *SQLiteRepository) UpsertRollups(ctx context.Context, rows []data.RollupEntity) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	stmt := `
+	INSERT INTO ohlc_rollup
+		(
+			symbol,
+			interval_seconds,
+			time,
+			open,
+			high,
+			low,
+			close
+		) VALUES (
+			:symbol,
+			:interval_seconds,
+			:time,
+			:open,
+			:high,
+			:low,
+			:close
+		)
+	ON CONFLICT (symbol, interval_seconds, time) DO UPDATE SET
+		open = excluded.open,
+		high = excluded.high,
+		low = excluded.low,
+		close = excluded.close;
+	`
+	_, err := r.NamedExecContext(ctx, stmt, utcRollups(rows))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetRollups retrieves the downsampled candles of a symbol and interval between the start and end times (inclusive),
+// ordered by time.
+func (r *SQLiteRepository) GetRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) ([]data.RollupEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		symbol,
+		interval_seconds,
+		time,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_rollup
+	WHERE
+		symbol = ?
+		AND interval_seconds = ?
+		AND time >= ?
+		AND time <= ?
+	ORDER BY time ASC
+	`
+	rollups := []data.RollupEntity{}
+
+	err := r.SelectContext(ctx, &rollups, stmt, symbol, intervalSeconds, startTime.UTC(), endTime.UTC())
+	if err != nil {
+		return nil, err
+	}
+	return rollups, nil
+}
+
+// GetOldestRollups retrieves the oldest downsampled candles of a symbol and interval.
+// At most `limit` rollups are returned, ordered by time.
+func (r *SQLiteRepository) GetOldestRollups(ctx context.Context, symbol string, intervalSeconds int64, limit int) ([]data.RollupEntity, error) {
+	stmt := `
+	SELECT
+		id,
+		symbol,
+		interval_seconds,
+		time,
+		open,
+		high,
+		low,
+		close
+	FROM
+		ohlc_rollup
+	WHERE
+		symbol = ?
+		AND interval_seconds = ?
+	ORDER BY time ASC
+	LIMIT ?
+	`
+	rollups := []data.RollupEntity{}
+
+	err := r.SelectContext(ctx, &rollups, stmt, symbol, intervalSeconds, limit)
+	if err != nil {
+		return nil, err
+	}
+	return rollups, nil
+}
+
+// DeleteRollups removes the downsampled candles of a symbol and interval between the start and end times (inclusive).
+// It returns the number of candles removed.
+func (r *SQLiteRepository) DeleteRollups(ctx context.Context, symbol string, intervalSeconds int64, startTime time.Time, endTime time.Time) (int64, error) {
+	stmt := `
+	DELETE FROM ohlc_rollup
+	WHERE
+		symbol = ?
+		AND interval_seconds = ?
+		AND time >= ?
+		AND time <= ?
+	`
+	result, err := r.ExecContext(ctx, stmt, symbol, intervalSeconds, startTime.UTC(), endTime.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetRollupSymbols retrieves the distinct symbols stored in the ohlc_rollup table.
+func (r *SQLiteRepository) GetRollupSymbols(ctx context.Context) ([]string, error) {
+	stmt := `
+	SELECT DISTINCT
+		symbol
+	FROM
+		ohlc_rollup
+	ORDER BY symbol ASC
+	`
+	symbols := []string{}
+
+	err := r.SelectContext(ctx, &symbols, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return symbols, nil
+}
+
+// utcRollups returns a copy of the downsampled candles with their time in UTC.
+func utcRollups(rows []data.RollupEntity) []data.RollupEntity {
+	utc := make([]data.RollupEntity, len(rows))
+	for i, row := range rows {
+		row.Time = row.Time.UTC()
+		utc[i] = row
+	}
+	return utc
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/db"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/database"
+	E "github.com/teezzan/candles/internal/errors"
+	"github.com/teezzan/candles/internal/null"
+)
+
+// newSQLiteRepository returns a repository backed by a migrated in-memory SQLite database.
+func newSQLiteRepository(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	sqliteDB, err := database.New(config.DatabaseConfig{Driver: database.DriverSQLite, Database: database.MemoryDatabase})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqliteDB.Close()
+	})
+	require.NoError(t, sqliteDB.Migrate(context.Background(), db.SQLiteMigrations()))
+	return NewSQLiteRepository(sqliteDB.SQL)
+}
+
+func TestSQLiteRepository_DataPoints(t *testing.T) {
+	ctx := context.Background()
+	r := newSQLiteRepository(t)
+	zone := time.FixedZone("UTC+1", 3600)
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var points []data.OHLCEntity
+	for i := 0; i < 5; i++ {
+		points = append(points, data.OHLCEntity{
+			Time:       start.Add(time.Duration(i) * time.Minute).In(zone),
+			Symbol:     "BTC",
+			Open:       decimal.RequireFromString("1.5"),
+			High:       decimal.RequireFromString("2.25"),
+			Low:        decimal.NewFromInt(1),
+			Close:      decimal.NewFromInt(2),
+			SourceFile: null.NewString("btc.csv"),
+		})
+	}
+	points = append(points, data.OHLCEntity{Time: start, Symbol: "ETH", Open: decimal.NewFromInt(1), High: decimal.NewFromInt(1), Low: decimal.NewFromInt(1), Close: decimal.NewFromInt(1), SourceFile: null.NewString("eth.csv")})
+	require.NoError(t, r.InsertDataPoints(ctx, points))
+
+	got, err := r.GetDataPoints(ctx, data.GetOHLCRequest{
+		Symbol:     "BTC",
+		StartTime:  start.Add(time.Minute).Unix(),
+		EndTime:    null.NewInt64(start.Add(4 * time.Minute).Unix()),
+		PageNumber: null.NewInt(2),
+		PageSize:   null.NewInt(2),
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.True(t, got[0].Time.Equal(start.Add(3*time.Minute)))
+	assert.True(t, got[1].Time.Equal(start.Add(4*time.Minute)))
+	assert.Equal(t, "2.25", got[0].High.String())
+
+	got, err = r.GetDataPointsInRange(ctx, "BTC", start, start.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, got, 3)
+
+	got, err = r.GetLatestDataPoints(ctx, "BTC", start.Add(4*time.Minute), 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.True(t, got[0].Time.Equal(start.Add(2*time.Minute)))
+
+	symbols, err := r.GetSymbols(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTC", "ETH"}, symbols)
+
+	require.NoError(t, r.DeleteDataPoint(ctx, "BTC", start.In(zone)))
+	count, err := r.CountDataPointsBySourceFile(ctx, "btc.csv")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	count, err = r.DeleteDataPointsBySourceFile(ctx, "btc.csv")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	symbols, err = r.GetSymbols(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ETH"}, symbols)
+}
+
+func TestSQLiteRepository_ProcessingStatus(t *testing.T) {
+	ctx := context.Background()
+	r := newSQLiteRepository(t)
+
+	_, err := r.GetProcessingStatus(ctx, "test.csv")
+	assert.True(t, E.IsErrEntityNotFound(err))
+
+	require.NoError(t, r.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{
+		FileName: "test.csv",
+		Status:   data.ProcessingStatusAwaitingUpload,
+		Uploader: null.NewString("alice"),
+		Tags:     data.Tags{"source": "test"},
+	}))
+	require.NoError(t, r.UpdateProcessingStatus(ctx, data.ProcessingStatusEntity{
+		FileName: "test.csv",
+		Status:   data.ProcessingStatusFailed,
+		Error:    null.NewString("test error"),
+	}))
+	require.NoError(t, r.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{
+		FileName: "test.csv",
+		Status:   data.ProcessingStatusQueued,
+	}))
+	require.NoError(t, r.UpdateProcessingProgress(ctx, data.ProcessingStatusEntity{
+		FileName:     "test.csv",
+		RowsInserted: 10,
+		Symbols:      data.Symbols{"BTC", "ETH"},
+		StartedAt:    null.NewTime(time.Now()),
+	}))
+
+	status, err := r.GetProcessingStatus(ctx, "test.csv")
+	require.NoError(t, err)
+	assert.Equal(t, data.ProcessingStatusQueued, status.Status)
+	assert.False(t, status.Error.Valid)
+	assert.Equal(t, "alice", status.Uploader.String)
+	assert.Equal(t, data.Tags{"source": "test"}, status.Tags)
+	assert.Equal(t, int64(10), status.RowsInserted)
+	assert.Equal(t, data.Symbols{"BTC", "ETH"}, status.Symbols)
+	assert.True(t, status.StartedAt.Valid)
+
+	tests := []struct {
+		name    string
+		payload data.GetUploadsRequest
+		want    int
+	}{
+		{
+			name:    "symbol touched by the file",
+			payload: data.GetUploadsRequest{Symbol: "ETH"},
+			want:    1,
+		},
+		{
+			name:    "symbol not touched by the file",
+			payload: data.GetUploadsRequest{Symbol: "BT"},
+		},
+		{
+			name:    "created within the time range",
+			payload: data.GetUploadsRequest{StartTime: null.NewInt64(time.Now().Add(-time.Hour).Unix())},
+			want:    1,
+		},
+		{
+			name:    "created before the time range",
+			payload: data.GetUploadsRequest{StartTime: null.NewInt64(time.Now().Add(time.Hour).Unix())},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.payload.SortBy = data.UploadSortFieldCreatedAt
+			tt.payload.Order = data.SortOrderDesc
+			tt.payload.PageNumber = null.NewInt(1)
+			tt.payload.PageSize = null.NewInt(10)
+			statuses, err := r.GetProcessingStatuses(ctx, tt.payload)
+			require.NoError(t, err)
+			assert.Len(t, statuses, tt.want)
+		})
+	}
+
+	require.NoError(t, r.RemoveStaleProcessingStatus(ctx, time.Now().Add(time.Hour)))
+	_, err = r.GetProcessingStatus(ctx, "test.csv")
+	assert.True(t, E.IsErrEntityNotFound(err))
+}
+
+func TestSQLiteRepository_UpsertDataQualityReports(t *testing.T) {
+	ctx := context.Background()
+	r := newSQLiteRepository(t)
+	day := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	report := data.DataQualityEntity{Symbol: "BTC", Day: day, IntervalSeconds: 60, ExpectedCount: 1440, PresentCount: 1000, Coverage: 69.44}
+	require.NoError(t, r.UpsertDataQualityReports(ctx, []data.DataQualityEntity{report}))
+	report.PresentCount = 1440
+	report.Coverage = 100
+	require.NoError(t, r.UpsertDataQualityReports(ctx, []data.DataQualityEntity{report}))
+
+	reports, err := r.GetDataQualityReports(ctx, "BTC", day, day.Add(12*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.True(t, reports[0].Day.Equal(day))
+	assert.Equal(t, int64(1440), reports[0].PresentCount)
+	assert.Equal(t, float64(100), reports[0].Coverage)
+	assert.Equal(t, data.Gaps{}, reports[0].Gaps)
+}
+
+func TestSQLiteRepository_WithTransaction(t *testing.T) {
+	ctx := context.Background()
+	r := newSQLiteRepository(t)
+	point := data.OHLCEntity{Time: time.Now(), Symbol: "BTC", Open: decimal.NewFromInt(1), High: decimal.NewFromInt(1), Low: decimal.NewFromInt(1), Close: decimal.NewFromInt(1)}
+	require.NoError(t, r.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{FileName: "test.csv", Status: data.ProcessingStatusInProgress}))
+
+	testErr := errors.New("test error")
+	err := r.WithTransaction(ctx, func(repo Repository) error {
+		require.NoError(t, repo.InsertDataPoints(ctx, []data.OHLCEntity{point}))
+		return testErr
+	})
+	assert.ErrorIs(t, err, testErr)
+	symbols, err := r.GetSymbols(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, symbols)
+
+	inserted := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- r.WithTransaction(ctx, func(repo Repository) error {
+			err := repo.InsertDataPoints(ctx, []data.OHLCEntity{point})
+			close(inserted)
+			<-release
+			return err
+		})
+	}()
+	<-inserted
+
+	// The progress is not recorded while another transaction locks the database, rather than waiting for it.
+	err = r.UpdateProcessingProgress(ctx, data.ProcessingStatusEntity{FileName: "test.csv", RowsInserted: 1})
+	assert.Error(t, err)
+
+	// Another transaction waits for the database to be unlocked.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+	}()
+	err = r.WithTransaction(ctx, func(repo Repository) error {
+		return repo.InsertDataPoints(ctx, []data.OHLCEntity{point})
+	})
+	require.NoError(t, err)
+	require.NoError(t, <-done)
+
+	points, err := r.GetDataPointsInRange(ctx, "BTC", point.Time.Add(-time.Second), point.Time.Add(time.Second))
+	require.NoError(t, err)
+	assert.Len(t, points, 2)
+}
@@ -0,0 +1,99 @@
+package ohlc
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+)
+
+// maxResampledBuckets is the maximum number of buckets a resampled query may span.
+const maxResampledBuckets = 100000
+
+// bucketStart returns the start of the interval bucket containing t. Buckets are aligned on the UNIX epoch.
+func bucketStart(t time.Time, interval time.Duration) time.Time {
+	step := int64(interval / time.Second)
+	u := t.Unix()
+	return time.Unix(u-u%step, 0)
+}
+
+// resampleDataPoints aggregates time ordered data points into candles of the given interval.
+// A candle opens at the first open and closes at the last close of its bucket,
+// its high and low being the extremes of the bucket.
+func resampleDataPoints(points []data.OHLCEntity, interval time.Duration) []data.OHLCEntity {
+	candles := make([]data.OHLCEntity, 0, len(points))
+	for _, p := range points {
+		b := bucketStart(p.Time, interval)
+		if n := len(candles); n > 0 && candles[n-1].Time.Equal(b) {
+			c := &candles[n-1]
+			if p.High.GreaterThan(c.High) {
+				c.High = p.High
+			}
+			if p.Low.LessThan(c.Low) {
+				c.Low = p.Low
+			}
+			c.Close = p.Close
+			continue
+		}
+
+		p.ID = 0
+		p.Time = b
+		candles = append(candles, p)
+	}
+	return candles
+}
+
+// fillDataPoints synthesises the missing buckets of resampled candles between the buckets of `start` and `end`.
+// Depending on the mode, a missing bucket is filled with a flat candle at the previous close, a placeholder
+// without prices or a candle linearly interpolated between the previous close and the next open. Flat and
+// interpolated candles are not synthesised before the first candle as there is no price to derive them from,
+// and linear interpolation falls back to the previous close after the last candle. Interpolated prices are rounded to
+// `precision` decimal places. Synthesised candles are flagged as such.
+func fillDataPoints(candles []data.OHLCEntity, symbol string, start time.Time, end time.Time, interval time.Duration, mode data.FillMode, precision int32) []data.OHLCEntity {
+	if mode == data.FillModeNone || mode == "" {
+		return candles
+	}
+
+	filled := make([]data.OHLCEntity, 0, len(candles))
+	var prev *data.OHLCEntity
+	i := 0
+	for b := bucketStart(start, interval); !b.After(end); b = b.Add(interval) {
+		if i < len(candles) && candles[i].Time.Equal(b) {
+			filled = append(filled, candles[i])
+			prev = &candles[i]
+			i++
+			continue
+		}
+
+		c := data.OHLCEntity{
+			Time:      b,
+			Symbol:    symbol,
+			Synthetic: true,
+		}
+		switch mode {
+		case data.FillModeNull:
+			filled = append(filled, c)
+			continue
+		case data.FillModePrevious, data.FillModeLinear:
+			if prev == nil {
+				continue
+			}
+		}
+
+		c.Open = prev.Close
+		if n := len(filled); n > 0 && filled[n-1].Synthetic {
+			c.Open = filled[n-1].Close
+		}
+		c.Close = prev.Close
+		if mode == data.FillModeLinear && i < len(candles) {
+			next := candles[i]
+			elapsed := decimal.NewFromInt(int64(b.Sub(prev.Time) / time.Second))
+			total := decimal.NewFromInt(int64(next.Time.Sub(prev.Time) / time.Second))
+			c.Close = prev.Close.Add(next.Open.Sub(prev.Close).Mul(elapsed).DivRound(total, precision))
+		}
+		c.High = decimal.Max(c.Open, c.Close)
+		c.Low = decimal.Min(c.Open, c.Close)
+		filled = append(filled, c)
+	}
+	return filled
+}
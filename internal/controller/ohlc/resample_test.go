@@ -0,0 +1,119 @@
+package ohlc
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+)
+
+func Test_resampleDataPoints(t *testing.T) {
+	start := time.Unix(1610000000-1610000000%3600, 0)
+	points := []data.OHLCEntity{
+		{Symbol: "BTC", Time: start, Open: price(100), High: price(110), Low: price(90), Close: price(105)},
+		{Symbol: "BTC", Time: start.Add(time.Minute), Open: price(105), High: price(130), Low: price(95), Close: price(120)},
+		{Symbol: "BTC", Time: start.Add(2 * time.Hour), Open: price(120), High: price(125), Low: price(80), Close: price(85)},
+	}
+
+	got := resampleDataPoints(points, time.Hour)
+	assert.Equal(t, formatCandles([]data.OHLCEntity{
+		{Symbol: "BTC", Time: start, Open: price(100), High: price(130), Low: price(90), Close: price(120)},
+		{Symbol: "BTC", Time: start.Add(2 * time.Hour), Open: price(120), High: price(125), Low: price(80), Close: price(85)},
+	}), formatCandles(got))
+}
+
+func Test_fillDataPoints(t *testing.T) {
+	start := time.Unix(1610000000-1610000000%3600, 0)
+	candles := []data.OHLCEntity{
+		{Symbol: "BTC", Time: start.Add(time.Hour), Open: price(100), High: price(100), Low: price(100), Close: price(100)},
+		{Symbol: "BTC", Time: start.Add(4 * time.Hour), Open: price(130), High: price(130), Low: price(130), Close: price(130)},
+	}
+	end := start.Add(5 * time.Hour)
+
+	tests := []struct {
+		name string
+		mode data.FillMode
+		want []data.OHLCEntity
+	}{
+		{
+			name: "none",
+			mode: data.FillModeNone,
+			want: candles,
+		},
+		{
+			name: "previous",
+			mode: data.FillModePrevious,
+			want: []data.OHLCEntity{
+				candles[0],
+				{Symbol: "BTC", Time: start.Add(2 * time.Hour), Open: price(100), High: price(100), Low: price(100), Close: price(100), Synthetic: true},
+				{Symbol: "BTC", Time: start.Add(3 * time.Hour), Open: price(100), High: price(100), Low: price(100), Close: price(100), Synthetic: true},
+				candles[1],
+				{Symbol: "BTC", Time: start.Add(5 * time.Hour), Open: price(130), High: price(130), Low: price(130), Close: price(130), Synthetic: true},
+			},
+		},
+		{
+			name: "null",
+			mode: data.FillModeNull,
+			want: []data.OHLCEntity{
+				{Symbol: "BTC", Time: start, Synthetic: true},
+				candles[0],
+				{Symbol: "BTC", Time: start.Add(2 * time.Hour), Synthetic: true},
+				{Symbol: "BTC", Time: start.Add(3 * time.Hour), Synthetic: true},
+				candles[1],
+				{Symbol: "BTC", Time: start.Add(5 * time.Hour), Synthetic: true},
+			},
+		},
+		{
+			name: "linear",
+			mode: data.FillModeLinear,
+			want: []data.OHLCEntity{
+				candles[0],
+				{Symbol: "BTC", Time: start.Add(2 * time.Hour), Open: price(100), High: price(110), Low: price(100), Close: price(110), Synthetic: true},
+				{Symbol: "BTC", Time: start.Add(3 * time.Hour), Open: price(110), High: price(120), Low: price(110), Close: price(120), Synthetic: true},
+				candles[1],
+				{Symbol: "BTC", Time: start.Add(5 * time.Hour), Open: price(130), High: price(130), Low: price(130), Close: price(130), Synthetic: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fillDataPoints(candles, "BTC", start, end, time.Hour, tt.mode, 2)
+			assert.Equal(t, formatCandles(tt.want), formatCandles(got))
+		})
+	}
+
+	// Interpolated prices are rounded to the precision of the symbol.
+	candles[1].Open = price(101)
+	got := fillDataPoints(candles, "BTC", start, end, time.Hour, data.FillModeLinear, 2)
+	require.Len(t, got, 5)
+	assert.Equal(t, []string{"100", "100.33", "100", "100.33"}, formatPrices(got[1].Open, got[1].High, got[1].Low, got[1].Close))
+	assert.Equal(t, []string{"100.33", "100.67", "100.33", "100.67"}, formatPrices(got[2].Open, got[2].High, got[2].Low, got[2].Close))
+}
+
+// price returns a decimal price, represented like a parsed one.
+func price(v float64) decimal.Decimal {
+	return decimal.RequireFromString(strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+// formatCandles returns the time, flag and prices of the candles, for comparison.
+func formatCandles(candles []data.OHLCEntity) []string {
+	got := make([]string, len(candles))
+	for i, c := range candles {
+		got[i] = fmt.Sprintf("%s %d %t %v", c.Symbol, c.Time.Unix(), c.Synthetic, formatPrices(c.Open, c.High, c.Low, c.Close))
+	}
+	return got
+}
+
+// formatPrices returns the prices without trailing zeros.
+func formatPrices(values ...decimal.Decimal) []string {
+	got := make([]string, len(values))
+	for i, v := range values {
+		got[i] = v.String()
+	}
+	return got
+}
@@ -0,0 +1,251 @@
+package ohlc
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+// retentionWindowBuckets is the number of buckets of the coarser resolution the expired data points are downsampled
+// into at once, to bound the number of data points loaded in memory.
+const retentionWindowBuckets = 1000
+
+// ApplyRetentionPolicy enforces the configured retention policy on the candles of every symbol, finest resolution
+// first. Expired candles are downsampled into the next coarser resolution before being removed, unless they have
+// the coarsest resolution. The rollups of an interval without retention rule expire along with the candles of the
+// coarsest resolution the interval is a multiple of, as the candles downsampled from them cover the same buckets.
+// A failing symbol does not prevent the others from being processed, the first error encountered is returned.
+func (s *DefaultService) ApplyRetentionPolicy(ctx context.Context) error {
+	policy := s.retentionPolicy
+	if len(policy.Resolutions) == 0 {
+		return nil
+	}
+
+	symbols, err := s.repository.GetSymbols(ctx)
+	if err != nil {
+		return err
+	}
+	rollupSymbols, err := s.repository.GetRollupSymbols(ctx)
+	if err != nil {
+		return err
+	}
+	symbols = mergeSymbols(symbols, rollupSymbols)
+
+	now := time.Now()
+	var firstErr error
+	for _, symbol := range symbols {
+		err = s.applyRetentionPolicy(ctx, policy, symbol, now)
+		if err != nil {
+			s.logger.Error("retention policy enforcement failed", zap.String("symbol", symbol), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// applyRetentionPolicy enforces the retention policy on the candles of a symbol.
+func (s *DefaultService) applyRetentionPolicy(ctx context.Context, policy *data.RetentionPolicy, symbol string, now time.Time) error {
+	cutoffs := map[time.Duration]time.Time{}
+	for i, resolution := range policy.Resolutions {
+		retention := policy.Retention(symbol, resolution)
+		if retention == 0 {
+			continue
+		}
+
+		cutoff := now.Add(-retention)
+		var coarser time.Duration
+		if i+1 < len(policy.Resolutions) {
+			coarser = policy.Resolutions[i+1]
+			// Only the complete buckets of the coarser resolution are downsampled.
+			cutoff = bucketStart(cutoff, coarser)
+		}
+		cutoffs[resolution] = cutoff
+
+		var count int64
+		var err error
+		if i == 0 {
+			count, err = s.expireDataPoints(ctx, symbol, cutoff, coarser)
+		} else {
+			count, err = s.expireRollups(ctx, symbol, resolution, cutoff, coarser)
+		}
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			s.logger.Info("expired candles removed",
+				zap.String("symbol", symbol),
+				zap.Duration("resolution", resolution),
+				zap.Time("before", cutoff),
+				zap.Int64("count", count),
+				zap.Duration("downsampled_to", coarser),
+			)
+		}
+	}
+
+	for _, interval := range s.rollupIntervals {
+		resolution := retentionResolutionOf(policy, interval)
+		cutoff, ok := cutoffs[resolution]
+		if !ok || (resolution == interval && resolution != policy.Resolutions[0]) {
+			// Note: kept forever, or already expired as a resolution of the policy.
+			continue
+		}
+		count, err := s.repository.DeleteRollups(ctx, symbol, int64(interval/time.Second), time.Unix(0, 0), cutoff.Add(-time.Second))
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			s.logger.Info("expired rollups removed",
+				zap.String("symbol", symbol),
+				zap.Duration("interval", interval),
+				zap.Time("before", cutoff),
+				zap.Int64("count", count),
+			)
+		}
+	}
+	return nil
+}
+
+// retentionResolutionOf returns the resolution of the policy whose retention applies to the rollups of an interval:
+// the coarsest resolution the interval is a multiple of, or the finest resolution if there is none.
+func retentionResolutionOf(policy *data.RetentionPolicy, interval time.Duration) time.Duration {
+	resolution := policy.Resolutions[0]
+	for _, r := range policy.Resolutions {
+		if interval%r == 0 {
+			resolution = r
+		}
+	}
+	return resolution
+}
+
+// expireDataPoints removes the data points of a symbol before the cutoff, once downsampled into rollups of the
+// coarser resolution if any. The data points are processed oldest first, a window of buckets at a time.
+// It returns the number of data points removed.
+func (s *DefaultService) expireDataPoints(ctx context.Context, symbol string, cutoff time.Time, coarser time.Duration) (int64, error) {
+	if coarser == 0 {
+		return s.repository.DeleteDataPointsBefore(ctx, symbol, cutoff)
+	}
+
+	var total int64
+	for {
+		oldest, err := s.repository.GetDataPoints(ctx, data.GetOHLCRequest{
+			Symbol:     symbol,
+			StartTime:  0,
+			EndTime:    null.NewInt64(cutoff.Unix() - 1),
+			PageNumber: null.NewInt(1),
+			PageSize:   null.NewInt(1),
+		})
+		if err != nil {
+			return total, err
+		}
+		if len(oldest) == 0 {
+			return total, nil
+		}
+
+		windowEnd := bucketStart(oldest[0].Time, coarser).Add(retentionWindowBuckets * coarser)
+		if windowEnd.After(cutoff) {
+			windowEnd = cutoff
+		}
+
+		var count int64
+		err = s.repository.WithTransaction(ctx, func(repo repository.Repository) error {
+			// The window is read within the transaction so that the data points removed are the ones downsampled.
+			points, err := repo.GetDataPointsInRange(ctx, symbol, oldest[0].Time, windowEnd.Add(-time.Second))
+			if err != nil {
+				return err
+			}
+			err = repo.UpsertRollups(ctx, newRollups(resampleDataPoints(points, coarser), coarser))
+			if err != nil {
+				return err
+			}
+			count, err = repo.DeleteDataPointsBefore(ctx, symbol, windowEnd)
+			return err
+		})
+		if err != nil {
+			return total, err
+		}
+		total += count
+		if count == 0 {
+			// Note: nothing left to remove, the data points of the window were removed concurrently.
+			return total, nil
+		}
+	}
+}
+
+// expireRollups removes the rollups of a symbol and resolution before the cutoff, once downsampled into rollups of
+// the coarser resolution if any. The rollups are processed oldest first, a window of buckets at a time, like the
+// data points. It returns the number of rollups removed.
+func (s *DefaultService) expireRollups(ctx context.Context, symbol string, resolution time.Duration, cutoff time.Time, coarser time.Duration) (int64, error) {
+	intervalSeconds := int64(resolution / time.Second)
+	if coarser == 0 {
+		return s.repository.DeleteRollups(ctx, symbol, intervalSeconds, time.Unix(0, 0), cutoff.Add(-time.Second))
+	}
+
+	var total int64
+	for {
+		oldest, err := s.repository.GetOldestRollups(ctx, symbol, intervalSeconds, 1)
+		if err != nil {
+			return total, err
+		}
+		if len(oldest) == 0 || !oldest[0].Time.Before(cutoff) {
+			return total, nil
+		}
+
+		windowStart := oldest[0].Time
+		windowEnd := bucketStart(windowStart, coarser).Add(retentionWindowBuckets * coarser)
+		if windowEnd.After(cutoff) {
+			windowEnd = cutoff
+		}
+
+		var count int64
+		err = s.repository.WithTransaction(ctx, func(repo repository.Repository) error {
+			rollups, err := repo.GetRollups(ctx, symbol, intervalSeconds, windowStart, windowEnd.Add(-time.Second))
+			if err != nil {
+				return err
+			}
+			err = repo.UpsertRollups(ctx, newRollups(resampleDataPoints(rollupPoints(rollups), coarser), coarser))
+			if err != nil {
+				return err
+			}
+			count, err = repo.DeleteRollups(ctx, symbol, intervalSeconds, windowStart, windowEnd.Add(-time.Second))
+			return err
+		})
+		if err != nil {
+			return total, err
+		}
+		total += count
+		if count == 0 {
+			// Note: nothing left to remove, the rollups of the window were removed concurrently.
+			return total, nil
+		}
+	}
+}
+
+// newRollups converts candles of the given interval to rollups.
+func newRollups(candles []data.OHLCEntity, interval time.Duration) []data.RollupEntity {
+	rollups := make([]data.RollupEntity, len(candles))
+	for i, c := range candles {
+		rollups[i] = data.NewRollupEntity(c, interval)
+	}
+	return rollups
+}
+
+// mergeSymbols returns the distinct symbols of both lists in alphabetical order.
+func mergeSymbols(a []string, b []string) []string {
+	seen := map[string]bool{}
+	symbols := []string{}
+	for _, symbol := range append(append([]string{}, a...), b...) {
+		if !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
@@ -0,0 +1,101 @@
+package ohlc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"go.uber.org/zap"
+)
+
+func TestDefaultService_ApplyRetentionPolicy(t *testing.T) {
+	ctx := context.Background()
+	day := 24 * time.Hour
+	today := time.Now().UTC().Truncate(day)
+	expired := today.Add(-200 * day)
+	recent := today.Add(-day)
+
+	repo := repository.NewMemoryRepository()
+	var points []data.OHLCEntity
+	for _, symbol := range []string{"BTC", "ETH"} {
+		for i := 0; i < 150; i++ {
+			for _, start := range []time.Time{expired, recent} {
+				p := float64(i)
+				points = append(points, data.OHLCEntity{
+					Time:   start.Add(time.Duration(i) * time.Minute),
+					Symbol: symbol,
+					Open:   price(p),
+					High:   price(p + 2),
+					Low:    price(p - 1),
+					Close:  price(p + 1),
+				})
+			}
+		}
+	}
+	require.NoError(t, repo.InsertDataPoints(ctx, points))
+	require.NoError(t, repo.UpsertRollups(ctx, []data.RollupEntity{
+		{Symbol: "XRP", IntervalSeconds: 3600, Time: today.Add(-1500 * day), Open: price(5), High: price(6), Low: price(4), Close: price(5)},
+		{Symbol: "XRP", IntervalSeconds: 3600, Time: today.Add(-400 * day), Open: price(1), High: price(3), Low: price(1), Close: price(2)},
+		{Symbol: "XRP", IntervalSeconds: 3600, Time: today.Add(-400*day + time.Hour), Open: price(2), High: price(4), Low: price(0), Close: price(3)},
+		{Symbol: "BTC", IntervalSeconds: 300, Time: expired, Open: price(0), High: price(6), Low: price(-1), Close: price(5)},
+		{Symbol: "BTC", IntervalSeconds: 300, Time: recent, Open: price(0), High: price(6), Low: price(-1), Close: price(5)},
+	}))
+
+	conf := config.Init()
+	conf.OHLCConfig.RetentionRules = []string{"1m=90d", "1h=1y", "1d=forever", "ETH/1m=forever"}
+	conf.OHLCConfig.RollupIntervals = []string{"5m", "1h", "1d"}
+	s := newService(t, zap.NewNop(), repo, &s3.ClientMock{}, &sqs.ClientMock{}, &webhook.ClientMock{}, conf.OHLCConfig)
+	require.NoError(t, s.ApplyRetentionPolicy(ctx))
+
+	// The expired data points are downsampled into hourly candles, the recent ones are kept.
+	btc, err := repo.GetDataPointsInRange(ctx, "BTC", time.Unix(0, 0), today)
+	require.NoError(t, err)
+	require.Len(t, btc, 150)
+	assert.True(t, btc[0].Time.Equal(recent))
+	hourly, err := repo.GetRollups(ctx, "BTC", 3600, time.Unix(0, 0), today)
+	require.NoError(t, err)
+	require.Len(t, hourly, 3)
+	assert.True(t, hourly[0].Time.Equal(expired))
+	assert.Equal(t, []string{"0", "61", "-1", "60"}, formatPrices(hourly[0].Open, hourly[0].High, hourly[0].Low, hourly[0].Close))
+	assert.Equal(t, []string{"120", "151", "119", "150"}, formatPrices(hourly[2].Open, hourly[2].High, hourly[2].Low, hourly[2].Close))
+
+	// The rollups of an interval without retention rule expire along with the data points they are computed from.
+	fiveMinutes, err := repo.GetRollups(ctx, "BTC", 300, time.Unix(0, 0), today)
+	require.NoError(t, err)
+	require.Len(t, fiveMinutes, 1)
+	assert.True(t, fiveMinutes[0].Time.Equal(recent))
+
+	// The symbols with an override keep their data points.
+	eth, err := repo.GetDataPointsInRange(ctx, "ETH", time.Unix(0, 0), today)
+	require.NoError(t, err)
+	assert.Len(t, eth, 300)
+
+	// The expired hourly candles are downsampled into daily candles, across several windows.
+	hourly, err = repo.GetRollups(ctx, "XRP", 3600, time.Unix(0, 0), today)
+	require.NoError(t, err)
+	assert.Empty(t, hourly)
+	daily, err := repo.GetRollups(ctx, "XRP", 86400, time.Unix(0, 0), today)
+	require.NoError(t, err)
+	require.Len(t, daily, 2)
+	assert.Equal(t, []string{"5", "6", "4", "5"}, formatPrices(daily[0].Open, daily[0].High, daily[0].Low, daily[0].Close))
+	assert.Equal(t, []string{"1", "4", "0", "3"}, formatPrices(daily[1].Open, daily[1].High, daily[1].Low, daily[1].Close))
+}
+
+func Test_retentionResolutionOf(t *testing.T) {
+	policy, err := data.ParseRetentionPolicy([]string{"1m=90d", "1h=1y", "1d=forever"})
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Minute, retentionResolutionOf(policy, 5*time.Minute))
+	assert.Equal(t, time.Minute, retentionResolutionOf(policy, 90*time.Minute))
+	assert.Equal(t, time.Hour, retentionResolutionOf(policy, 4*time.Hour))
+	assert.Equal(t, 24*time.Hour, retentionResolutionOf(policy, 7*24*time.Hour))
+	assert.Equal(t, time.Minute, retentionResolutionOf(policy, 30*time.Second))
+}
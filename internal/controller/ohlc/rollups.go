@@ -0,0 +1,231 @@
+package ohlc
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+// rollupRebuildWindowBuckets is the number of buckets of the coarsest rollup interval rebuilt at once, to bound the
+// number of data points loaded in memory.
+const rollupRebuildWindowBuckets = 7
+
+// timeRange is a time range, both ends included.
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+// touchedRanges tracks, for each symbol, the time range covered by the data points written or removed.
+type touchedRanges map[string]timeRange
+
+// add extends the time ranges of the symbols of the data points to cover them.
+func (r touchedRanges) add(points []data.OHLCEntity) {
+	for _, p := range points {
+		tr, ok := r[p.Symbol]
+		if !ok {
+			r[p.Symbol] = timeRange{start: p.Time, end: p.Time}
+			continue
+		}
+		if p.Time.Before(tr.start) {
+			tr.start = p.Time
+		}
+		if p.Time.After(tr.end) {
+			tr.end = p.Time
+		}
+		r[p.Symbol] = tr
+	}
+}
+
+// touchedRangesOf returns the time ranges covered by the data points.
+func touchedRangesOf(points []data.OHLCEntity) touchedRanges {
+	touched := touchedRanges{}
+	touched.add(points)
+	return touched
+}
+
+// updateRollups refreshes the rollups of the touched time ranges, one symbol at a time in alphabetical order.
+func (s *DefaultService) updateRollups(ctx context.Context, repo repository.Repository, touched touchedRanges) error {
+	intervals := s.rollupIntervals
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	symbols := make([]string, 0, len(touched))
+	for symbol := range touched {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	for _, symbol := range symbols {
+		err := refreshRollups(ctx, repo, intervals, symbol, touched[symbol])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshRollups recomputes the rollups of a symbol in the buckets of every interval overlapping the time range,
+// finest interval first. The rollups of the finest interval are aggregated from the data points and the rollups of
+// the other intervals from the rollups of the previous interval, so a bucket without data is removed.
+func refreshRollups(ctx context.Context, repo repository.Repository, intervals []time.Duration, symbol string, tr timeRange) error {
+	for i, interval := range intervals {
+		start := bucketStart(tr.start, interval)
+		end := bucketStart(tr.end, interval).Add(interval - time.Second)
+
+		var points []data.OHLCEntity
+		var err error
+		if i == 0 {
+			points, err = repo.GetDataPointsInRange(ctx, symbol, start, end)
+		} else {
+			var rollups []data.RollupEntity
+			rollups, err = repo.GetRollups(ctx, symbol, int64(intervals[i-1]/time.Second), start, end)
+			points = rollupPoints(rollups)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = repo.DeleteRollups(ctx, symbol, int64(interval/time.Second), start, end)
+		if err != nil {
+			return err
+		}
+		err = repo.UpsertRollups(ctx, newRollups(resampleDataPoints(points, interval), interval))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getCandlesInRange retrieves the candles of a symbol between the start and end times (inclusive) to be resampled into
+// the given interval. They are read from the coarsest rollups the interval is a multiple of, or from the data points
+// if there are none or if the rollups do not cover the data points of the time range.
+func (s *DefaultService) getCandlesInRange(ctx context.Context, symbol string, start time.Time, end time.Time, interval time.Duration) ([]data.OHLCEntity, error) {
+	for i := len(s.rollupIntervals) - 1; i >= 0; i-- {
+		if interval%s.rollupIntervals[i] != 0 {
+			continue
+		}
+		rollups, err := s.repository.GetRollups(ctx, symbol, int64(s.rollupIntervals[i]/time.Second), start, end)
+		if err != nil {
+			return nil, err
+		}
+		covered, err := s.rollupsCover(ctx, symbol, s.rollupIntervals[i], rollups, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if !covered {
+			break
+		}
+		return rollupPoints(rollups), nil
+	}
+	return s.repository.GetDataPointsInRange(ctx, symbol, start, end)
+}
+
+// rollupsCover returns whether the rollups of an interval cover the oldest and latest data points of a symbol between
+// the start and end times. The rollups are maintained on every write, hence they only miss the data points stored
+// before they were enabled, such as after an upgrade, until the rollups are rebuilt.
+func (s *DefaultService) rollupsCover(ctx context.Context, symbol string, interval time.Duration, rollups []data.RollupEntity, start time.Time, end time.Time) (bool, error) {
+	oldest, err := s.repository.GetDataPoints(ctx, data.GetOHLCRequest{
+		Symbol:     symbol,
+		StartTime:  start.Unix(),
+		EndTime:    null.NewInt64(end.Unix()),
+		PageNumber: null.NewInt(1),
+		PageSize:   null.NewInt(1),
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(oldest) == 0 {
+		return true, nil
+	}
+	latest, err := s.repository.GetLatestDataPoints(ctx, symbol, end.Add(time.Second), 1)
+	if err != nil {
+		return false, err
+	}
+
+	buckets := map[int64]bool{}
+	for _, rollup := range rollups {
+		buckets[rollup.Time.Unix()] = true
+	}
+	for _, p := range append(oldest, latest...) {
+		if !buckets[bucketStart(p.Time, interval).Unix()] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// RebuildRollups recomputes the rollups of every symbol from its stored data points, such as after enabling the rollups
+// or changing their intervals. The rollups of the time ranges whose data points were expired by the retention policy
+// are kept. A failing symbol does not prevent the others from being processed, the first error encountered is returned.
+func (s *DefaultService) RebuildRollups(ctx context.Context) error {
+	intervals := s.rollupIntervals
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	symbols, err := s.repository.GetSymbols(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var firstErr error
+	for _, symbol := range symbols {
+		err = s.rebuildRollups(ctx, intervals, symbol, now)
+		if err != nil {
+			s.logger.Error("rollup rebuild failed", zap.String("symbol", symbol), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// rebuildRollups recomputes the rollups of a symbol up to the given time, a window of buckets of the coarsest interval
+// at a time. Every window starts at its first data point so that the finer rollups before it are left untouched.
+func (s *DefaultService) rebuildRollups(ctx context.Context, intervals []time.Duration, symbol string, now time.Time) error {
+	coarsest := intervals[len(intervals)-1]
+	next := time.Unix(0, 0)
+	for next.Before(now) {
+		first, err := s.repository.GetDataPoints(ctx, data.GetOHLCRequest{
+			Symbol:     symbol,
+			StartTime:  next.Unix(),
+			EndTime:    null.NewInt64(now.Unix()),
+			PageNumber: null.NewInt(1),
+			PageSize:   null.NewInt(1),
+		})
+		if err != nil {
+			return err
+		}
+		if len(first) == 0 {
+			return nil
+		}
+
+		end := bucketStart(first[0].Time, coarsest).Add(rollupRebuildWindowBuckets * coarsest)
+		err = s.repository.WithTransaction(ctx, func(repo repository.Repository) error {
+			return refreshRollups(ctx, repo, intervals, symbol, timeRange{start: first[0].Time, end: end.Add(-time.Second)})
+		})
+		if err != nil {
+			return err
+		}
+		next = end
+	}
+	return nil
+}
+
+// rollupPoints converts rollups to candles.
+func rollupPoints(rollups []data.RollupEntity) []data.OHLCEntity {
+	points := make([]data.OHLCEntity, len(rollups))
+	for i := range rollups {
+		points[i] = rollups[i].OHLCEntity()
+	}
+	return points
+}
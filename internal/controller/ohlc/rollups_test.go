@@ -0,0 +1,146 @@
+package ohlc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+func TestDefaultService_rollups(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := repository.NewMemoryRepository()
+	conf := config.Init()
+	s := newService(t, zap.NewNop(), repo, &s3.ClientMock{}, &sqs.ClientMock{}, &webhook.ClientMock{}, conf.OHLCConfig)
+
+	prices := func(rollup data.RollupEntity) []string {
+		return formatPrices(rollup.Open, rollup.High, rollup.Low, rollup.Close)
+	}
+	rollups := func(interval time.Duration) []data.RollupEntity {
+		rollups, err := repo.GetRollups(ctx, "BTC", int64(interval/time.Second), start, start.Add(24*time.Hour))
+		require.NoError(t, err)
+		return rollups
+	}
+
+	csv := "UNIX,SYMBOL,OPEN,HIGH,LOW,CLOSE\n"
+	for i := 0; i < 130; i++ {
+		price := 100 + i
+		csv += fmt.Sprintf("%d,BTC,%d,%d,%d,%d\n", start.Add(time.Duration(i)*time.Minute).Unix(), price, price+2, price-1, price+1)
+	}
+	upload, err := s.ProcessCSV(ctx, data.UploadRequest{}, strings.NewReader(csv), int64(len(csv)))
+	require.NoError(t, err)
+	require.Equal(t, data.ProcessingStatusCompleted, upload.Status)
+
+	// The rollups are updated along with the inserted data points.
+	fiveMinutes := rollups(5 * time.Minute)
+	require.Len(t, fiveMinutes, 26)
+	assert.Equal(t, []string{"100", "106", "99", "105"}, prices(fiveMinutes[0]))
+	hourly := rollups(time.Hour)
+	require.Len(t, hourly, 3)
+	assert.Equal(t, []string{"160", "221", "159", "220"}, prices(hourly[1]))
+	daily := rollups(24 * time.Hour)
+	require.Len(t, daily, 1)
+	assert.Equal(t, []string{"100", "231", "99", "230"}, prices(daily[0]))
+
+	late := start.Add(200 * time.Minute)
+	err = s.CreateDataPoints(ctx, "late.csv", [][]string{
+		{"UNIX", "SYMBOL", "OPEN", "HIGH", "LOW", "CLOSE"},
+		{fmt.Sprint(late.Unix()), "BTC", "300", "1000", "299", "301"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, rollups(time.Hour), 4)
+	daily = rollups(24 * time.Hour)
+	require.Len(t, daily, 1)
+	assert.Equal(t, []string{"100", "1000", "99", "301"}, prices(daily[0]))
+
+	// Resampled queries read the coarsest rollups their interval is a multiple of.
+	require.NoError(t, repo.UpsertRollups(ctx, []data.RollupEntity{{Symbol: "BTC", IntervalSeconds: 86400, Time: start, Open: price(1), High: price(1), Low: price(1), Close: price(1)}}))
+	candles, _, err := s.GetDataPoints(ctx, data.GetOHLCRequest{Symbol: "BTC", StartTime: start.Unix(), EndTime: null.NewInt64(start.Add(24*time.Hour - time.Second).Unix()), Interval: "1d"})
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, "1", candles[0].Open.String())
+	candles, _, err = s.GetDataPoints(ctx, data.GetOHLCRequest{Symbol: "BTC", StartTime: start.Unix(), EndTime: null.NewInt64(start.Add(24*time.Hour - time.Second).Unix()), Interval: "2h"})
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+	assert.Equal(t, []string{"100", "221", "99", "220"}, formatPrices(candles[0].Open, candles[0].High, candles[0].Low, candles[0].Close))
+
+	// Rolling an upload back removes its candles from the rollups.
+	_, err = s.RollbackUpload(ctx, upload.Filename, false)
+	require.NoError(t, err)
+	assert.Len(t, rollups(5*time.Minute), 1)
+	assert.Len(t, rollups(time.Hour), 1)
+	daily = rollups(24 * time.Hour)
+	require.Len(t, daily, 1)
+	assert.Equal(t, []string{"300", "1000", "299", "301"}, prices(daily[0]))
+}
+
+func TestDefaultService_RebuildRollups(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := repository.NewMemoryRepository()
+
+	var points []data.OHLCEntity
+	for _, day := range []int{0, 30} {
+		for i := 0; i < 10; i++ {
+			p := float64(100 + i)
+			points = append(points, data.OHLCEntity{
+				Time:   start.Add(time.Duration(day)*24*time.Hour + time.Duration(i)*time.Minute),
+				Symbol: "BTC",
+				Open:   price(p),
+				High:   price(p + 2),
+				Low:    price(p - 1),
+				Close:  price(p + 1),
+			})
+		}
+	}
+	require.NoError(t, repo.InsertDataPoints(ctx, points))
+	require.NoError(t, repo.UpsertRollups(ctx, []data.RollupEntity{
+		// The data points of the hour before the first data point were expired by the retention policy.
+		{Symbol: "BTC", IntervalSeconds: 3600, Time: start.Add(-time.Hour), Open: price(1), High: price(1), Low: price(1), Close: price(1)},
+		{Symbol: "BTC", IntervalSeconds: 300, Time: start, Open: price(1), High: price(1), Low: price(1), Close: price(1)},
+	}))
+
+	conf := config.Init()
+	s := newService(t, zap.NewNop(), repo, &s3.ClientMock{}, &sqs.ClientMock{}, &webhook.ClientMock{}, conf.OHLCConfig)
+
+	// Resampled queries read the data points until the rollups cover them.
+	day := data.GetOHLCRequest{Symbol: "BTC", StartTime: start.Unix(), EndTime: null.NewInt64(start.Add(24*time.Hour - time.Second).Unix()), Interval: "1d"}
+	candles, _, err := s.GetDataPoints(ctx, day)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, []string{"100", "111", "99", "110"}, formatPrices(candles[0].Open, candles[0].High, candles[0].Low, candles[0].Close))
+
+	require.NoError(t, s.RebuildRollups(ctx))
+
+	fiveMinutes, err := repo.GetRollups(ctx, "BTC", 300, time.Unix(0, 0), start.Add(60*24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, fiveMinutes, 4)
+	assert.Equal(t, []string{"100", "106", "99", "105"}, formatPrices(fiveMinutes[0].Open, fiveMinutes[0].High, fiveMinutes[0].Low, fiveMinutes[0].Close))
+	hourly, err := repo.GetRollups(ctx, "BTC", 3600, time.Unix(0, 0), start.Add(60*24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, hourly, 3)
+	assert.True(t, hourly[0].Time.Equal(start.Add(-time.Hour)), "the rollups without data points are kept")
+	daily, err := repo.GetRollups(ctx, "BTC", 86400, time.Unix(0, 0), start.Add(60*24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, daily, 2)
+	assert.True(t, daily[1].Time.Equal(start.Add(30*24*time.Hour)))
+
+	require.NoError(t, repo.UpsertRollups(ctx, []data.RollupEntity{{Symbol: "BTC", IntervalSeconds: 86400, Time: start, Open: price(1), High: price(1), Low: price(1), Close: price(1)}}))
+	candles, _, err = s.GetDataPoints(ctx, day)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, "1", candles[0].Open.String(), "the rollups are read once they cover the data points")
+}
@@ -1,29 +1,46 @@
 package ohlc
 
 import (
-	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/teezzan/candles/internal/config"
 	"github.com/teezzan/candles/internal/controller/ohlc/data"
 	E "github.com/teezzan/candles/internal/errors"
 	"github.com/teezzan/candles/internal/httputil"
 	"go.uber.org/zap"
 )
 
+const (
+	// sseHeartbeatInterval is the interval between two heartbeats of an event stream.
+	sseHeartbeatInterval = 15 * time.Second
+	// maxFormValueSize is the maximum size in bytes of a text field of a multipart form.
+	maxFormValueSize = 4096
+)
+
 // HTTPHandler is the HTTP handler for the ohlc service.
 type HTTPHandler struct {
-	logger      *zap.Logger
-	ohlcService Service
+	logger        *zap.Logger
+	ohlcService   Service
+	maxUploadSize int64
 }
 
 // NewHTTPHandler initializes a new HTTP Handler.
 func NewHTTPHandler(
 	logger *zap.Logger,
 	ohlcService Service,
+	ohlcConf config.OHLCConfig,
 ) *HTTPHandler {
 	return &HTTPHandler{
-		logger:      logger,
-		ohlcService: ohlcService,
+		logger:        logger,
+		ohlcService:   ohlcService,
+		maxUploadSize: int64(ohlcConf.MaxUploadSizeInMB) << 20,
 	}
 }
 
@@ -34,45 +51,112 @@ func (h *HTTPHandler) SetupRouter(r *gin.RouterGroup) error {
 	r.POST("/data", handler(h.processCSVHandler))
 	r.GET("/data", handler(h.getOHLCDataHandler))
 	r.GET("/generate_url", handler(h.generatePreSignedURLHandler))
+	r.POST("/generate_url", handler(h.generateConstrainedPreSignedURLHandler))
 	r.GET("/status/:filename", handler(h.getFileProcessingStatusHandler))
+	r.GET("/uploads", handler(h.getUploadsHandler))
+	r.GET("/uploads/events", handler(h.watchUploadsHandler))
+	r.POST("/uploads/multipart", handler(h.initiateMultipartUploadHandler))
+	r.POST("/uploads/:filename/multipart/parts", handler(h.getPartURLsHandler))
+	r.POST("/uploads/:filename/multipart/complete", handler(h.completeMultipartUploadHandler))
+	r.DELETE("/uploads/:filename/multipart", handler(h.abortMultipartUploadHandler))
+	r.GET("/uploads/:filename/events", handler(h.watchUploadHandler))
+	r.POST("/uploads/:filename/rollback", handler(h.rollbackUploadHandler))
+	r.POST("/uploads/:filename/retry", handler(h.retryUploadHandler))
+	r.POST("/uploads/:filename/cancel", handler(h.cancelUploadHandler))
+	r.GET("/uploads/:filename/webhooks", handler(h.getWebhookDeliveriesHandler))
+	r.GET("/rejected_messages", handler(h.getRejectedMessagesHandler))
+	r.POST("/quality", handler(h.generateDataQualityReportsHandler))
+	r.GET("/quality", handler(h.getDataQualityReportsHandler))
+	r.GET("/anomalies", handler(h.getAnomaliesHandler))
+	r.POST("/anomalies/:id/accept", handler(h.acceptAnomalyHandler))
+	r.DELETE("/anomalies/:id", handler(h.deleteAnomalyHandler))
 
 	return nil
 }
 
-// processCSVHandler processes the CSV file.
+// processCSVHandler processes the CSV file, synchronously unless requested otherwise or too large.
+// The multipart form is streamed, so its text fields must precede the file.
 //
 //	@Summary		Takes a CSV file upload and processes it
-//	@Description	The endpoint takes a small CSV file upload and processes it. Max file size is 30MB.
+//	@Description	The endpoint takes a CSV file upload and processes it. The maximum request size is configurable and defaults to 30MB. A file processed synchronously is responded with the number of rows inserted and rejected. Files uploaded with async=true, or above a configurable threshold of 10MB by default, are handed over to the S3 pipeline and processed asynchronously, in which case the endpoint responds with 202 Accepted and the filename to track the processing with through GET /status. The uploader and callback_url fields must precede the file in the form.
 //	@Accept			multipart/form-data
 //	@Produce		json
-//	@Param			file	formData	file	true	"CSV file to be processed"
-//	@Success		200
-//	@Failure		400	{object}	httputil.ErrorResponse
-//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Param			async			query		bool	false	"Whether to process the file asynchronously"
+//	@Param			uploader		formData	string	false	"Who uploads the file"
+//	@Param			callback_url	formData	string	false	"URL notified with a signed JSON payload once the file is processed"
+//	@Param			file			formData	file	true	"CSV file to be processed"
+//	@Success		200				{object}	data.UploadResponse
+//	@Success		202				{object}	data.UploadResponse
+//	@Failure		400				{object}	httputil.ErrorResponse
+//	@Failure		413				{object}	httputil.ErrorResponse
+//	@Failure		500				{object}	httputil.ErrorResponse
 //	@Router			/data [post]
 func (h *HTTPHandler) processCSVHandler(c *gin.Context) error {
-	file, err := c.FormFile("file")
-	if err != nil {
-		return httputil.BadRequest(c, err)
+	if c.Request.ContentLength > h.maxUploadSize {
+		return httputil.RequestEntityTooLarge(c, fmt.Errorf("request body larger than %d bytes", h.maxUploadSize))
 	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize)
 
-	src, err := file.Open()
+	reader, err := c.Request.MultipartReader()
 	if err != nil {
 		return httputil.BadRequest(c, err)
 	}
 
-	r := csv.NewReader(src)
-	csvData, err := r.ReadAll()
-	if err != nil {
+	var form data.UploadRequest
+	if err := c.ShouldBindQuery(&form); err != nil {
 		return httputil.BadRequest(c, err)
 	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return httputil.BadRequest(c, errors.New("file is required"))
+		}
+		if err != nil {
+			return h.uploadError(c, err)
+		}
 
-	err = h.ohlcService.CreateDataPoints(c, csvData)
-	if err != nil {
-		return err
+		switch part.FormName() {
+		case "uploader":
+			form.Uploader, err = readFormValue(part)
+		case "callback_url":
+			form.CallbackURL, err = readFormValue(part)
+		case "file":
+			result, err := h.ohlcService.ProcessCSV(c, form, part, c.Request.ContentLength)
+			if err != nil {
+				return h.uploadError(c, err)
+			}
+			if result.Status == data.ProcessingStatusQueued {
+				c.Header("Location", "/status/"+result.Filename)
+				return httputil.Accepted(c, result)
+			}
+			return httputil.OK(c, result)
+		}
+		if err != nil {
+			return h.uploadError(c, err)
+		}
 	}
+}
 
-	return httputil.OK(c, nil)
+// uploadError responds with 413 Request Entity Too Large if an upload exceeded the maximum upload size
+// and returns other errors as is.
+func (h *HTTPHandler) uploadError(c *gin.Context, err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return httputil.RequestEntityTooLarge(c, fmt.Errorf("request body larger than %d bytes", maxBytesErr.Limit))
+	}
+	return err
+}
+
+// readFormValue reads a text field of a multipart form.
+func readFormValue(part *multipart.Part) (string, error) {
+	b, err := io.ReadAll(io.LimitReader(part, maxFormValueSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(b) > maxFormValueSize {
+		return "", E.NewErrInvalidArgument(fmt.Sprintf("%s must be at most %d bytes", part.FormName(), maxFormValueSize))
+	}
+	return string(b), nil
 }
 
 // getOHLCPointsHandler gets the OHLC points for the given time range.
@@ -80,11 +164,13 @@ func (h *HTTPHandler) processCSVHandler(c *gin.Context) error {
 //	@Summary		returns the OHLC points for the given time range
 //	@Description	The endpoint returns the OHLC points for a particular Symbol for  the given time range
 //	@Produce		json
-//	@Param			symbol		query		string	true	"This is the symbol of the OHLC token"			example(BTC)
-//	@Param			from		query		string	true	"UNIX time representation of the start time"	example(10344553332)
-//	@Param			to			query		string	false	"UNIX time representation of the end time"		example(101019283847)
-//	@Param			page		query		int		false	"page of response"								example(1)
-//	@Param			page_size	query		int		false	"Number of OHLC datapoints per page"			example(5)
+//	@Param			symbol		query		string	true	"This is the symbol of the OHLC token"							example(BTC)
+//	@Param			from		query		string	true	"UNIX time representation of the start time"					example(10344553332)
+//	@Param			to			query		string	false	"UNIX time representation of the end time"						example(101019283847)
+//	@Param			page		query		int		false	"page of response"												example(1)
+//	@Param			page_size	query		int		false	"Number of OHLC datapoints per page"							example(5)
+//	@Param			interval	query		string	false	"Resample the OHLC datapoints into candles of this interval"	example(1h)
+//	@Param			fill		query		string	false	"How missing candles are synthesised when an interval is set"	Enums(none, previous, null, linear)
 //	@Success		200			{object}	data.GetOHLCResponse
 //	@Failure		400			{object}	httputil.ErrorResponse
 //	@Failure		500			{object}	httputil.ErrorResponse
@@ -116,12 +202,18 @@ func (h *HTTPHandler) getOHLCDataHandler(c *gin.Context) error {
 //
 //	@Summary		Generates a pre-signed URL for the given file name for uploading on S3
 //	@Description	The endpoint generates a pre-signed URL for the given file name for uploading on S3, It supports huge files
-//	@Success		200	{object}	data.GeneratePresignedURLResponse
-//	@Failure		400	{object}	httputil.ErrorResponse
-//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Param			uploader		query		string	false	"Who uploads the file"													example(ops-team)
+//	@Param			callback_url	query		string	false	"URL notified with a signed JSON payload once the file is processed"	example(https://etl.example.com/hooks/candles)
+//	@Success		200				{object}	data.GeneratePresignedURLResponse
+//	@Failure		400				{object}	httputil.ErrorResponse
+//	@Failure		500				{object}	httputil.ErrorResponse
 //	@Router			/generate_url [get]
 func (h *HTTPHandler) generatePreSignedURLHandler(c *gin.Context) error {
-	result, err := h.ohlcService.GeneratePreSignedURL(c)
+	var query data.GeneratePresignedURLRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	result, err := h.ohlcService.GeneratePreSignedURL(c, query)
 	if err != nil {
 		return err
 	}
@@ -129,6 +221,138 @@ func (h *HTTPHandler) generatePreSignedURLHandler(c *gin.Context) error {
 	return httputil.OK(c, result)
 }
 
+// generateConstrainedPreSignedURLHandler generates a pre-signed URL constrained by the upload options of the request body.
+//
+//	@Summary		Generates a pre-signed URL with upload constraints and processing options for uploading on S3
+//	@Description	The endpoint generates a pre-signed URL for uploading a file on S3. The expected size, checksums and tags are enforced by S3, the returned headers having to be sent with the upload. The format and column mapping are stored with the file and applied when it is processed.
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		data.GeneratePresignedURLRequest	true	"Upload constraints and processing options"
+//	@Success		200		{object}	data.GeneratePresignedURLResponse
+//	@Failure		400		{object}	httputil.ErrorResponse
+//	@Failure		500		{object}	httputil.ErrorResponse
+//	@Router			/generate_url [post]
+func (h *HTTPHandler) generateConstrainedPreSignedURLHandler(c *gin.Context) error {
+	var payload data.GeneratePresignedURLRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	result, err := h.ohlcService.GeneratePreSignedURL(c, payload)
+	if err != nil {
+		return err
+	}
+
+	return httputil.OK(c, result)
+}
+
+// initiateMultipartUploadHandler initiates a multipart upload on S3.
+//
+//	@Summary		Initiates a multipart upload on S3
+//	@Description	The endpoint initiates a multipart upload on S3 for files larger than a single presigned URL allows, and returns the presigned URLs of its first parts. Each part but the last must be at least 5MB and the ETag returned by S3 for each part must be kept to complete the upload.
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		data.InitiateMultipartUploadRequest	true	"Number of parts, upload constraints and processing options"
+//	@Success		200		{object}	data.MultipartUploadResponse
+//	@Failure		400		{object}	httputil.ErrorResponse
+//	@Failure		500		{object}	httputil.ErrorResponse
+//	@Router			/uploads/multipart [post]
+func (h *HTTPHandler) initiateMultipartUploadHandler(c *gin.Context) error {
+	var payload data.InitiateMultipartUploadRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	result, err := h.ohlcService.InitiateMultipartUpload(c, payload)
+	if err != nil {
+		return err
+	}
+
+	return httputil.OK(c, result)
+}
+
+// getPartURLsHandler hands out the presigned URLs of parts of a multipart upload.
+//
+//	@Summary		Returns the presigned URLs of parts of a multipart upload
+//	@Description	The endpoint returns the presigned URLs of the requested parts of a multipart upload, to resume an interrupted upload or upload more parts
+//	@Accept			json
+//	@Produce		json
+//	@Param			filename	path		string					true	"This is the filename"	example("7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv")
+//	@Param			payload		body		data.GetPartURLsRequest	true	"Upload ID and part numbers"
+//	@Success		200			{object}	data.MultipartUploadResponse
+//	@Failure		400			{object}	httputil.ErrorResponse
+//	@Failure		404			{object}	httputil.ErrorResponse
+//	@Failure		500			{object}	httputil.ErrorResponse
+//	@Router			/uploads/{filename}/multipart/parts [post]
+func (h *HTTPHandler) getPartURLsHandler(c *gin.Context) error {
+	var payload data.GetPartURLsRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	result, err := h.ohlcService.GetPartURLs(c, c.Param("filename"), payload)
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return httputil.NotFound(c, err)
+		}
+		return err
+	}
+
+	return httputil.OK(c, result)
+}
+
+// completeMultipartUploadHandler completes a multipart upload.
+//
+//	@Summary		Completes a multipart upload
+//	@Description	The endpoint assembles the uploaded parts of a multipart upload into the file, which is then processed like any file uploaded on S3
+//	@Accept			json
+//	@Produce		json
+//	@Param			filename	path	string								true	"This is the filename"	example("7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv")
+//	@Param			payload		body	data.CompleteMultipartUploadRequest	true	"Upload ID and uploaded parts"
+//	@Success		204
+//	@Failure		400	{object}	httputil.ErrorResponse
+//	@Failure		404	{object}	httputil.ErrorResponse
+//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Router			/uploads/{filename}/multipart/complete [post]
+func (h *HTTPHandler) completeMultipartUploadHandler(c *gin.Context) error {
+	var payload data.CompleteMultipartUploadRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	err := h.ohlcService.CompleteMultipartUpload(c, c.Param("filename"), payload)
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return httputil.NotFound(c, err)
+		}
+		return err
+	}
+	return httputil.NoContent(c)
+}
+
+// abortMultipartUploadHandler aborts a multipart upload.
+//
+//	@Summary		Aborts a multipart upload
+//	@Description	The endpoint aborts a multipart upload, discarding its uploaded parts. The file is marked as CANCELLED.
+//	@Produce		json
+//	@Param			filename	path	string	true	"This is the filename"	example("7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv")
+//	@Param			upload_id	query	string	true	"ID of the multipart upload"
+//	@Success		204
+//	@Failure		400	{object}	httputil.ErrorResponse
+//	@Failure		404	{object}	httputil.ErrorResponse
+//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Router			/uploads/{filename}/multipart [delete]
+func (h *HTTPHandler) abortMultipartUploadHandler(c *gin.Context) error {
+	var query data.AbortMultipartUploadRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	err := h.ohlcService.AbortMultipartUpload(c, c.Param("filename"), query.UploadID)
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return httputil.NotFound(c, err)
+		}
+		return err
+	}
+	return httputil.NoContent(c)
+}
+
 // getFileProcessingStatusHandler gets the file processing status.
 //
 //	@Summary		returns the status of the file processing
@@ -150,3 +374,400 @@ func (h *HTTPHandler) getFileProcessingStatusHandler(c *gin.Context) error {
 	}
 	return httputil.OK(c, status)
 }
+
+// getUploadsHandler lists the uploaded files and their processing status.
+//
+//	@Summary		returns the uploaded files and their processing status
+//	@Description	The endpoint lists the uploaded files and their processing status, optionally filtered by status, uploader, symbol touched and creation time range
+//	@Produce		json
+//	@Param			status		query		string	false	"Processing status of the files"				Enums(AWAITING_UPLOAD, QUEUED, IN_PROGRESS, RETRYING, COMPLETED, FAILED, CANCELLED, ROLLED_BACK)
+//	@Param			uploader	query		string	false	"Who uploaded the files"						example(ops-team)
+//	@Param			symbol		query		string	false	"Symbol touched by the files"					example(BTC)
+//	@Param			from		query		string	false	"UNIX time representation of the start time"	example(10344553332)
+//	@Param			to			query		string	false	"UNIX time representation of the end time"		example(101019283847)
+//	@Param			sort_by		query		string	false	"Field the files are sorted by"					Enums(created_at, updated_at, file_name, status)
+//	@Param			order		query		string	false	"Sort order"									Enums(asc, desc)
+//	@Param			page		query		int		false	"page of response"								example(1)
+//	@Param			page_size	query		int		false	"Number of files per page"						example(5)
+//	@Success		200			{object}	data.GetUploadsResponse
+//	@Failure		400			{object}	httputil.ErrorResponse
+//	@Failure		500			{object}	httputil.ErrorResponse
+//	@Router			/uploads [get]
+func (h *HTTPHandler) getUploadsHandler(c *gin.Context) error {
+	var query data.GetUploadsRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	uploads, page, err := h.ohlcService.GetUploads(c, query)
+	if err != nil {
+		return err
+	}
+	if uploads == nil {
+		uploads = []data.ProcessingStatusEntity{}
+	}
+
+	return httputil.OK(c, data.GetUploadsResponse{
+		Uploads: uploads,
+		Page:    *page,
+	})
+}
+
+// watchUploadHandler streams the status transitions and progress of an uploaded file.
+//
+//	@Summary		Streams the processing status of an uploaded file
+//	@Description	The endpoint streams the status transitions and progress of an uploaded file as Server-Sent Events. The current status is sent first and the stream closes once the file reaches a terminal status.
+//	@Produce		text/event-stream
+//	@Param			filename	path		string	true	"This is the filename"	example("7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv")
+//	@Success		200			{object}	data.ProcessingStatusEntity
+//	@Failure		404			{object}	httputil.ErrorResponse
+//	@Failure		500			{object}	httputil.ErrorResponse
+//	@Router			/uploads/{filename}/events [get]
+func (h *HTTPHandler) watchUploadHandler(c *gin.Context) error {
+	filename := c.Param("filename")
+	events, stop := h.ohlcService.WatchUploads(filename, "")
+	defer stop()
+
+	status, err := h.ohlcService.GetProcessingStatus(c, filename)
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return httputil.NotFound(c, err)
+		}
+		return err
+	}
+	h.streamUploadEvents(c, events, status)
+	return nil
+}
+
+// watchUploadsHandler streams the status transitions and progress of the uploaded files.
+//
+//	@Summary		Streams the processing status of the uploaded files
+//	@Description	The endpoint streams the status transitions and progress of the uploaded files, optionally of a single uploader, as Server-Sent Events.
+//	@Produce		text/event-stream
+//	@Param			uploader	query		string	false	"Who uploaded the files"	example(ops-team)
+//	@Success		200			{object}	data.ProcessingStatusEntity
+//	@Failure		400			{object}	httputil.ErrorResponse
+//	@Failure		500			{object}	httputil.ErrorResponse
+//	@Router			/uploads/events [get]
+func (h *HTTPHandler) watchUploadsHandler(c *gin.Context) error {
+	var query data.WatchUploadsRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	events, stop := h.ohlcService.WatchUploads("", query.Uploader)
+	defer stop()
+
+	h.streamUploadEvents(c, events, nil)
+	return nil
+}
+
+// streamUploadEvents streams upload events as Server-Sent Events, named after their type, until the client disconnects.
+// When an upload is given, it is sent first and the stream closes once it reaches a terminal status. Its status is
+// then also read on every heartbeat in case an event was missed or the file is processed by another instance.
+func (h *HTTPHandler) streamUploadEvents(c *gin.Context, events <-chan data.UploadEvent, upload *data.ProcessingStatusEntity) {
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	if upload != nil {
+		c.SSEvent(string(data.UploadEventTypeStatus), upload)
+		if upload.Status.IsTerminal() {
+			c.Writer.Flush()
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		c.Writer.Flush()
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			c.SSEvent(string(e.Type), &e.Upload)
+			if upload != nil && e.Upload.Status.IsTerminal() {
+				c.Writer.Flush()
+				return
+			}
+		case <-heartbeat.C:
+			if upload != nil {
+				status, err := h.ohlcService.GetProcessingStatus(c, upload.FileName)
+				if err != nil {
+					return
+				}
+				if status.Status.IsTerminal() {
+					c.SSEvent(string(data.UploadEventTypeStatus), status)
+					c.Writer.Flush()
+					return
+				}
+			}
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+		}
+	}
+}
+
+// rollbackUploadHandler deletes the data points originating from an uploaded file.
+//
+//	@Summary		Deletes all OHLC points originating from an uploaded file
+//	@Description	The endpoint deletes all OHLC points inserted from an uploaded file and marks its processing status as ROLLED_BACK. In dry-run mode, it only returns the number of OHLC points that would be deleted.
+//	@Produce		json
+//	@Param			filename	path		string	true	"This is the filename"								example("7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv")
+//	@Param			dry_run		query		bool	false	"Only count the OHLC points that would be deleted"	example(true)
+//	@Success		200			{object}	data.RollbackUploadResponse
+//	@Failure		400			{object}	httputil.ErrorResponse
+//	@Failure		404			{object}	httputil.ErrorResponse
+//	@Failure		500			{object}	httputil.ErrorResponse
+//	@Router			/uploads/{filename}/rollback [post]
+func (h *HTTPHandler) rollbackUploadHandler(c *gin.Context) error {
+	var query data.RollbackUploadRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	result, err := h.ohlcService.RollbackUpload(c, c.Param("filename"), query.DryRun)
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return httputil.NotFound(c, err)
+		}
+		return err
+	}
+	return httputil.OK(c, result)
+}
+
+// retryUploadHandler re-runs the processing of an uploaded file.
+//
+//	@Summary		Re-runs the processing of a file uploaded on S3
+//	@Description	The endpoint re-runs the processing of a failed, cancelled or rolled back file still stored on S3. The file is processed in the background with the RETRYING status.
+//	@Produce		json
+//	@Param			filename	path	string	true	"This is the filename"	example("7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv")
+//	@Success		204
+//	@Failure		400	{object}	httputil.ErrorResponse
+//	@Failure		404	{object}	httputil.ErrorResponse
+//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Router			/uploads/{filename}/retry [post]
+func (h *HTTPHandler) retryUploadHandler(c *gin.Context) error {
+	err := h.ohlcService.RetryUpload(c, c.Param("filename"))
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return httputil.NotFound(c, err)
+		}
+		return err
+	}
+	return httputil.NoContent(c)
+}
+
+// cancelUploadHandler cancels the processing of an uploaded file.
+//
+//	@Summary		Cancels the processing of an uploaded file
+//	@Description	The endpoint cancels the processing of a queued or in-flight file. The file is marked as CANCELLED once its processing stops.
+//	@Produce		json
+//	@Param			filename	path	string	true	"This is the filename"	example("7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv")
+//	@Success		204
+//	@Failure		400	{object}	httputil.ErrorResponse
+//	@Failure		404	{object}	httputil.ErrorResponse
+//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Router			/uploads/{filename}/cancel [post]
+func (h *HTTPHandler) cancelUploadHandler(c *gin.Context) error {
+	err := h.ohlcService.CancelUpload(c, c.Param("filename"))
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return httputil.NotFound(c, err)
+		}
+		return err
+	}
+	return httputil.NoContent(c)
+}
+
+// getWebhookDeliveriesHandler gets the webhook delivery log of an uploaded file.
+//
+//	@Summary		returns the webhook delivery log of an uploaded file
+//	@Description	The endpoint returns every attempt to deliver the webhooks of an uploaded file, oldest first
+//	@Produce		json
+//	@Param			filename	path		string	true	"This is the filename"	example("7d2f5f5c-0b1a-4b1e-9c5e-1c2d3e4f5g6h.csv")
+//	@Success		200			{object}	data.GetWebhookDeliveriesResponse
+//	@Failure		404			{object}	httputil.ErrorResponse
+//	@Failure		500			{object}	httputil.ErrorResponse
+//	@Router			/uploads/{filename}/webhooks [get]
+func (h *HTTPHandler) getWebhookDeliveriesHandler(c *gin.Context) error {
+	deliveries, err := h.ohlcService.GetWebhookDeliveries(c, c.Param("filename"))
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return httputil.NotFound(c, err)
+		}
+		return err
+	}
+	return httputil.OK(c, data.GetWebhookDeliveriesResponse{
+		Deliveries: deliveries,
+	})
+}
+
+// getRejectedMessagesHandler gets the SQS messages rejected by the consumer.
+//
+//	@Summary		returns the SQS messages rejected by the consumer
+//	@Description	The endpoint returns the SQS messages not notifying an upload to the bucket, most recent first. A message is rejected if it does not carry an S3 event, or if any of its records is not an object creation in the bucket matching the upload key filter.
+//	@Produce		json
+//	@Param			page		query		int	false	"page of response"				example(1)
+//	@Param			page_size	query		int	false	"Number of messages per page"	example(5)
+//	@Success		200			{object}	data.GetRejectedMessagesResponse
+//	@Failure		400			{object}	httputil.ErrorResponse
+//	@Failure		500			{object}	httputil.ErrorResponse
+//	@Router			/rejected_messages [get]
+func (h *HTTPHandler) getRejectedMessagesHandler(c *gin.Context) error {
+	var query data.GetRejectedMessagesRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	messages, page, err := h.ohlcService.GetRejectedMessages(c, query)
+	if err != nil {
+		return err
+	}
+
+	resp := data.GetRejectedMessagesResponse{
+		Messages: messages,
+		Page:     *page,
+	}
+	if resp.Messages == nil {
+		resp.Messages = []data.RejectedMessageEntity{}
+	}
+
+	return httputil.OK(c, resp)
+}
+
+// generateDataQualityReportsHandler scans the stored data points and generates the data quality reports.
+//
+//	@Summary		Scans the OHLC points of a symbol and reports the data completeness per day
+//	@Description	The endpoint scans the stored OHLC points of a symbol for the expected interval and reports, per day, the missing buckets, duplicate timestamps and coverage percentage. At most 366 days are scanned at once. The reports are persisted.
+//	@Produce		json
+//	@Param			symbol		query		string	true	"This is the symbol of the OHLC token"			example(BTC)
+//	@Param			interval	query		string	true	"Expected interval between OHLC points"			example(1m)
+//	@Param			from		query		string	true	"UNIX time representation of the start time"	example(10344553332)
+//	@Param			to			query		string	false	"UNIX time representation of the end time"		example(101019283847)
+//	@Success		200			{object}	data.GetDataQualityResponse
+//	@Failure		400			{object}	httputil.ErrorResponse
+//	@Failure		500			{object}	httputil.ErrorResponse
+//	@Router			/quality [post]
+func (h *HTTPHandler) generateDataQualityReportsHandler(c *gin.Context) error {
+	var query data.DataQualityRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	reports, err := h.ohlcService.GenerateDataQualityReports(c, query)
+	if err != nil {
+		return err
+	}
+
+	return httputil.OK(c, data.GetDataQualityResponse{Reports: reports})
+}
+
+// getDataQualityReportsHandler gets the persisted data quality reports.
+//
+//	@Summary		returns the data quality reports of a symbol for the given time range
+//	@Description	The endpoint returns the persisted data quality reports of a symbol for the days in the given time range
+//	@Produce		json
+//	@Param			symbol	query		string	true	"This is the symbol of the OHLC token"			example(BTC)
+//	@Param			from	query		string	true	"UNIX time representation of the start time"	example(10344553332)
+//	@Param			to		query		string	false	"UNIX time representation of the end time"		example(101019283847)
+//	@Success		200		{object}	data.GetDataQualityResponse
+//	@Failure		400		{object}	httputil.ErrorResponse
+//	@Failure		500		{object}	httputil.ErrorResponse
+//	@Router			/quality [get]
+func (h *HTTPHandler) getDataQualityReportsHandler(c *gin.Context) error {
+	var query data.DataQualityRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	reports, err := h.ohlcService.GetDataQualityReports(c, query)
+	if err != nil {
+		return err
+	}
+
+	return httputil.OK(c, data.GetDataQualityResponse{Reports: reports})
+}
+
+// getAnomaliesHandler gets the suspicious data points awaiting review.
+//
+//	@Summary		returns the suspicious OHLC points detected during ingestion
+//	@Description	The endpoint returns the flagged and quarantined OHLC points awaiting review
+//	@Produce		json
+//	@Param			symbol		query		string	false	"This is the symbol of the OHLC token"	example(BTC)
+//	@Param			status		query		string	false	"Review status of the OHLC point"		Enums(FLAGGED, QUARANTINED)
+//	@Param			page		query		int		false	"page of response"						example(1)
+//	@Param			page_size	query		int		false	"Number of OHLC points per page"		example(5)
+//	@Success		200			{object}	data.GetAnomaliesResponse
+//	@Failure		400			{object}	httputil.ErrorResponse
+//	@Failure		500			{object}	httputil.ErrorResponse
+//	@Router			/anomalies [get]
+func (h *HTTPHandler) getAnomaliesHandler(c *gin.Context) error {
+	var query data.GetAnomaliesRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	anomalies, page, err := h.ohlcService.GetAnomalies(c, query)
+	if err != nil {
+		return err
+	}
+
+	resp := data.GetAnomaliesResponse{
+		Anomalies: anomalies,
+		Page:      *page,
+	}
+	if resp.Anomalies == nil {
+		resp.Anomalies = []data.AnomalyEntity{}
+	}
+
+	return httputil.OK(c, resp)
+}
+
+// acceptAnomalyHandler accepts a suspicious data point.
+//
+//	@Summary		Accepts a suspicious OHLC point
+//	@Description	The endpoint accepts a suspicious OHLC point. A quarantined point is stored, a flagged point is kept.
+//	@Produce		json
+//	@Param			id	path	int	true	"ID of the suspicious OHLC point"	example(1)
+//	@Success		204
+//	@Failure		400	{object}	httputil.ErrorResponse
+//	@Failure		404	{object}	httputil.ErrorResponse
+//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Router			/anomalies/{id}/accept [post]
+func (h *HTTPHandler) acceptAnomalyHandler(c *gin.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	err = h.ohlcService.AcceptAnomaly(c, id)
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return httputil.NotFound(c, err)
+		}
+		return err
+	}
+	return httputil.NoContent(c)
+}
+
+// deleteAnomalyHandler deletes a suspicious data point.
+//
+//	@Summary		Deletes a suspicious OHLC point
+//	@Description	The endpoint discards a suspicious OHLC point. A flagged point is also removed from the stored OHLC points.
+//	@Produce		json
+//	@Param			id	path	int	true	"ID of the suspicious OHLC point"	example(1)
+//	@Success		204
+//	@Failure		400	{object}	httputil.ErrorResponse
+//	@Failure		404	{object}	httputil.ErrorResponse
+//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Router			/anomalies/{id} [delete]
+func (h *HTTPHandler) deleteAnomalyHandler(c *gin.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httputil.BadRequest(c, err)
+	}
+	err = h.ohlcService.DeleteAnomaly(c, id)
+	if err != nil {
+		if E.IsErrEntityNotFound(err) {
+			return httputil.NotFound(c, err)
+		}
+		return err
+	}
+	return httputil.NoContent(c)
+}
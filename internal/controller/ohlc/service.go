@@ -3,6 +3,7 @@ package ohlc
 
 import (
 	"context"
+	"io"
 
 	"github.com/teezzan/candles/internal/controller/ohlc/data"
 )
@@ -11,12 +12,35 @@ import (
 
 // Service defines the ohlc service.
 type Service interface {
-	CreateDataPoints(ctx context.Context, dataPoints [][]string) error
+	CreateDataPoints(ctx context.Context, filename string, dataPoints [][]string) error
+	ProcessCSV(ctx context.Context, payload data.UploadRequest, r io.Reader, size int64) (*data.UploadResponse, error)
 	GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, *int, error)
-	GeneratePreSignedURL(ctx context.Context) (*data.GeneratePresignedURLResponse, error)
-	GetAndProcessSQSMessage(ctx context.Context) error
+	GeneratePreSignedURL(ctx context.Context, payload data.GeneratePresignedURLRequest) (*data.GeneratePresignedURLResponse, error)
+	InitiateMultipartUpload(ctx context.Context, payload data.InitiateMultipartUploadRequest) (*data.MultipartUploadResponse, error)
+	GetPartURLs(ctx context.Context, filename string, payload data.GetPartURLsRequest) (*data.MultipartUploadResponse, error)
+	CompleteMultipartUpload(ctx context.Context, filename string, payload data.CompleteMultipartUploadRequest) error
+	AbortMultipartUpload(ctx context.Context, filename string, uploadID string) error
+	AbortStaleMultipartUploads(ctx context.Context, hours int) error
+	ConsumeSQSMessages(ctx context.Context) error
+	GetRejectedMessages(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, *int, error)
 	DownloadAndProcessCSV(ctx context.Context, filename string) error
 	UpdateProcessingStatus(ctx context.Context, filename string, status data.ProcessingStatus, err error) error
 	DeleteStaleProcessingStatus(ctx context.Context, days int) error
+	MaintainPartitions(ctx context.Context, monthsAhead int, retentionInMonths int) error
 	GetProcessingStatus(ctx context.Context, filename string) (*data.ProcessingStatusEntity, error)
+	GetUploads(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, *int, error)
+	WatchUploads(filename string, uploader string) (<-chan data.UploadEvent, func())
+	RollbackUpload(ctx context.Context, filename string, dryRun bool) (*data.RollbackUploadResponse, error)
+	RetryUpload(ctx context.Context, filename string) error
+	CancelUpload(ctx context.Context, filename string) error
+	GetWebhookDeliveries(ctx context.Context, filename string) ([]data.WebhookDeliveryEntity, error)
+	WaitForWebhookDeliveries(ctx context.Context) error
+	GenerateDataQualityReports(ctx context.Context, payload data.DataQualityRequest) ([]data.DataQualityEntity, error)
+	GetDataQualityReports(ctx context.Context, payload data.DataQualityRequest) ([]data.DataQualityEntity, error)
+	RunDataQualityCheck(ctx context.Context) error
+	ApplyRetentionPolicy(ctx context.Context) error
+	RebuildRollups(ctx context.Context) error
+	GetAnomalies(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, *int, error)
+	AcceptAnomaly(ctx context.Context, id int64) error
+	DeleteAnomaly(ctx context.Context, id int64) error
 }
@@ -3,19 +3,24 @@ package ohlc
 import (
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/teezzan/candles/internal/client/s3"
 	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
 	"github.com/teezzan/candles/internal/config"
 	"github.com/teezzan/candles/internal/controller/ohlc/data"
 	"github.com/teezzan/candles/internal/controller/ohlc/repository"
 	E "github.com/teezzan/candles/internal/errors"
 	"github.com/teezzan/candles/internal/null"
+	"github.com/teezzan/candles/internal/pubsub"
 	"github.com/teezzan/candles/internal/util"
 	"go.uber.org/zap"
 )
@@ -23,66 +28,283 @@ import (
 var _ Service = (*DefaultService)(nil)
 
 type DefaultService struct {
-	logger               *zap.Logger
-	repository           repository.Repository
-	s3Client             s3.Client
-	sqsClient            sqs.Client
-	discardInCompleteRow bool
-	defaulDataPointLimit int
+	logger                 *zap.Logger
+	repository             repository.Repository
+	s3Client               s3.Client
+	sqsClient              sqs.Client
+	webhookClient          webhook.Client
+	discardInCompleteRow   bool
+	defaulDataPointLimit   int
+	dataQualitySymbols     []string
+	dataQualityInterval    string
+	anomalyAction          data.AnomalyAction
+	anomalyDetector        anomalyDetector
+	ingestionMode          data.IngestionMode
+	ingestionBatchSize     int
+	progressUpdateInterval time.Duration
+	asyncUploadThreshold   int64
+	workerCount            int
+	workerShutdownTimeout  time.Duration
+	uploadKeyPrefix        string
+	uploadKeySuffix        string
+	jobs                   *jobRegistry
+	uploadEvents           *pubsub.Broker[data.UploadEvent]
+	webhookURLs            []string
+	webhookMaxAttempts     int
+	webhookBackoff         time.Duration
+	webhookDeliveries      sync.WaitGroup
+	retentionPolicy        *data.RetentionPolicy
+	rollupIntervals        []time.Duration
+	pricePrecisions        *data.PricePrecisions
 }
 
+// NewService initializes a new default service. It returns an error if the configuration is invalid.
 func NewService(
 	logger *zap.Logger,
 	repository repository.Repository,
 	s3Client s3.Client,
 	sqsClient sqs.Client,
+	webhookClient webhook.Client,
 	ohlcConf config.OHLCConfig,
-) *DefaultService {
+) (*DefaultService, error) {
+	anomalyAction := data.AnomalyAction(ohlcConf.AnomalyAction)
+	if !anomalyAction.IsValid() {
+		return nil, E.NewErrInvalidArgument(fmt.Sprintf("invalid anomaly action %q", ohlcConf.AnomalyAction))
+	}
+	retentionPolicy, err := data.ParseRetentionPolicy(ohlcConf.RetentionRules)
+	if err != nil {
+		return nil, err
+	}
+	rollupIntervals, err := data.ParseRollupIntervals(ohlcConf.RollupIntervals)
+	if err != nil {
+		return nil, err
+	}
+	pricePrecisions, err := data.ParsePricePrecisions(ohlcConf.PricePrecisions)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DefaultService{
-		logger:               logger,
-		repository:           repository,
-		discardInCompleteRow: ohlcConf.DiscardInCompleteRow,
-		defaulDataPointLimit: ohlcConf.DefaultDataPointLimit,
-		s3Client:             s3Client,
-		sqsClient:            sqsClient,
+		logger:                 logger,
+		repository:             repository,
+		discardInCompleteRow:   ohlcConf.DiscardInCompleteRow,
+		defaulDataPointLimit:   ohlcConf.DefaultDataPointLimit,
+		dataQualitySymbols:     ohlcConf.DataQualitySymbols,
+		dataQualityInterval:    ohlcConf.DataQualityInterval,
+		anomalyAction:          anomalyAction,
+		ingestionMode:          data.IngestionMode(ohlcConf.IngestionMode),
+		ingestionBatchSize:     ohlcConf.IngestionBatchSize,
+		progressUpdateInterval: time.Duration(ohlcConf.ProgressUpdateIntervalInSeconds) * time.Second,
+		asyncUploadThreshold:   int64(ohlcConf.AsyncUploadThresholdInMB) << 20,
+		workerCount:            ohlcConf.WorkerCount,
+		workerShutdownTimeout:  time.Duration(ohlcConf.WorkerShutdownTimeoutInSeconds) * time.Second,
+		uploadKeyPrefix:        ohlcConf.UploadKeyPrefix,
+		uploadKeySuffix:        ohlcConf.UploadKeySuffix,
+		jobs:                   newJobRegistry(),
+		uploadEvents:           pubsub.NewBroker[data.UploadEvent](uploadEventBufferSize),
+		webhookURLs:            ohlcConf.WebhookURLs,
+		webhookMaxAttempts:     ohlcConf.WebhookMaxAttempts,
+		webhookBackoff:         time.Duration(ohlcConf.WebhookBackoffInSeconds) * time.Second,
+		retentionPolicy:        retentionPolicy,
+		rollupIntervals:        rollupIntervals,
+		pricePrecisions:        pricePrecisions,
+		s3Client:               s3Client,
+		sqsClient:              sqsClient,
+		webhookClient:          webhookClient,
+		anomalyDetector: anomalyDetector{
+			maxJumpPercent: ohlcConf.AnomalyMaxJumpPercent,
+			zScore:         ohlcConf.AnomalyZScore,
+			windowSize:     ohlcConf.AnomalyWindowSize,
+		},
+	}, nil
+}
+
+// rowReader reads the rows of a CSV file one at a time. It is implemented by *csv.Reader.
+type rowReader interface {
+	Read() ([]string, error)
+}
+
+// sliceRowReader reads the rows of an already parsed CSV file.
+type sliceRowReader struct {
+	rows [][]string
+}
+
+// Read implements rowReader.
+func (r *sliceRowReader) Read() ([]string, error) {
+	if len(r.rows) == 0 {
+		return nil, io.EOF
 	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	return row, nil
 }
 
 // CreateDataPoints creates OHLCEntities from a 2D array of strings and inserts them into the repository.
+// See ingestRows for the details.
+func (s *DefaultService) CreateDataPoints(ctx context.Context, filename string, dataPoints [][]string) error {
+	return s.ingestRows(ctx, filename, &sliceRowReader{rows: dataPoints}, nil)
+}
+
+// ingestCSV streams a CSV file of `size` bytes from `r` into the repository, decoding it and renaming its columns
+// according to its options. The ingestion progress is periodically recorded in the processing status of the file,
+// and its final state is returned.
+func (s *DefaultService) ingestCSV(ctx context.Context, filename string, r io.Reader, size int64, options fileOptions) (data.ProcessingStatusEntity, error) {
+	reader := &countingReader{r: r}
+	progress := newIngestionProgress(filename, reader, size)
+
+	rows, err := csvRows(reader, options)
+	if err == nil {
+		err = s.ingestRows(ctx, filename, rows, progress)
+	}
+	if err != nil {
+		progress.discardInserted()
+	}
+	s.reportProgress(uncancelled(ctx), progress, true)
+	return progress.snapshot(), err
+}
+
+// ingestRows creates OHLCEntities from CSV rows and inserts them into the repository in batches of `ingestionBatchSize`.
 // The first row is expected to contain the header. If a row is incomplete, it can either be discarded
-// or return an error based on the value of `discardInCompleteRow`.
-func (s *DefaultService) CreateDataPoints(ctx context.Context, dataPoints [][]string) error {
-	if len(dataPoints) == 0 {
+// or return an error based on the value of `discardInCompleteRow`. Suspicious rows are then flagged, quarantined
+// or rejected based on the configured anomaly action. Prices are rounded to the precision of their symbol.
+// Every data point records the file it originates from so that the upload can be rolled back.
+// In transaction mode, the file is ingested within a single transaction so that its data is either fully visible
+// or not at all. In batch mode, meant for very large files, every batch is committed on its own and the data points
+// already inserted are deleted if a later batch fails, so the data of the file is partially visible while it is being ingested.
+func (s *DefaultService) ingestRows(ctx context.Context, filename string, rows rowReader, progress *ingestionProgress) error {
+	header, err := rows.Read()
+	if err == io.EOF {
 		return nil
 	}
-
-	header := dataPoints[0]
+	if err != nil {
+		return csvError(err)
+	}
 	fieldIndexes := getFieldTitleIndex(header)
 	if fieldIndexes.IsInComplete() {
 		return E.NewErrInvalidArgument("Invalid CSV header")
 	}
 
-	ohlcPoints := make([]data.OHLCEntity, 0, len(dataPoints)-1)
-	for _, row := range dataPoints[1:] {
-		d, err := extractDataPoint(row, fieldIndexes)
-		if err != nil {
-			if s.discardInCompleteRow {
+	touched := touchedRanges{}
+	ingest := func(repo repository.Repository) error {
+		batch := []data.OHLCEntity{}
+		for {
+			row, err := rows.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return csvError(err)
+			}
+			progress.parsed()
+
+			d, err := extractDataPoint(row, fieldIndexes)
+			if err != nil {
+				if !s.discardInCompleteRow {
+					return err
+				}
 				s.logger.Warn("Discarding incomplete row", zap.Error(err))
-			} else {
-				return err
+				progress.rejected(1)
+				continue
+			}
+			d.Round(s.pricePrecisions.Precision(d.Symbol))
+			d.SourceFile = null.NewString(filename)
+			batch = append(batch, *d)
+
+			if s.ingestionBatchSize > 0 && len(batch) >= s.ingestionBatchSize {
+				err = s.insertBatch(ctx, repo, batch, progress, touched)
+				if err != nil {
+					return err
+				}
+				batch = []data.OHLCEntity{}
 			}
-		} else {
-			ohlcPoints = append(ohlcPoints, *d)
 		}
+		return s.insertBatch(ctx, repo, batch, progress, touched)
 	}
 
-	err := s.repository.InsertDataPoints(ctx, ohlcPoints)
+	if s.ingestionMode != data.IngestionModeBatch {
+		return s.repository.WithTransaction(ctx, ingest)
+	}
+
+	err = ingest(s.repository)
 	if err != nil {
+		if _, delErr := s.repository.DeleteDataPointsBySourceFile(uncancelled(ctx), filename); delErr != nil {
+			s.logger.Error("failed to delete partially inserted data points", zap.String("filename", filename), zap.Error(delErr))
+		} else if rollupErr := s.updateRollups(uncancelled(ctx), s.repository, touched); rollupErr != nil {
+			s.logger.Error("failed to update rollups", zap.String("filename", filename), zap.Error(rollupErr))
+		}
 		return err
 	}
 	return nil
 }
 
+// insertBatch screens a batch of data points, inserts the accepted and the suspicious data points, updates the rollups
+// of the accepted data points and records the progress along with the time ranges touched.
+// The progress is only reported in batch mode: in transaction mode, the data points are not visible before the file
+// is complete, and a write outside of the transaction would wait for its lock on SQLite.
+func (s *DefaultService) insertBatch(ctx context.Context, repo repository.Repository, batch []data.OHLCEntity, progress *ingestionProgress, touched touchedRanges) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	accepted, anomalies, err := s.screenDataPoints(ctx, repo, batch)
+	if err != nil {
+		return err
+	}
+	if len(accepted) > 0 {
+		err = repo.InsertDataPoints(ctx, accepted)
+		if err != nil {
+			return err
+		}
+		touched.add(accepted)
+		err = s.updateRollups(ctx, repo, touchedRangesOf(accepted))
+		if err != nil {
+			return err
+		}
+	}
+	if len(anomalies) > 0 {
+		err = repo.InsertAnomalies(ctx, anomalies)
+		if err != nil {
+			return err
+		}
+	}
+
+	progress.inserted(accepted)
+	progress.rejected(len(batch) - len(accepted))
+	if s.ingestionMode == data.IngestionModeBatch {
+		s.reportProgress(ctx, progress, false)
+	}
+	return nil
+}
+
+// reportProgress records the ingestion progress in the processing status of the file,
+// at most once per `progressUpdateInterval` unless forced.
+func (s *DefaultService) reportProgress(ctx context.Context, progress *ingestionProgress, force bool) {
+	if progress == nil {
+		return
+	}
+	now := time.Now()
+	if !force && !progress.due(now, s.progressUpdateInterval) {
+		return
+	}
+	progress.lastUpdate = now
+
+	err := s.repository.UpdateProcessingProgress(ctx, progress.snapshot())
+	if err != nil {
+		s.logger.Warn("failed to update processing progress", zap.String("filename", progress.status.FileName), zap.Error(err))
+		return
+	}
+	s.publishUpload(ctx, progress.status.FileName, data.UploadEventTypeProgress)
+}
+
+// csvError converts a CSV parsing error into an invalid argument error.
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return E.NewErrInvalidArgument(err.Error())
+	}
+	return err
+}
+
 // getFieldTitleIndex returns a `data.OHLCFieldIndexes` containing the index positions of OHLC and Unix fields in a given header
 func getFieldTitleIndex(header []string) data.OHLCFieldIndexes {
 	v := data.DefaultOHLCFieldIndexes
@@ -137,7 +359,7 @@ func extractDataPoint(row []string, fieldIndexes data.OHLCFieldIndexes) (*data.O
 
 	if fieldIndexes.Open.Index != nil {
 		t := row[*fieldIndexes.Open.Index]
-		val, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		val, err := data.ParsePrice(t)
 		if err != nil {
 			return nil, err
 		}
@@ -146,7 +368,7 @@ func extractDataPoint(row []string, fieldIndexes data.OHLCFieldIndexes) (*data.O
 
 	if fieldIndexes.High.Index != nil {
 		t := row[*fieldIndexes.High.Index]
-		val, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		val, err := data.ParsePrice(t)
 		if err != nil {
 			return nil, err
 		}
@@ -155,7 +377,7 @@ func extractDataPoint(row []string, fieldIndexes data.OHLCFieldIndexes) (*data.O
 
 	if fieldIndexes.Low.Index != nil {
 		t := row[*fieldIndexes.Low.Index]
-		val, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		val, err := data.ParsePrice(t)
 		if err != nil {
 			return nil, err
 		}
@@ -164,7 +386,7 @@ func extractDataPoint(row []string, fieldIndexes data.OHLCFieldIndexes) (*data.O
 
 	if fieldIndexes.Close.Index != nil {
 		t := row[*fieldIndexes.Close.Index]
-		val, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		val, err := data.ParsePrice(t)
 		if err != nil {
 			return nil, err
 		}
@@ -178,10 +400,95 @@ func extractDataPoint(row []string, fieldIndexes data.OHLCFieldIndexes) (*data.O
 	return &d, nil
 }
 
+// ProcessCSV streams a directly uploaded CSV file of `size` bytes into the repository. The upload is given a generated
+// filename and its processing status is recorded like the files uploaded to S3, so that it can be tracked, cancelled
+// and rolled back. The callback URL of the payload, if any, is notified once the file is processed.
+// A file processed synchronously is returned with the number of rows inserted and rejected.
+// A file requested to be processed asynchronously, larger than `asyncUploadThreshold` or of unknown size is instead
+// streamed to S3 and processed asynchronously like the files uploaded to S3, and returned as queued.
+func (s *DefaultService) ProcessCSV(ctx context.Context, payload data.UploadRequest, r io.Reader, size int64) (*data.UploadResponse, error) {
+	callbackURL, err := callbackURLOf(payload.CallbackURL)
+	if err != nil {
+		return nil, err
+	}
+	filename := newFilename(data.FileFormatCSV)
+	if payload.Async || size < 0 || size > s.asyncUploadThreshold {
+		return s.enqueueCSV(ctx, filename, payload, callbackURL, r)
+	}
+
+	err = s.repository.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{
+		FileName:    filename,
+		Status:      data.ProcessingStatusInProgress,
+		Uploader:    uploaderOf(payload.Uploader),
+		CallbackURL: callbackURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishUpload(ctx, filename, data.UploadEventTypeStatus)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.jobs.start(filename, cancel)
+	defer s.jobs.done(filename)
+
+	progress, err := s.ingestCSV(ctx, filename, r, size, fileOptions{format: data.FileFormatCSV})
+	if outcomeErr := s.recordOutcome(ctx, filename, err); err == nil && outcomeErr != nil {
+		return nil, outcomeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &data.UploadResponse{
+		Filename:     filename,
+		Status:       data.ProcessingStatusCompleted,
+		RowsInserted: null.NewInt64(progress.RowsInserted),
+		RowsRejected: null.NewInt64(progress.RowsRejected),
+	}, nil
+}
+
+// enqueueCSV streams a directly uploaded CSV file to S3, from where it is processed asynchronously once
+// S3 notifies its upload. The file is recorded as awaiting upload until the notification queues it, like the files
+// uploaded through a presigned URL.
+func (s *DefaultService) enqueueCSV(ctx context.Context, filename string, payload data.UploadRequest, callbackURL null.String, r io.Reader) (*data.UploadResponse, error) {
+	err := s.checkKeySuffix(data.FileFormatCSV)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repository.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{
+		FileName:    filename,
+		Status:      data.ProcessingStatusAwaitingUpload,
+		Uploader:    uploaderOf(payload.Uploader),
+		CallbackURL: callbackURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishUpload(ctx, filename, data.UploadEventTypeStatus)
+
+	options := fileOptions{format: data.FileFormatCSV}
+	metadata, err := options.metadata()
+	if err == nil {
+		err = s.s3Client.UploadObject(ctx, s.objectKey(filename), r, options.format.ContentType(), metadata)
+	}
+	if err != nil {
+		if statusErr := s.UpdateProcessingStatus(uncancelled(ctx), filename, data.ProcessingStatusFailed, err); statusErr != nil {
+			s.logger.Warn("failed to update processing status", zap.String("filename", filename), zap.Error(statusErr))
+		}
+		return nil, err
+	}
+	return &data.UploadResponse{
+		Filename: filename,
+		Status:   data.ProcessingStatusQueued,
+	}, nil
+}
+
 // GetDataPoints returns a slice of OHLCEntity representing the requested open-high-low-close data points for a specific symbol.
 // It validates the inputs such as symbol, start and end time, page size and page number and returns an error if they are not valid.
 // The page size and page number are optional and default to defaultDataPointLimit and 1 respectively if not provided.
 // The end time is also optional and defaults to the current time if not provided.
+// When an interval is provided, the data points are resampled into candles of that interval and the missing
+// buckets are synthesised according to the fill mode, which defaults to none.
 // The result is based on the data obtained from the repository.
 func (s *DefaultService) GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, *int, error) {
 	if payload.Symbol == "" {
@@ -209,77 +516,131 @@ func (s *DefaultService) GetDataPoints(ctx context.Context, payload data.GetOHLC
 	if !payload.EndTime.Valid {
 		payload.EndTime = null.NewInt64(time.Now().Unix())
 	}
+	if payload.Fill == "" {
+		payload.Fill = data.FillModeNone
+	}
+	if !payload.Fill.IsValid() {
+		return nil, nil, E.NewErrInvalidArgument("fill must be one of none, previous, null or linear")
+	}
 
-	data, err := s.repository.GetDataPoints(ctx, payload)
+	if payload.Interval == "" {
+		if payload.Fill != data.FillModeNone {
+			return nil, nil, E.NewErrInvalidArgument("interval is required when fill is set")
+		}
+		points, err := s.repository.GetDataPoints(ctx, payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		return points, payload.PageNumber.AsRef(), nil
+	}
+
+	candles, err := s.getResampledDataPoints(ctx, payload)
 	if err != nil {
 		return nil, nil, err
 	}
-	return data, payload.PageNumber.AsRef(), nil
+	return candles, payload.PageNumber.AsRef(), nil
 }
 
-// GeneratePreSignedURL generates a presigned URL for uploading a file to S3.
-// It returns the generated URL and filename.
-func (s *DefaultService) GeneratePreSignedURL(ctx context.Context) (*data.GeneratePresignedURLResponse, error) {
-	filename := fmt.Sprintf("%s.csv", util.GenerateUUID())
-	url, err := s.s3Client.GeneratePresignedURL(ctx, filename)
+// getResampledDataPoints aggregates the data points of the requested time range into candles of the requested interval,
+// synthesises the missing buckets according to the fill mode and returns the requested page of candles.
+func (s *DefaultService) getResampledDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, error) {
+	interval, err := data.ParseInterval(payload.Interval)
 	if err != nil {
 		return nil, err
 	}
+	startTime := time.Unix(payload.StartTime, 0)
+	endTime := time.Unix(payload.EndTime.Int64, 0)
+	if endTime.Sub(startTime)/interval >= maxResampledBuckets {
+		return nil, E.NewErrInvalidArgument("interval is too small for the requested time range")
+	}
 
-	return &data.GeneratePresignedURLResponse{
-		URL:      url,
-		Filename: filename,
-	}, nil
+	points, err := s.getCandlesInRange(ctx, payload.Symbol, bucketStart(startTime, interval), endTime, interval)
+	if err != nil {
+		return nil, err
+	}
+	candles := resampleDataPoints(points, interval)
+	candles = fillDataPoints(candles, payload.Symbol, startTime, endTime, interval, payload.Fill, s.pricePrecisions.Precision(payload.Symbol))
+
+	offset := (payload.PageNumber.Int64 - 1) * payload.PageSize.Int64
+	if offset >= int64(len(candles)) {
+		return []data.OHLCEntity{}, nil
+	}
+	end := offset + payload.PageSize.Int64
+	if end > int64(len(candles)) {
+		end = int64(len(candles))
+	}
+	return candles[offset:end], nil
 }
 
-// GetAndProcessSQSMessage retrieves filenames from SQS messages, logs them and creates Goroutines
-// to process the files in parallel using the DownloadAndProcessCSV method.
-// It returns an error if any occurred during the retrieval of filenames from SQS messages.
-func (s *DefaultService) GetAndProcessSQSMessage(ctx context.Context) error {
-	filenames, err := s.sqsClient.GetFilenamesFromMessages(ctx)
-	s.logger.Info("filenames are", zap.Any("filenames", filenames))
+// GeneratePreSignedURL generates a presigned URL for uploading a file to S3.
+// The expected size, checksums and tags of the payload are signed along with the URL so that S3 rejects a non-matching
+// upload, while its format and column mapping are stored as metadata of the file and applied when it is processed.
+// The file is recorded as awaiting upload along with its uploader and tags so that it can be listed before being processed,
+// and along with its callback URL so that it can be notified once the file is processed.
+// It returns the generated URL, the headers the upload must send and the filename.
+func (s *DefaultService) GeneratePreSignedURL(ctx context.Context, payload data.GeneratePresignedURLRequest) (*data.GeneratePresignedURLResponse, error) {
+	callbackURL, err := callbackURLOf(payload.CallbackURL)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if len(filenames) == 0 {
-		return nil
+	conditions, options, err := uploadConditionsOf(payload)
+	if err != nil {
+		return nil, err
 	}
-	for _, filename := range filenames {
-		s.repository.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{
-			FileName: filename,
-			Status:   data.ProcessingStatusInProgress,
-		})
-		// Create Goroutines to process the files in parallel
-		go s.DownloadAndProcessCSV(ctx, filename)
+	err = s.checkKeySuffix(options.format)
+	if err != nil {
+		return nil, err
+	}
+	filename := newFilename(options.format)
+	presigned, err := s.s3Client.GeneratePresignedURL(ctx, s.objectKey(filename), conditions)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// DownloadAndProcessCSV retrieves a large CSV object from S3 and processes the data to create data points.
-// If an error occurs while downloading the object from S3 or processing the data, it will be returned.
-func (s *DefaultService) DownloadAndProcessCSV(ctx context.Context, filename string) error {
-	s3FileData, err := s.s3Client.DownloadLargeObject(ctx, filename)
+	err = s.repository.InsertProcessingStatus(ctx, data.ProcessingStatusEntity{
+		FileName:    filename,
+		Status:      data.ProcessingStatusAwaitingUpload,
+		Uploader:    uploaderOf(payload.Uploader),
+		CallbackURL: callbackURL,
+		Tags:        payload.Tags,
+	})
 	if err != nil {
-		s.UpdateProcessingStatus(ctx, filename, data.ProcessingStatusFailed, err)
-		return err
+		return nil, err
 	}
+	s.publishUpload(ctx, filename, data.UploadEventTypeStatus)
 
-	r := csv.NewReader(strings.NewReader(string(s3FileData)))
-	csvData, err := r.ReadAll()
+	return &data.GeneratePresignedURLResponse{
+		URL:      presigned.URL,
+		Filename: filename,
+		Headers:  presigned.Headers,
+	}, nil
+}
+
+// DownloadAndProcessCSV streams a large CSV object from S3 and processes the data to create data points.
+// The file is processed according to the options stored in its metadata when its presigned URL was generated.
+// The outcome is recorded in the processing status of the file.
+// If an error occurs while reading the object from S3 or processing the data, it will be returned.
+func (s *DefaultService) DownloadAndProcessCSV(ctx context.Context, filename string) error {
+	object, err := s.s3Client.OpenObject(ctx, s.objectKey(filename))
 	if err != nil {
-		s.UpdateProcessingStatus(ctx, filename, data.ProcessingStatusFailed, err)
+		s.recordOutcome(ctx, filename, err)
 		return err
 	}
+	defer object.Body.Close()
 
-	err = s.CreateDataPoints(ctx, csvData)
+	options, err := fileOptionsOf(filename, object.Metadata)
+	if err == nil && options.expectedSize > 0 && object.Size != options.expectedSize {
+		err = E.NewErrInvalidArgument(fmt.Sprintf("file size %d does not match the expected size %d", object.Size, options.expectedSize))
+	}
+	if err == nil {
+		_, err = s.ingestCSV(ctx, filename, object.Body, object.Size, options)
+	}
+	s.recordOutcome(ctx, filename, err)
 	if err != nil {
-		s.UpdateProcessingStatus(ctx, filename, data.ProcessingStatusFailed, err)
 		return err
-	} else {
-		s.logger.Debug("data points created", zap.String("filename", filename))
 	}
 
-	s.UpdateProcessingStatus(ctx, filename, data.ProcessingStatusCompleted, nil)
+	s.logger.Debug("data points created", zap.String("filename", filename))
 	return nil
 }
 
@@ -289,19 +650,153 @@ func (s *DefaultService) UpdateProcessingStatus(ctx context.Context, filename st
 		FileName: filename,
 		Status:   status,
 	}
+	if status == data.ProcessingStatusCompleted || status == data.ProcessingStatusFailed || status == data.ProcessingStatusCancelled {
+		p.FinishedAt = null.NewTime(time.Now())
+	}
 	if err != nil {
 		p.Error = null.NewString(err.Error())
 		s.logger.Error("error occurred", zap.String("filename", filename), zap.Error(err))
 	}
-	return s.repository.UpdateProcessingStatus(ctx, p)
+	err = s.repository.UpdateProcessingStatus(ctx, p)
+	if err != nil {
+		return err
+	}
+	s.publishUpload(ctx, filename, data.UploadEventTypeStatus)
+	return nil
 }
 
-// GetProcessingStatus returns the processing status of a file.
+// GetProcessingStatus returns the processing status of a file, along with the estimated completion time of a file in progress.
 func (s *DefaultService) GetProcessingStatus(ctx context.Context, filename string) (*data.ProcessingStatusEntity, error) {
-	return s.repository.GetProcessingStatus(ctx, filename)
+	status, err := s.repository.GetProcessingStatus(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+	status.EstimatedCompletion = estimateCompletion(*status)
+	return status, nil
+}
+
+// GetUploads returns the processing statuses of the uploaded files matching the filters of the payload.
+// The uploads are sorted by creation time in descending order unless requested otherwise.
+// The page size and page number are optional and default to defaultDataPointLimit and 1 respectively if not provided.
+func (s *DefaultService) GetUploads(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, *int, error) {
+	if payload.Status != "" && !payload.Status.IsValid() {
+		return nil, nil, E.NewErrInvalidArgument("unknown status")
+	}
+	if payload.StartTime.Valid && payload.EndTime.Valid && payload.EndTime.Int64 < payload.StartTime.Int64 {
+		return nil, nil, E.NewErrInvalidArgument("to must be greater than from")
+	}
+	if payload.PageSize.Valid && payload.PageSize.Int64 <= 0 {
+		return nil, nil, E.NewErrInvalidArgument("page size must be greater than 0")
+	}
+	if payload.PageNumber.Valid && payload.PageNumber.Int64 <= 0 {
+		return nil, nil, E.NewErrInvalidArgument("page number must be greater than 0")
+	}
+
+	if payload.SortBy == "" {
+		payload.SortBy = data.UploadSortFieldCreatedAt
+	}
+	if !payload.SortBy.IsValid() {
+		return nil, nil, E.NewErrInvalidArgument("sort_by must be one of created_at, updated_at, file_name or status")
+	}
+	if payload.Order == "" {
+		payload.Order = data.SortOrderDesc
+	}
+	if !payload.Order.IsValid() {
+		return nil, nil, E.NewErrInvalidArgument("order must be one of asc or desc")
+	}
+	if !payload.PageSize.Valid {
+		payload.PageSize = null.NewInt(s.defaulDataPointLimit)
+	}
+	if !payload.PageNumber.Valid {
+		payload.PageNumber = null.NewInt(1)
+	}
+
+	uploads, err := s.repository.GetProcessingStatuses(ctx, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range uploads {
+		uploads[i].EstimatedCompletion = estimateCompletion(uploads[i])
+	}
+	return uploads, payload.PageNumber.AsRef(), nil
+}
+
+// newFilename returns a new unique filename of an uploaded file of the given format.
+func newFilename(format data.FileFormat) string {
+	return fmt.Sprintf("%s.%s", util.GenerateUUID(), format)
+}
+
+// objectKey returns the S3 key of an uploaded file, under the upload key prefix so that the consumer accepts it.
+func (s *DefaultService) objectKey(filename string) string {
+	return s.uploadKeyPrefix + filename
+}
+
+// checkKeySuffix returns an ErrInvalidArgument if the S3 key of a file of the given format does not end with the upload
+// key suffix, as the consumer would never process it.
+func (s *DefaultService) checkKeySuffix(format data.FileFormat) error {
+	if !strings.HasSuffix("."+string(format), s.uploadKeySuffix) {
+		return E.NewErrInvalidArgument(fmt.Sprintf("files in the %s format cannot be uploaded to S3, their keys do not end with %q", format, s.uploadKeySuffix))
+	}
+	return nil
+}
+
+// uploaderOf returns the uploader to record, an empty uploader being recorded as unknown.
+func uploaderOf(uploader string) null.String {
+	uploader = strings.TrimSpace(uploader)
+	if uploader == "" {
+		return null.NewInvalidString()
+	}
+	return null.NewString(uploader)
 }
 
 // DeleteStaleProcessingStatus deletes processing status records older than the specified number of days.
 func (s *DefaultService) DeleteStaleProcessingStatus(ctx context.Context, days int) error {
 	return s.repository.RemoveStaleProcessingStatus(ctx, time.Now().AddDate(0, 0, -days))
 }
+
+// RollbackUpload deletes all data points originating from an uploaded file, updates the rollups of the symbols and time range
+// recorded in its processing status and marks it as rolled back.
+// In dry-run mode, nothing is deleted and only the number of data points that would be deleted is returned.
+// A file still being processed cannot be rolled back.
+func (s *DefaultService) RollbackUpload(ctx context.Context, filename string, dryRun bool) (*data.RollbackUploadResponse, error) {
+	status, err := s.repository.GetProcessingStatus(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+	if isActive(status.Status) {
+		return nil, E.NewErrInvalidArgument("file is still being processed")
+	}
+
+	resp := &data.RollbackUploadResponse{
+		Filename: filename,
+		DryRun:   dryRun,
+	}
+	if dryRun {
+		resp.AffectedRows, err = s.repository.CountDataPointsBySourceFile(ctx, filename)
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	resp.AffectedRows, err = s.repository.DeleteDataPointsBySourceFile(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+	if status.RangeStart.Valid && status.RangeEnd.Valid {
+		touched := touchedRanges{}
+		for _, symbol := range status.Symbols {
+			touched[symbol] = timeRange{start: status.RangeStart.Time, end: status.RangeEnd.Time}
+		}
+		err = s.updateRollups(ctx, s.repository, touched)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = s.UpdateProcessingStatus(ctx, filename, data.ProcessingStatusRolledBack, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("upload rolled back", zap.String("filename", filename), zap.Int64("rows", resp.AffectedRows))
+	return resp, nil
+}
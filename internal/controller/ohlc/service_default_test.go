@@ -3,21 +3,103 @@ package ohlc
 import (
 	"context"
 	"errors"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	migrations "github.com/teezzan/candles/db"
 	"github.com/teezzan/candles/internal/client/s3"
 	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
 	"github.com/teezzan/candles/internal/config"
 	"github.com/teezzan/candles/internal/controller/ohlc/data"
 	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"github.com/teezzan/candles/internal/database"
+	E "github.com/teezzan/candles/internal/errors"
 	"github.com/teezzan/candles/internal/null"
 	"github.com/teezzan/candles/internal/util"
 	"go.uber.org/zap"
 )
 
+// newService initializes a new service, failing the test if the configuration is invalid.
+func newService(
+	t *testing.T,
+	logger *zap.Logger,
+	repository repository.Repository,
+	s3Client s3.Client,
+	sqsClient sqs.Client,
+	webhookClient webhook.Client,
+	ohlcConf config.OHLCConfig,
+) *DefaultService {
+	s, err := NewService(logger, repository, s3Client, sqsClient, webhookClient, ohlcConf)
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewService(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(conf *config.OHLCConfig)
+		wantErr bool
+	}{
+		{
+			name:   "default configuration",
+			update: func(conf *config.OHLCConfig) {},
+		},
+		{
+			name: "unknown anomaly action",
+			update: func(conf *config.OHLCConfig) {
+				conf.AnomalyAction = "Flag"
+			},
+			wantErr: true,
+		},
+		{
+			name: "rollup intervals not multiples of each other",
+			update: func(conf *config.OHLCConfig) {
+				conf.RollupIntervals = []string{"2m", "3m"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid price precision rule",
+			update: func(conf *config.OHLCConfig) {
+				conf.PricePrecisions = []string{"BTC"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid retention rule",
+			update: func(conf *config.OHLCConfig) {
+				conf.RetentionRules = []string{"1m"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "upload key suffix of a single format",
+			update: func(conf *config.OHLCConfig) {
+				conf.UploadKeyPrefix = "uploads/"
+				conf.UploadKeySuffix = ".gz"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := config.Init()
+			tt.update(&conf.OHLCConfig)
+			_, err := NewService(zap.NewNop(), &repository.RepositoryMock{}, &s3.ClientMock{}, &sqs.ClientMock{}, &webhook.ClientMock{}, conf.OHLCConfig)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func Test_extractDataPoint(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -40,13 +122,48 @@ func Test_extractDataPoint(t *testing.T) {
 			want: &data.OHLCEntity{
 				Time:   time.Unix(1610000000, 0),
 				Symbol: "BTC/USD",
-				Open:   100,
-				High:   200,
-				Low:    50,
-				Close:  150,
+				Open:   price(100),
+				High:   price(200),
+				Low:    price(50),
+				Close:  price(150),
 			},
 			wantErr: false,
 		},
+		{
+			name: "satoshi-level prices",
+			row: []string{
+				"1610000000",
+				"BTC/USD",
+				"0.00000001",
+				"0.3",
+				" 0.1 ",
+				"9999999999.9999999999",
+			},
+			fieldIndexes: getFieldTitleIndex([]string{"UNIX", "SYMBOL", "OPEN", "HIGH", "LOW", "CLOSE"}),
+			want: &data.OHLCEntity{
+				Time:   time.Unix(1610000000, 0),
+				Symbol: "BTC/USD",
+				Open:   decimal.RequireFromString("0.00000001"),
+				High:   decimal.RequireFromString("0.3"),
+				Low:    decimal.RequireFromString("0.1"),
+				Close:  decimal.RequireFromString("9999999999.9999999999"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "price too large to be stored",
+			row: []string{
+				"1610000000",
+				"BTC/USD",
+				"100",
+				"10000000000",
+				"50",
+				"150",
+			},
+			fieldIndexes: getFieldTitleIndex([]string{"UNIX", "SYMBOL", "OPEN", "HIGH", "LOW", "CLOSE"}),
+			want:         nil,
+			wantErr:      true,
+		},
 		{
 			name: "invalid row",
 			row: []string{
@@ -273,10 +390,14 @@ func TestDefaultService_CreateDataPoints(t *testing.T) {
 				mockSQSClient = &sqs.ClientMock{}
 			)
 			conf := config.Init()
+			conf.OHLCConfig.RollupIntervals = nil
 			conf.OHLCConfig.DiscardInCompleteRow = tt.discardInCompleteRow
+			tt.repository.WithTransactionFunc = func(ctx context.Context, fn func(repository.Repository) error) error {
+				return fn(&tt.repository)
+			}
 
-			s := NewService(logger, &tt.repository, mockS3Client, mockSQSClient, conf.OHLCConfig)
-			err := s.CreateDataPoints(ctx, tt.dataPoints)
+			s := newService(t, logger, &tt.repository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			err := s.CreateDataPoints(ctx, "test.csv", tt.dataPoints)
 			require.Equal(t, tt.wantErr, err != nil)
 			assert.Len(t, tt.repository.InsertDataPointsCalls(), tt.InsertDataPointsCallsNum)
 		})
@@ -285,24 +406,86 @@ func TestDefaultService_CreateDataPoints(t *testing.T) {
 
 func TestDefaultService_GeneratePreSignedURL(t *testing.T) {
 	tests := []struct {
-		name     string
-		s3Client s3.ClientMock
-		wantErr  bool
+		name           string
+		payload        data.GeneratePresignedURLRequest
+		keySuffix      string
+		s3Client       s3.ClientMock
+		wantConditions s3.UploadConditions
+		wantErr        bool
 	}{
 		{
-			name: "valid s3 client response",
+			name:    "valid s3 client response",
+			payload: data.GeneratePresignedURLRequest{Uploader: "operator"},
 			s3Client: s3.ClientMock{
-				GeneratePresignedURLFunc: func(ctx context.Context, key string) (string, error) {
-					return "https://test.com", nil
+				GeneratePresignedURLFunc: func(ctx context.Context, key string, conditions s3.UploadConditions) (*s3.PresignedURL, error) {
+					return &s3.PresignedURL{URL: "https://test.com"}, nil
 				},
 			},
+			wantConditions: s3.UploadConditions{
+				ContentType: "text/csv",
+				Metadata:    map[string]string{"candles-format": "csv"},
+			},
 			wantErr: false,
 		},
 		{
-			name: "s3 client respond with error",
+			name: "upload constraints and processing options",
+			payload: data.GeneratePresignedURLRequest{
+				Format:        data.FileFormatCSVGzip,
+				ExpectedSize:  1024,
+				ContentSHA256: "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+				ColumnMapping: data.ColumnMapping{"ts": data.UnixFieldName},
+				Tags:          data.Tags{"source": "binance"},
+			},
+			keySuffix: ".gz",
 			s3Client: s3.ClientMock{
-				GeneratePresignedURLFunc: func(ctx context.Context, key string) (string, error) {
-					return "", errors.New("test error")
+				GeneratePresignedURLFunc: func(ctx context.Context, key string, conditions s3.UploadConditions) (*s3.PresignedURL, error) {
+					return &s3.PresignedURL{URL: "https://test.com"}, nil
+				},
+			},
+			wantConditions: s3.UploadConditions{
+				ContentType:    "application/gzip",
+				ContentLength:  1024,
+				ChecksumSHA256: "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+				Metadata: map[string]string{
+					"candles-format":         "csv.gz",
+					"candles-expected-size":  "1024",
+					"candles-column-mapping": `{"ts":"UNIX"}`,
+				},
+				Tags: data.Tags{"source": "binance"},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "unsupported format",
+			payload:  data.GeneratePresignedURLRequest{Format: "xlsx"},
+			s3Client: s3.ClientMock{},
+			wantErr:  true,
+		},
+		{
+			name:      "format not matching the upload key suffix",
+			payload:   data.GeneratePresignedURLRequest{Format: data.FileFormatCSV},
+			keySuffix: ".gz",
+			s3Client:  s3.ClientMock{},
+			wantErr:   true,
+		},
+		{
+			name:     "invalid checksum",
+			payload:  data.GeneratePresignedURLRequest{ContentMD5: "not-a-digest"},
+			s3Client: s3.ClientMock{},
+			wantErr:  true,
+		},
+		{
+			name:     "column mapped to an unknown field",
+			payload:  data.GeneratePresignedURLRequest{ColumnMapping: data.ColumnMapping{"ts": "TIME"}},
+			s3Client: s3.ClientMock{},
+			wantErr:  true,
+		},
+		{
+			name:    "s3 client respond with error",
+			payload: data.GeneratePresignedURLRequest{Uploader: "operator"},
+			s3Client: s3.ClientMock{
+				GeneratePresignedURLFunc: func(ctx context.Context, key string, conditions s3.UploadConditions) (*s3.PresignedURL, error) {
+					return nil, errors.New("test error")
 				},
 			},
 			wantErr: true,
@@ -314,16 +497,26 @@ func TestDefaultService_GeneratePreSignedURL(t *testing.T) {
 				ctx            = context.Background()
 				logger         = zap.NewNop()
 				mockSQSClient  = &sqs.ClientMock{}
-				mockRepository = &repository.RepositoryMock{}
+				mockRepository = &repository.RepositoryMock{
+					InsertProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+						return nil
+					},
+				}
 			)
 			conf := config.Init()
+			conf.OHLCConfig.UploadKeyPrefix = "uploads/"
+			conf.OHLCConfig.UploadKeySuffix = tt.keySuffix
 
-			s := NewService(logger, mockRepository, &tt.s3Client, mockSQSClient, conf.OHLCConfig)
-			got, err := s.GeneratePreSignedURL(ctx)
+			s := newService(t, logger, mockRepository, &tt.s3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			got, err := s.GeneratePreSignedURL(ctx, tt.payload)
 			require.Equal(t, tt.wantErr, err != nil)
 			if !tt.wantErr {
 				assert.NotEmpty(t, got)
-				assert.Len(t, tt.s3Client.GeneratePresignedURLCalls(), 1)
+				calls := tt.s3Client.GeneratePresignedURLCalls()
+				require.Len(t, calls, 1)
+				assert.Equal(t, tt.wantConditions, calls[0].Conditions)
+				assert.Equal(t, "uploads/"+got.Filename, calls[0].Key)
+				assert.True(t, strings.HasSuffix(got.Filename, "."+string(calls[0].Conditions.Metadata["candles-format"])))
 			}
 		})
 	}
@@ -347,8 +540,8 @@ func TestDefaultService_DownloadAndProcessCSV(t *testing.T) {
 		{
 			name: "valid CSV file without error",
 			s3Client: s3.ClientMock{
-				DownloadLargeObjectFunc: func(ctx context.Context, objectKey string) ([]byte, error) {
-					return []byte(validCSV), nil
+				OpenObjectFunc: func(ctx context.Context, objectKey string) (*s3.Object, error) {
+					return &s3.Object{Body: io.NopCloser(strings.NewReader(validCSV)), Size: int64(len(validCSV))}, nil
 				},
 			},
 			wantErr: false,
@@ -356,8 +549,8 @@ func TestDefaultService_DownloadAndProcessCSV(t *testing.T) {
 		{
 			name: "invalid CSV file without error",
 			s3Client: s3.ClientMock{
-				DownloadLargeObjectFunc: func(ctx context.Context, objectKey string) ([]byte, error) {
-					return []byte(invalidCSV), nil
+				OpenObjectFunc: func(ctx context.Context, objectKey string) (*s3.Object, error) {
+					return &s3.Object{Body: io.NopCloser(strings.NewReader(invalidCSV)), Size: int64(len(invalidCSV))}, nil
 				},
 			},
 			wantErr: true,
@@ -365,12 +558,25 @@ func TestDefaultService_DownloadAndProcessCSV(t *testing.T) {
 		{
 			name: "no CSV file with error",
 			s3Client: s3.ClientMock{
-				DownloadLargeObjectFunc: func(ctx context.Context, objectKey string) ([]byte, error) {
+				OpenObjectFunc: func(ctx context.Context, objectKey string) (*s3.Object, error) {
 					return nil, errors.New("test error")
 				},
 			},
 			wantErr: true,
 		},
+		{
+			name: "CSV file not of the expected size",
+			s3Client: s3.ClientMock{
+				OpenObjectFunc: func(ctx context.Context, objectKey string) (*s3.Object, error) {
+					return &s3.Object{
+						Body:     io.NopCloser(strings.NewReader(validCSV)),
+						Size:     int64(len(validCSV)),
+						Metadata: map[string]string{"candles-expected-size": "1"},
+					}, nil
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -382,18 +588,28 @@ func TestDefaultService_DownloadAndProcessCSV(t *testing.T) {
 					InsertDataPointsFunc: func(ctx context.Context, rows []data.OHLCEntity) error {
 						return nil
 					},
+					GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+						return &data.ProcessingStatusEntity{FileName: fileName}, nil
+					},
 					UpdateProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
 						return nil
 					},
+					UpdateProcessingProgressFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+						return nil
+					},
 				}
 			)
+			mockRepository.WithTransactionFunc = func(ctx context.Context, fn func(repository.Repository) error) error {
+				return fn(mockRepository)
+			}
 			conf := config.Init()
+			conf.OHLCConfig.RollupIntervals = nil
 
-			s := NewService(logger, mockRepository, &tt.s3Client, mockSQSClient, conf.OHLCConfig)
+			s := newService(t, logger, mockRepository, &tt.s3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
 			err := s.DownloadAndProcessCSV(ctx, "test")
 			require.Equal(t, tt.wantErr, err != nil)
 			if !tt.wantErr {
-				assert.Len(t, tt.s3Client.DownloadLargeObjectCalls(), 1)
+				assert.Len(t, tt.s3Client.OpenObjectCalls(), 1)
 				assert.Len(t, mockRepository.InsertDataPointsCalls(), 1)
 
 			}
@@ -401,38 +617,46 @@ func TestDefaultService_DownloadAndProcessCSV(t *testing.T) {
 	}
 }
 
-func TestDefaultService_GetAndProcessSQSMessage(t *testing.T) {
+func TestDefaultService_ProcessCSV(t *testing.T) {
 	tests := []struct {
-		name      string
-		sqsClient sqs.ClientMock
-		wantErr   bool
+		name       string
+		size       int64
+		async      bool
+		uploadErr  error
+		wantStatus data.ProcessingStatus
+		wantUpload bool
+		wantErr    bool
 	}{
 		{
-			name: "valid filenames without error",
-			sqsClient: sqs.ClientMock{
-				GetFilenamesFromMessagesFunc: func(ctx context.Context) ([]string, error) {
-					return []string{"test.csv"}, nil
-				},
-			},
-			wantErr: false,
+			name:       "small file processed synchronously",
+			size:       int64(len(validCSV)),
+			wantStatus: data.ProcessingStatusCompleted,
 		},
 		{
-			name: "No file without error",
-			sqsClient: sqs.ClientMock{
-				GetFilenamesFromMessagesFunc: func(ctx context.Context) ([]string, error) {
-					return nil, nil
-				},
-			},
-			wantErr: false,
+			name:       "large file handed to S3",
+			size:       20 << 20,
+			wantStatus: data.ProcessingStatusQueued,
+			wantUpload: true,
 		},
 		{
-			name: "no file with error",
-			sqsClient: sqs.ClientMock{
-				GetFilenamesFromMessagesFunc: func(ctx context.Context) ([]string, error) {
-					return nil, errors.New("test error")
-				},
-			},
-			wantErr: true,
+			name:       "small file processed asynchronously on request",
+			size:       int64(len(validCSV)),
+			async:      true,
+			wantStatus: data.ProcessingStatusQueued,
+			wantUpload: true,
+		},
+		{
+			name:       "file of unknown size handed to S3",
+			size:       -1,
+			wantStatus: data.ProcessingStatusQueued,
+			wantUpload: true,
+		},
+		{
+			name:       "failed upload to S3",
+			size:       20 << 20,
+			uploadErr:  errors.New("test error"),
+			wantUpload: true,
+			wantErr:    true,
 		},
 	}
 	for _, tt := range tests {
@@ -441,31 +665,114 @@ func TestDefaultService_GetAndProcessSQSMessage(t *testing.T) {
 				ctx          = context.Background()
 				logger       = zap.NewNop()
 				mockS3Client = &s3.ClientMock{
-					DownloadLargeObjectFunc: func(ctx context.Context, objectKey string) ([]byte, error) {
-						return []byte(validCSV), nil
+					UploadObjectFunc: func(ctx context.Context, key string, body io.Reader, contentType string, metadata map[string]string) error {
+						return tt.uploadErr
 					},
 				}
+				mockSQSClient  = &sqs.ClientMock{}
 				mockRepository = &repository.RepositoryMock{
-					InsertDataPointsFunc: func(ctx context.Context, rows []data.OHLCEntity) error {
+					InsertProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
 						return nil
 					},
-					InsertProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+					InsertDataPointsFunc: func(ctx context.Context, rows []data.OHLCEntity) error {
 						return nil
 					},
+					GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+						return &data.ProcessingStatusEntity{FileName: fileName}, nil
+					},
 					UpdateProcessingStatusFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
 						return nil
 					},
+					UpdateProcessingProgressFunc: func(ctx context.Context, status data.ProcessingStatusEntity) error {
+						return nil
+					},
 				}
 			)
+			mockRepository.WithTransactionFunc = func(ctx context.Context, fn func(repository.Repository) error) error {
+				return fn(mockRepository)
+			}
 			conf := config.Init()
+			conf.OHLCConfig.RollupIntervals = nil
 
-			s := NewService(logger, mockRepository, mockS3Client, &tt.sqsClient, conf.OHLCConfig)
-			err := s.GetAndProcessSQSMessage(ctx)
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			got, err := s.ProcessCSV(ctx, data.UploadRequest{Async: tt.async}, strings.NewReader(validCSV), tt.size)
 			require.Equal(t, tt.wantErr, err != nil)
+			if tt.wantUpload {
+				require.Len(t, mockS3Client.UploadObjectCalls(), 1)
+				assert.Empty(t, mockRepository.InsertDataPointsCalls())
+				inserts := mockRepository.InsertProcessingStatusCalls()
+				require.Len(t, inserts, 1)
+				assert.Equal(t, data.ProcessingStatusAwaitingUpload, inserts[0].Status.Status)
+			} else {
+				assert.Empty(t, mockS3Client.UploadObjectCalls())
+				assert.Len(t, mockRepository.InsertDataPointsCalls(), 1)
+			}
+			if tt.wantErr {
+				updates := mockRepository.UpdateProcessingStatusCalls()
+				require.Len(t, updates, 1)
+				assert.Equal(t, data.ProcessingStatusFailed, updates[0].Status.Status)
+				return
+			}
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.NotEmpty(t, got.Filename)
+			assert.Equal(t, !tt.wantUpload, got.RowsInserted.Valid)
+			assert.Equal(t, !tt.wantUpload, got.RowsRejected.Valid)
 		})
 	}
 }
 
+func TestDefaultService_ProcessCSV_sqlite(t *testing.T) {
+	ctx := context.Background()
+	sqliteDB, err := database.New(config.DatabaseConfig{Driver: database.DriverSQLite, Database: database.MemoryDatabase})
+	require.NoError(t, err)
+	defer sqliteDB.Close()
+	require.NoError(t, sqliteDB.Migrate(ctx, migrations.SQLiteMigrations()))
+	conf := config.Init()
+
+	s := newService(t, zap.NewNop(), repository.NewSQLiteRepository(sqliteDB.SQL), &s3.ClientMock{}, &sqs.ClientMock{}, &webhook.ClientMock{}, conf.OHLCConfig)
+	got, err := s.ProcessCSV(ctx, data.UploadRequest{Uploader: "alice"}, strings.NewReader(validCSV), int64(len(validCSV)))
+	require.NoError(t, err)
+	assert.Equal(t, data.ProcessingStatusCompleted, got.Status)
+	assert.Equal(t, int64(2), got.RowsInserted.Int64)
+
+	status, err := s.GetProcessingStatus(ctx, got.Filename)
+	require.NoError(t, err)
+	assert.Equal(t, data.ProcessingStatusCompleted, status.Status)
+	assert.Equal(t, int64(2), status.RowsInserted)
+
+	points, _, err := s.GetDataPoints(ctx, data.GetOHLCRequest{Symbol: "BTC", StartTime: 1610000000, EndTime: null.NewInt64(1610000001)})
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	assert.Equal(t, "250", points[1].High.String())
+}
+
+func TestDefaultService_CreateDataPoints_pricePrecisions(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	conf := config.Init()
+	conf.OHLCConfig.PricePrecisions = []string{"BTC=8", "EUR=2"}
+	s := newService(t, zap.NewNop(), repo, &s3.ClientMock{}, &sqs.ClientMock{}, &webhook.ClientMock{}, conf.OHLCConfig)
+
+	err := s.CreateDataPoints(ctx, "prices.csv", [][]string{
+		{"UNIX", "SYMBOL", "OPEN", "HIGH", "LOW", "CLOSE"},
+		{"1610000000", "BTC/USD", "0.000000015", "68123.123456789", "0.00000001", "0.3"},
+		{"1610000000", "EUR/USD", "1.005", "1.2345", "0.995", "1.1"},
+		{"1610000000", "ETH/USD", "0.12345678901", "0.3", "0.1", "0.2"},
+	})
+	require.NoError(t, err)
+
+	for symbol, want := range map[string][]string{
+		"BTC/USD": {"0.00000002", "68123.12345679", "0.00000001", "0.3"},
+		"EUR/USD": {"1.01", "1.23", "1", "1.1"},
+		"ETH/USD": {"0.123456789", "0.3", "0.1", "0.2"},
+	} {
+		points, err := repo.GetDataPointsInRange(ctx, symbol, time.Unix(1610000000, 0), time.Unix(1610000000, 0))
+		require.NoError(t, err)
+		require.Len(t, points, 1, symbol)
+		assert.Equal(t, want, formatPrices(points[0].Open, points[0].High, points[0].Low, points[0].Close), symbol)
+	}
+}
+
 func TestDefaultService_GetDataPoints(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -483,10 +790,10 @@ func TestDefaultService_GetDataPoints(t *testing.T) {
 							ID:     1,
 							Symbol: "HAKO",
 							Time:   time.Now(),
-							Open:   100,
-							High:   200,
-							Low:    50,
-							Close:  150,
+							Open:   price(100),
+							High:   price(200),
+							Low:    price(50),
+							Close:  price(150),
 						},
 					}, nil
 				},
@@ -560,10 +867,33 @@ func TestDefaultService_GetDataPoints(t *testing.T) {
 							ID:     1,
 							Symbol: "HAKO",
 							Time:   time.Now(),
-							Open:   100,
-							High:   200,
-							Low:    50,
-							Close:  150,
+							Open:   price(100),
+							High:   price(200),
+							Low:    price(50),
+							Close:  price(150),
+						},
+					}, nil
+				},
+			},
+			payload: data.GetOHLCRequest{
+				Symbol:    "HAKO",
+				StartTime: time.Now().Add(-time.Hour).Unix(),
+			},
+			wantPageNumber: util.IntPtr(1),
+			wantErr:        false,
+		},
+		{
+			name: "valid payload with interval and fill",
+			repository: repository.RepositoryMock{
+				GetDataPointsInRangeFunc: func(ctx context.Context, symbol string, startTime time.Time, endTime time.Time) ([]data.OHLCEntity, error) {
+					return []data.OHLCEntity{
+						{
+							Symbol: "HAKO",
+							Time:   time.Now().Add(-30 * time.Minute),
+							Open:   price(100),
+							High:   price(200),
+							Low:    price(50),
+							Close:  price(150),
 						},
 					}, nil
 				},
@@ -571,10 +901,35 @@ func TestDefaultService_GetDataPoints(t *testing.T) {
 			payload: data.GetOHLCRequest{
 				Symbol:    "HAKO",
 				StartTime: time.Now().Add(-time.Hour).Unix(),
+				Interval:  "1m",
+				Fill:      data.FillModePrevious,
 			},
 			wantPageNumber: util.IntPtr(1),
 			wantErr:        false,
 		},
+		{
+			name:       "invalid payload with fill without interval",
+			repository: repository.RepositoryMock{},
+			payload: data.GetOHLCRequest{
+				Symbol:    "HAKO",
+				StartTime: time.Now().Add(-time.Hour).Unix(),
+				Fill:      data.FillModeLinear,
+			},
+			wantPageNumber: nil,
+			wantErr:        true,
+		},
+		{
+			name:       "invalid payload with unknown fill",
+			repository: repository.RepositoryMock{},
+			payload: data.GetOHLCRequest{
+				Symbol:    "HAKO",
+				StartTime: time.Now().Add(-time.Hour).Unix(),
+				Interval:  "1m",
+				Fill:      "zero",
+			},
+			wantPageNumber: nil,
+			wantErr:        true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -586,7 +941,7 @@ func TestDefaultService_GetDataPoints(t *testing.T) {
 			)
 			conf := config.Init()
 
-			s := NewService(logger, &tt.repository, mockS3Client, mockSQSClient, conf.OHLCConfig)
+			s := newService(t, logger, &tt.repository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
 			got, pageNumber, err := s.GetDataPoints(ctx, tt.payload)
 			require.Equal(t, tt.wantErr, err != nil)
 			if !tt.wantErr {
@@ -597,3 +952,232 @@ func TestDefaultService_GetDataPoints(t *testing.T) {
 
 	}
 }
+
+func TestDefaultService_RollbackUpload(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           data.ProcessingStatus
+		statusErr        error
+		dryRun           bool
+		wantErr          bool
+		wantAffectedRows int64
+		wantDeleteCalls  int
+		wantUpdateCalls  int
+	}{
+		{
+			name:             "completed upload is rolled back",
+			status:           data.ProcessingStatusCompleted,
+			wantAffectedRows: 3,
+			wantDeleteCalls:  1,
+			wantUpdateCalls:  1,
+		},
+		{
+			name:             "dry run only counts the data points",
+			status:           data.ProcessingStatusCompleted,
+			dryRun:           true,
+			wantAffectedRows: 5,
+		},
+		{
+			name:    "upload in progress",
+			status:  data.ProcessingStatusInProgress,
+			wantErr: true,
+		},
+		{
+			name:      "unknown upload",
+			statusErr: E.NewErrEntityNotFound("file", "test.csv"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx            = context.Background()
+				logger         = zap.NewNop()
+				mockS3Client   = &s3.ClientMock{}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{
+					GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+						if tt.statusErr != nil {
+							return nil, tt.statusErr
+						}
+						return &data.ProcessingStatusEntity{FileName: fileName, Status: tt.status}, nil
+					},
+					CountDataPointsBySourceFileFunc: func(ctx context.Context, fileName string) (int64, error) {
+						return 5, nil
+					},
+					DeleteDataPointsBySourceFileFunc: func(ctx context.Context, fileName string) (int64, error) {
+						return 3, nil
+					},
+					UpdateProcessingStatusFunc: func(ctx context.Context, p data.ProcessingStatusEntity) error {
+						return nil
+					},
+				}
+			)
+			conf := config.Init()
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			got, err := s.RollbackUpload(ctx, "test.csv", tt.dryRun)
+			require.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, mockRepository.DeleteDataPointsBySourceFileCalls(), tt.wantDeleteCalls)
+			assert.Len(t, mockRepository.UpdateProcessingStatusCalls(), tt.wantUpdateCalls)
+			if tt.wantErr {
+				return
+			}
+			assert.Equal(t, tt.wantAffectedRows, got.AffectedRows)
+			assert.Equal(t, tt.dryRun, got.DryRun)
+		})
+	}
+}
+
+func TestDefaultService_CreateDataPoints_ingestionMode(t *testing.T) {
+	dataPoints := [][]string{
+		{"UNIX", "SYMBOL", "OPEN", "HIGH", "LOW", "CLOSE"},
+		{"1610000000", "BTC", "100", "200", "50", "150"},
+		{"1610000060", "BTC", "150", "250", "100", "200"},
+		{"1610000120", "BTC", "200", "250", "150", "200"},
+	}
+	tests := []struct {
+		name                    string
+		mode                    data.IngestionMode
+		failingBatch            int
+		wantErr                 bool
+		wantInsertCalls         int
+		wantTransactionCalls    int
+		wantDeleteBySourceCalls int
+	}{
+		{
+			name:                 "transaction mode inserts every batch in a transaction",
+			mode:                 data.IngestionModeTransaction,
+			wantInsertCalls:      2,
+			wantTransactionCalls: 1,
+		},
+		{
+			name:                 "transaction mode failure",
+			mode:                 data.IngestionModeTransaction,
+			failingBatch:         2,
+			wantErr:              true,
+			wantInsertCalls:      2,
+			wantTransactionCalls: 1,
+		},
+		{
+			name:            "batch mode inserts every batch on its own",
+			mode:            data.IngestionModeBatch,
+			wantInsertCalls: 2,
+		},
+		{
+			name:                    "batch mode failure deletes the inserted batches",
+			mode:                    data.IngestionModeBatch,
+			failingBatch:            2,
+			wantErr:                 true,
+			wantInsertCalls:         2,
+			wantDeleteBySourceCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx            = context.Background()
+				logger         = zap.NewNop()
+				mockS3Client   = &s3.ClientMock{}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{}
+			)
+			mockRepository.InsertDataPointsFunc = func(ctx context.Context, rows []data.OHLCEntity) error {
+				if len(mockRepository.InsertDataPointsCalls()) == tt.failingBatch {
+					return errors.New("insert failed")
+				}
+				return nil
+			}
+			mockRepository.WithTransactionFunc = func(ctx context.Context, fn func(repository.Repository) error) error {
+				return fn(mockRepository)
+			}
+			mockRepository.DeleteDataPointsBySourceFileFunc = func(ctx context.Context, fileName string) (int64, error) {
+				return 2, nil
+			}
+			conf := config.Init()
+			conf.OHLCConfig.RollupIntervals = nil
+			conf.OHLCConfig.IngestionMode = string(tt.mode)
+			conf.OHLCConfig.IngestionBatchSize = 2
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			err := s.CreateDataPoints(ctx, "test.csv", dataPoints)
+			require.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, mockRepository.InsertDataPointsCalls(), tt.wantInsertCalls)
+			assert.Len(t, mockRepository.WithTransactionCalls(), tt.wantTransactionCalls)
+			assert.Len(t, mockRepository.DeleteDataPointsBySourceFileCalls(), tt.wantDeleteBySourceCalls)
+		})
+	}
+}
+
+func TestDefaultService_GetUploads(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     data.GetUploadsRequest
+		wantErr     bool
+		wantPayload data.GetUploadsRequest
+	}{
+		{
+			name:    "defaults",
+			payload: data.GetUploadsRequest{Status: data.ProcessingStatusFailed},
+			wantPayload: data.GetUploadsRequest{
+				Status:     data.ProcessingStatusFailed,
+				SortBy:     data.UploadSortFieldCreatedAt,
+				Order:      data.SortOrderDesc,
+				PageNumber: null.NewInt(1),
+				PageSize:   null.NewInt(100),
+			},
+		},
+		{
+			name:    "unknown status",
+			payload: data.GetUploadsRequest{Status: "LOST"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sort field",
+			payload: data.GetUploadsRequest{SortBy: "size"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sort order",
+			payload: data.GetUploadsRequest{Order: "up"},
+			wantErr: true,
+		},
+		{
+			name: "to before from",
+			payload: data.GetUploadsRequest{
+				StartTime: null.NewInt64(1610000000),
+				EndTime:   null.NewInt64(1600000000),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx            = context.Background()
+				logger         = zap.NewNop()
+				mockS3Client   = &s3.ClientMock{}
+				mockSQSClient  = &sqs.ClientMock{}
+				mockRepository = &repository.RepositoryMock{
+					GetProcessingStatusesFunc: func(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, error) {
+						return []data.ProcessingStatusEntity{{FileName: "test.csv", Status: data.ProcessingStatusFailed}}, nil
+					},
+				}
+			)
+			conf := config.Init()
+			conf.OHLCConfig.DefaultDataPointLimit = 100
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, &webhook.ClientMock{}, conf.OHLCConfig)
+			got, page, err := s.GetUploads(ctx, tt.payload)
+			require.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Empty(t, mockRepository.GetProcessingStatusesCalls())
+				return
+			}
+			require.Len(t, mockRepository.GetProcessingStatusesCalls(), 1)
+			assert.Equal(t, tt.wantPayload, mockRepository.GetProcessingStatusesCalls()[0].Payload)
+			assert.Len(t, got, 1)
+			assert.Equal(t, 1, *page)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package ohlc
 import (
 	"context"
 	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"io"
 	"sync"
 )
 
@@ -19,30 +20,99 @@ var _ Service = &ServiceMock{}
 //
 //		// make and configure a mocked Service
 //		mockedService := &ServiceMock{
-//			CreateDataPointsFunc: func(ctx context.Context, dataPoints [][]string) error {
+//			AbortMultipartUploadFunc: func(ctx context.Context, filename string, uploadID string) error {
+//				panic("mock out the AbortMultipartUpload method")
+//			},
+//			AbortStaleMultipartUploadsFunc: func(ctx context.Context, hours int) error {
+//				panic("mock out the AbortStaleMultipartUploads method")
+//			},
+//			AcceptAnomalyFunc: func(ctx context.Context, id int64) error {
+//				panic("mock out the AcceptAnomaly method")
+//			},
+//			ApplyRetentionPolicyFunc: func(ctx context.Context) error {
+//				panic("mock out the ApplyRetentionPolicy method")
+//			},
+//			CancelUploadFunc: func(ctx context.Context, filename string) error {
+//				panic("mock out the CancelUpload method")
+//			},
+//			CompleteMultipartUploadFunc: func(ctx context.Context, filename string, payload data.CompleteMultipartUploadRequest) error {
+//				panic("mock out the CompleteMultipartUpload method")
+//			},
+//			ConsumeSQSMessagesFunc: func(ctx context.Context) error {
+//				panic("mock out the ConsumeSQSMessages method")
+//			},
+//			CreateDataPointsFunc: func(ctx context.Context, filename string, dataPoints [][]string) error {
 //				panic("mock out the CreateDataPoints method")
 //			},
+//			DeleteAnomalyFunc: func(ctx context.Context, id int64) error {
+//				panic("mock out the DeleteAnomaly method")
+//			},
 //			DeleteStaleProcessingStatusFunc: func(ctx context.Context, days int) error {
 //				panic("mock out the DeleteStaleProcessingStatus method")
 //			},
 //			DownloadAndProcessCSVFunc: func(ctx context.Context, filename string) error {
 //				panic("mock out the DownloadAndProcessCSV method")
 //			},
-//			GeneratePreSignedURLFunc: func(ctx context.Context) (*data.GeneratePresignedURLResponse, error) {
+//			GenerateDataQualityReportsFunc: func(ctx context.Context, payload data.DataQualityRequest) ([]data.DataQualityEntity, error) {
+//				panic("mock out the GenerateDataQualityReports method")
+//			},
+//			GeneratePreSignedURLFunc: func(ctx context.Context, payload data.GeneratePresignedURLRequest) (*data.GeneratePresignedURLResponse, error) {
 //				panic("mock out the GeneratePreSignedURL method")
 //			},
-//			GetAndProcessSQSMessageFunc: func(ctx context.Context) error {
-//				panic("mock out the GetAndProcessSQSMessage method")
+//			GetAnomaliesFunc: func(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, *int, error) {
+//				panic("mock out the GetAnomalies method")
 //			},
 //			GetDataPointsFunc: func(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, *int, error) {
 //				panic("mock out the GetDataPoints method")
 //			},
+//			GetDataQualityReportsFunc: func(ctx context.Context, payload data.DataQualityRequest) ([]data.DataQualityEntity, error) {
+//				panic("mock out the GetDataQualityReports method")
+//			},
+//			GetPartURLsFunc: func(ctx context.Context, filename string, payload data.GetPartURLsRequest) (*data.MultipartUploadResponse, error) {
+//				panic("mock out the GetPartURLs method")
+//			},
 //			GetProcessingStatusFunc: func(ctx context.Context, filename string) (*data.ProcessingStatusEntity, error) {
 //				panic("mock out the GetProcessingStatus method")
 //			},
+//			GetRejectedMessagesFunc: func(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, *int, error) {
+//				panic("mock out the GetRejectedMessages method")
+//			},
+//			GetUploadsFunc: func(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, *int, error) {
+//				panic("mock out the GetUploads method")
+//			},
+//			GetWebhookDeliveriesFunc: func(ctx context.Context, filename string) ([]data.WebhookDeliveryEntity, error) {
+//				panic("mock out the GetWebhookDeliveries method")
+//			},
+//			InitiateMultipartUploadFunc: func(ctx context.Context, payload data.InitiateMultipartUploadRequest) (*data.MultipartUploadResponse, error) {
+//				panic("mock out the InitiateMultipartUpload method")
+//			},
+//			MaintainPartitionsFunc: func(ctx context.Context, monthsAhead int, retentionInMonths int) error {
+//				panic("mock out the MaintainPartitions method")
+//			},
+//			ProcessCSVFunc: func(ctx context.Context, payload data.UploadRequest, r io.Reader, size int64) (*data.UploadResponse, error) {
+//				panic("mock out the ProcessCSV method")
+//			},
+//			RebuildRollupsFunc: func(ctx context.Context) error {
+//				panic("mock out the RebuildRollups method")
+//			},
+//			RetryUploadFunc: func(ctx context.Context, filename string) error {
+//				panic("mock out the RetryUpload method")
+//			},
+//			RollbackUploadFunc: func(ctx context.Context, filename string, dryRun bool) (*data.RollbackUploadResponse, error) {
+//				panic("mock out the RollbackUpload method")
+//			},
+//			RunDataQualityCheckFunc: func(ctx context.Context) error {
+//				panic("mock out the RunDataQualityCheck method")
+//			},
 //			UpdateProcessingStatusFunc: func(ctx context.Context, filename string, status data.ProcessingStatus, err error) error {
 //				panic("mock out the UpdateProcessingStatus method")
 //			},
+//			WaitForWebhookDeliveriesFunc: func(ctx context.Context) error {
+//				panic("mock out the WaitForWebhookDeliveries method")
+//			},
+//			WatchUploadsFunc: func(filename string, uploader string) (<-chan data.UploadEvent, func()) {
+//				panic("mock out the WatchUploads method")
+//			},
 //		}
 //
 //		// use mockedService in code that requires Service
@@ -50,8 +120,32 @@ var _ Service = &ServiceMock{}
 //
 //	}
 type ServiceMock struct {
+	// AbortMultipartUploadFunc mocks the AbortMultipartUpload method.
+	AbortMultipartUploadFunc func(ctx context.Context, filename string, uploadID string) error
+
+	// AbortStaleMultipartUploadsFunc mocks the AbortStaleMultipartUploads method.
+	AbortStaleMultipartUploadsFunc func(ctx context.Context, hours int) error
+
+	// AcceptAnomalyFunc mocks the AcceptAnomaly method.
+	AcceptAnomalyFunc func(ctx context.Context, id int64) error
+
+	// ApplyRetentionPolicyFunc mocks the ApplyRetentionPolicy method.
+	ApplyRetentionPolicyFunc func(ctx context.Context) error
+
+	// CancelUploadFunc mocks the CancelUpload method.
+	CancelUploadFunc func(ctx context.Context, filename string) error
+
+	// CompleteMultipartUploadFunc mocks the CompleteMultipartUpload method.
+	CompleteMultipartUploadFunc func(ctx context.Context, filename string, payload data.CompleteMultipartUploadRequest) error
+
+	// ConsumeSQSMessagesFunc mocks the ConsumeSQSMessages method.
+	ConsumeSQSMessagesFunc func(ctx context.Context) error
+
 	// CreateDataPointsFunc mocks the CreateDataPoints method.
-	CreateDataPointsFunc func(ctx context.Context, dataPoints [][]string) error
+	CreateDataPointsFunc func(ctx context.Context, filename string, dataPoints [][]string) error
+
+	// DeleteAnomalyFunc mocks the DeleteAnomaly method.
+	DeleteAnomalyFunc func(ctx context.Context, id int64) error
 
 	// DeleteStaleProcessingStatusFunc mocks the DeleteStaleProcessingStatus method.
 	DeleteStaleProcessingStatusFunc func(ctx context.Context, days int) error
@@ -59,30 +153,133 @@ type ServiceMock struct {
 	// DownloadAndProcessCSVFunc mocks the DownloadAndProcessCSV method.
 	DownloadAndProcessCSVFunc func(ctx context.Context, filename string) error
 
+	// GenerateDataQualityReportsFunc mocks the GenerateDataQualityReports method.
+	GenerateDataQualityReportsFunc func(ctx context.Context, payload data.DataQualityRequest) ([]data.DataQualityEntity, error)
+
 	// GeneratePreSignedURLFunc mocks the GeneratePreSignedURL method.
-	GeneratePreSignedURLFunc func(ctx context.Context) (*data.GeneratePresignedURLResponse, error)
+	GeneratePreSignedURLFunc func(ctx context.Context, payload data.GeneratePresignedURLRequest) (*data.GeneratePresignedURLResponse, error)
 
-	// GetAndProcessSQSMessageFunc mocks the GetAndProcessSQSMessage method.
-	GetAndProcessSQSMessageFunc func(ctx context.Context) error
+	// GetAnomaliesFunc mocks the GetAnomalies method.
+	GetAnomaliesFunc func(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, *int, error)
 
 	// GetDataPointsFunc mocks the GetDataPoints method.
 	GetDataPointsFunc func(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, *int, error)
 
+	// GetDataQualityReportsFunc mocks the GetDataQualityReports method.
+	GetDataQualityReportsFunc func(ctx context.Context, payload data.DataQualityRequest) ([]data.DataQualityEntity, error)
+
+	// GetPartURLsFunc mocks the GetPartURLs method.
+	GetPartURLsFunc func(ctx context.Context, filename string, payload data.GetPartURLsRequest) (*data.MultipartUploadResponse, error)
+
 	// GetProcessingStatusFunc mocks the GetProcessingStatus method.
 	GetProcessingStatusFunc func(ctx context.Context, filename string) (*data.ProcessingStatusEntity, error)
 
+	// GetRejectedMessagesFunc mocks the GetRejectedMessages method.
+	GetRejectedMessagesFunc func(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, *int, error)
+
+	// GetUploadsFunc mocks the GetUploads method.
+	GetUploadsFunc func(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, *int, error)
+
+	// GetWebhookDeliveriesFunc mocks the GetWebhookDeliveries method.
+	GetWebhookDeliveriesFunc func(ctx context.Context, filename string) ([]data.WebhookDeliveryEntity, error)
+
+	// InitiateMultipartUploadFunc mocks the InitiateMultipartUpload method.
+	InitiateMultipartUploadFunc func(ctx context.Context, payload data.InitiateMultipartUploadRequest) (*data.MultipartUploadResponse, error)
+
+	// MaintainPartitionsFunc mocks the MaintainPartitions method.
+	MaintainPartitionsFunc func(ctx context.Context, monthsAhead int, retentionInMonths int) error
+
+	// ProcessCSVFunc mocks the ProcessCSV method.
+	ProcessCSVFunc func(ctx context.Context, payload data.UploadRequest, r io.Reader, size int64) (*data.UploadResponse, error)
+
+	// RebuildRollupsFunc mocks the RebuildRollups method.
+	RebuildRollupsFunc func(ctx context.Context) error
+
+	// RetryUploadFunc mocks the RetryUpload method.
+	RetryUploadFunc func(ctx context.Context, filename string) error
+
+	// RollbackUploadFunc mocks the RollbackUpload method.
+	RollbackUploadFunc func(ctx context.Context, filename string, dryRun bool) (*data.RollbackUploadResponse, error)
+
+	// RunDataQualityCheckFunc mocks the RunDataQualityCheck method.
+	RunDataQualityCheckFunc func(ctx context.Context) error
+
 	// UpdateProcessingStatusFunc mocks the UpdateProcessingStatus method.
 	UpdateProcessingStatusFunc func(ctx context.Context, filename string, status data.ProcessingStatus, err error) error
 
+	// WaitForWebhookDeliveriesFunc mocks the WaitForWebhookDeliveries method.
+	WaitForWebhookDeliveriesFunc func(ctx context.Context) error
+
+	// WatchUploadsFunc mocks the WatchUploads method.
+	WatchUploadsFunc func(filename string, uploader string) (<-chan data.UploadEvent, func())
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AbortMultipartUpload holds details about calls to the AbortMultipartUpload method.
+		AbortMultipartUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filename is the filename argument value.
+			Filename string
+			// UploadID is the uploadID argument value.
+			UploadID string
+		}
+		// AbortStaleMultipartUploads holds details about calls to the AbortStaleMultipartUploads method.
+		AbortStaleMultipartUploads []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hours is the hours argument value.
+			Hours int
+		}
+		// AcceptAnomaly holds details about calls to the AcceptAnomaly method.
+		AcceptAnomaly []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// ApplyRetentionPolicy holds details about calls to the ApplyRetentionPolicy method.
+		ApplyRetentionPolicy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// CancelUpload holds details about calls to the CancelUpload method.
+		CancelUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filename is the filename argument value.
+			Filename string
+		}
+		// CompleteMultipartUpload holds details about calls to the CompleteMultipartUpload method.
+		CompleteMultipartUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filename is the filename argument value.
+			Filename string
+			// Payload is the payload argument value.
+			Payload data.CompleteMultipartUploadRequest
+		}
+		// ConsumeSQSMessages holds details about calls to the ConsumeSQSMessages method.
+		ConsumeSQSMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// CreateDataPoints holds details about calls to the CreateDataPoints method.
 		CreateDataPoints []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Filename is the filename argument value.
+			Filename string
 			// DataPoints is the dataPoints argument value.
 			DataPoints [][]string
 		}
+		// DeleteAnomaly holds details about calls to the DeleteAnomaly method.
+		DeleteAnomaly []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
 		// DeleteStaleProcessingStatus holds details about calls to the DeleteStaleProcessingStatus method.
 		DeleteStaleProcessingStatus []struct {
 			// Ctx is the ctx argument value.
@@ -97,15 +294,26 @@ type ServiceMock struct {
 			// Filename is the filename argument value.
 			Filename string
 		}
+		// GenerateDataQualityReports holds details about calls to the GenerateDataQualityReports method.
+		GenerateDataQualityReports []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.DataQualityRequest
+		}
 		// GeneratePreSignedURL holds details about calls to the GeneratePreSignedURL method.
 		GeneratePreSignedURL []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.GeneratePresignedURLRequest
 		}
-		// GetAndProcessSQSMessage holds details about calls to the GetAndProcessSQSMessage method.
-		GetAndProcessSQSMessage []struct {
+		// GetAnomalies holds details about calls to the GetAnomalies method.
+		GetAnomalies []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.GetAnomaliesRequest
 		}
 		// GetDataPoints holds details about calls to the GetDataPoints method.
 		GetDataPoints []struct {
@@ -114,6 +322,22 @@ type ServiceMock struct {
 			// Payload is the payload argument value.
 			Payload data.GetOHLCRequest
 		}
+		// GetDataQualityReports holds details about calls to the GetDataQualityReports method.
+		GetDataQualityReports []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.DataQualityRequest
+		}
+		// GetPartURLs holds details about calls to the GetPartURLs method.
+		GetPartURLs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filename is the filename argument value.
+			Filename string
+			// Payload is the payload argument value.
+			Payload data.GetPartURLsRequest
+		}
 		// GetProcessingStatus holds details about calls to the GetProcessingStatus method.
 		GetProcessingStatus []struct {
 			// Ctx is the ctx argument value.
@@ -121,6 +345,80 @@ type ServiceMock struct {
 			// Filename is the filename argument value.
 			Filename string
 		}
+		// GetRejectedMessages holds details about calls to the GetRejectedMessages method.
+		GetRejectedMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.GetRejectedMessagesRequest
+		}
+		// GetUploads holds details about calls to the GetUploads method.
+		GetUploads []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.GetUploadsRequest
+		}
+		// GetWebhookDeliveries holds details about calls to the GetWebhookDeliveries method.
+		GetWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filename is the filename argument value.
+			Filename string
+		}
+		// InitiateMultipartUpload holds details about calls to the InitiateMultipartUpload method.
+		InitiateMultipartUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.InitiateMultipartUploadRequest
+		}
+		// MaintainPartitions holds details about calls to the MaintainPartitions method.
+		MaintainPartitions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MonthsAhead is the monthsAhead argument value.
+			MonthsAhead int
+			// RetentionInMonths is the retentionInMonths argument value.
+			RetentionInMonths int
+		}
+		// ProcessCSV holds details about calls to the ProcessCSV method.
+		ProcessCSV []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload data.UploadRequest
+			// R is the r argument value.
+			R io.Reader
+			// Size is the size argument value.
+			Size int64
+		}
+		// RebuildRollups holds details about calls to the RebuildRollups method.
+		RebuildRollups []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RetryUpload holds details about calls to the RetryUpload method.
+		RetryUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filename is the filename argument value.
+			Filename string
+		}
+		// RollbackUpload holds details about calls to the RollbackUpload method.
+		RollbackUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filename is the filename argument value.
+			Filename string
+			// DryRun is the dryRun argument value.
+			DryRun bool
+		}
+		// RunDataQualityCheck holds details about calls to the RunDataQualityCheck method.
+		RunDataQualityCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// UpdateProcessingStatus holds details about calls to the UpdateProcessingStatus method.
 		UpdateProcessingStatus []struct {
 			// Ctx is the ctx argument value.
@@ -132,204 +430,575 @@ type ServiceMock struct {
 			// Err is the err argument value.
 			Err error
 		}
+		// WaitForWebhookDeliveries holds details about calls to the WaitForWebhookDeliveries method.
+		WaitForWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// WatchUploads holds details about calls to the WatchUploads method.
+		WatchUploads []struct {
+			// Filename is the filename argument value.
+			Filename string
+			// Uploader is the uploader argument value.
+			Uploader string
+		}
 	}
+	lockAbortMultipartUpload        sync.RWMutex
+	lockAbortStaleMultipartUploads  sync.RWMutex
+	lockAcceptAnomaly               sync.RWMutex
+	lockApplyRetentionPolicy        sync.RWMutex
+	lockCancelUpload                sync.RWMutex
+	lockCompleteMultipartUpload     sync.RWMutex
+	lockConsumeSQSMessages          sync.RWMutex
 	lockCreateDataPoints            sync.RWMutex
+	lockDeleteAnomaly               sync.RWMutex
 	lockDeleteStaleProcessingStatus sync.RWMutex
 	lockDownloadAndProcessCSV       sync.RWMutex
+	lockGenerateDataQualityReports  sync.RWMutex
 	lockGeneratePreSignedURL        sync.RWMutex
-	lockGetAndProcessSQSMessage     sync.RWMutex
+	lockGetAnomalies                sync.RWMutex
 	lockGetDataPoints               sync.RWMutex
+	lockGetDataQualityReports       sync.RWMutex
+	lockGetPartURLs                 sync.RWMutex
 	lockGetProcessingStatus         sync.RWMutex
+	lockGetRejectedMessages         sync.RWMutex
+	lockGetUploads                  sync.RWMutex
+	lockGetWebhookDeliveries        sync.RWMutex
+	lockInitiateMultipartUpload     sync.RWMutex
+	lockMaintainPartitions          sync.RWMutex
+	lockProcessCSV                  sync.RWMutex
+	lockRebuildRollups              sync.RWMutex
+	lockRetryUpload                 sync.RWMutex
+	lockRollbackUpload              sync.RWMutex
+	lockRunDataQualityCheck         sync.RWMutex
 	lockUpdateProcessingStatus      sync.RWMutex
+	lockWaitForWebhookDeliveries    sync.RWMutex
+	lockWatchUploads                sync.RWMutex
 }
 
-// CreateDataPoints calls CreateDataPointsFunc.
-func (mock *ServiceMock) CreateDataPoints(ctx context.Context, dataPoints [][]string) error {
-	if mock.CreateDataPointsFunc == nil {
-		panic("ServiceMock.CreateDataPointsFunc: method is nil but Service.CreateDataPoints was just called")
+// AbortMultipartUpload calls AbortMultipartUploadFunc.
+func (mock *ServiceMock) AbortMultipartUpload(ctx context.Context, filename string, uploadID string) error {
+	if mock.AbortMultipartUploadFunc == nil {
+		panic("ServiceMock.AbortMultipartUploadFunc: method is nil but Service.AbortMultipartUpload was just called")
 	}
 	callInfo := struct {
-		Ctx        context.Context
-		DataPoints [][]string
+		Ctx      context.Context
+		Filename string
+		UploadID string
 	}{
-		Ctx:        ctx,
-		DataPoints: dataPoints,
+		Ctx:      ctx,
+		Filename: filename,
+		UploadID: uploadID,
 	}
-	mock.lockCreateDataPoints.Lock()
-	mock.calls.CreateDataPoints = append(mock.calls.CreateDataPoints, callInfo)
-	mock.lockCreateDataPoints.Unlock()
-	return mock.CreateDataPointsFunc(ctx, dataPoints)
+	mock.lockAbortMultipartUpload.Lock()
+	mock.calls.AbortMultipartUpload = append(mock.calls.AbortMultipartUpload, callInfo)
+	mock.lockAbortMultipartUpload.Unlock()
+	return mock.AbortMultipartUploadFunc(ctx, filename, uploadID)
 }
 
-// CreateDataPointsCalls gets all the calls that were made to CreateDataPoints.
+// AbortMultipartUploadCalls gets all the calls that were made to AbortMultipartUpload.
 // Check the length with:
 //
-//	len(mockedService.CreateDataPointsCalls())
-func (mock *ServiceMock) CreateDataPointsCalls() []struct {
-	Ctx        context.Context
-	DataPoints [][]string
+//	len(mockedService.AbortMultipartUploadCalls())
+func (mock *ServiceMock) AbortMultipartUploadCalls() []struct {
+	Ctx      context.Context
+	Filename string
+	UploadID string
 } {
 	var calls []struct {
-		Ctx        context.Context
-		DataPoints [][]string
+		Ctx      context.Context
+		Filename string
+		UploadID string
 	}
-	mock.lockCreateDataPoints.RLock()
-	calls = mock.calls.CreateDataPoints
-	mock.lockCreateDataPoints.RUnlock()
+	mock.lockAbortMultipartUpload.RLock()
+	calls = mock.calls.AbortMultipartUpload
+	mock.lockAbortMultipartUpload.RUnlock()
 	return calls
 }
 
-// DeleteStaleProcessingStatus calls DeleteStaleProcessingStatusFunc.
-func (mock *ServiceMock) DeleteStaleProcessingStatus(ctx context.Context, days int) error {
-	if mock.DeleteStaleProcessingStatusFunc == nil {
-		panic("ServiceMock.DeleteStaleProcessingStatusFunc: method is nil but Service.DeleteStaleProcessingStatus was just called")
+// AbortStaleMultipartUploads calls AbortStaleMultipartUploadsFunc.
+func (mock *ServiceMock) AbortStaleMultipartUploads(ctx context.Context, hours int) error {
+	if mock.AbortStaleMultipartUploadsFunc == nil {
+		panic("ServiceMock.AbortStaleMultipartUploadsFunc: method is nil but Service.AbortStaleMultipartUploads was just called")
 	}
 	callInfo := struct {
-		Ctx  context.Context
-		Days int
+		Ctx   context.Context
+		Hours int
 	}{
-		Ctx:  ctx,
-		Days: days,
+		Ctx:   ctx,
+		Hours: hours,
 	}
-	mock.lockDeleteStaleProcessingStatus.Lock()
-	mock.calls.DeleteStaleProcessingStatus = append(mock.calls.DeleteStaleProcessingStatus, callInfo)
-	mock.lockDeleteStaleProcessingStatus.Unlock()
-	return mock.DeleteStaleProcessingStatusFunc(ctx, days)
+	mock.lockAbortStaleMultipartUploads.Lock()
+	mock.calls.AbortStaleMultipartUploads = append(mock.calls.AbortStaleMultipartUploads, callInfo)
+	mock.lockAbortStaleMultipartUploads.Unlock()
+	return mock.AbortStaleMultipartUploadsFunc(ctx, hours)
 }
 
-// DeleteStaleProcessingStatusCalls gets all the calls that were made to DeleteStaleProcessingStatus.
+// AbortStaleMultipartUploadsCalls gets all the calls that were made to AbortStaleMultipartUploads.
 // Check the length with:
 //
-//	len(mockedService.DeleteStaleProcessingStatusCalls())
-func (mock *ServiceMock) DeleteStaleProcessingStatusCalls() []struct {
-	Ctx  context.Context
-	Days int
+//	len(mockedService.AbortStaleMultipartUploadsCalls())
+func (mock *ServiceMock) AbortStaleMultipartUploadsCalls() []struct {
+	Ctx   context.Context
+	Hours int
 } {
 	var calls []struct {
-		Ctx  context.Context
-		Days int
+		Ctx   context.Context
+		Hours int
 	}
-	mock.lockDeleteStaleProcessingStatus.RLock()
-	calls = mock.calls.DeleteStaleProcessingStatus
-	mock.lockDeleteStaleProcessingStatus.RUnlock()
+	mock.lockAbortStaleMultipartUploads.RLock()
+	calls = mock.calls.AbortStaleMultipartUploads
+	mock.lockAbortStaleMultipartUploads.RUnlock()
 	return calls
 }
 
-// DownloadAndProcessCSV calls DownloadAndProcessCSVFunc.
-func (mock *ServiceMock) DownloadAndProcessCSV(ctx context.Context, filename string) error {
-	if mock.DownloadAndProcessCSVFunc == nil {
-		panic("ServiceMock.DownloadAndProcessCSVFunc: method is nil but Service.DownloadAndProcessCSV was just called")
+// AcceptAnomaly calls AcceptAnomalyFunc.
+func (mock *ServiceMock) AcceptAnomaly(ctx context.Context, id int64) error {
+	if mock.AcceptAnomalyFunc == nil {
+		panic("ServiceMock.AcceptAnomalyFunc: method is nil but Service.AcceptAnomaly was just called")
 	}
 	callInfo := struct {
-		Ctx      context.Context
-		Filename string
+		Ctx context.Context
+		ID  int64
 	}{
-		Ctx:      ctx,
-		Filename: filename,
+		Ctx: ctx,
+		ID:  id,
 	}
-	mock.lockDownloadAndProcessCSV.Lock()
-	mock.calls.DownloadAndProcessCSV = append(mock.calls.DownloadAndProcessCSV, callInfo)
-	mock.lockDownloadAndProcessCSV.Unlock()
-	return mock.DownloadAndProcessCSVFunc(ctx, filename)
+	mock.lockAcceptAnomaly.Lock()
+	mock.calls.AcceptAnomaly = append(mock.calls.AcceptAnomaly, callInfo)
+	mock.lockAcceptAnomaly.Unlock()
+	return mock.AcceptAnomalyFunc(ctx, id)
 }
 
-// DownloadAndProcessCSVCalls gets all the calls that were made to DownloadAndProcessCSV.
+// AcceptAnomalyCalls gets all the calls that were made to AcceptAnomaly.
 // Check the length with:
 //
-//	len(mockedService.DownloadAndProcessCSVCalls())
-func (mock *ServiceMock) DownloadAndProcessCSVCalls() []struct {
-	Ctx      context.Context
-	Filename string
+//	len(mockedService.AcceptAnomalyCalls())
+func (mock *ServiceMock) AcceptAnomalyCalls() []struct {
+	Ctx context.Context
+	ID  int64
 } {
 	var calls []struct {
-		Ctx      context.Context
-		Filename string
+		Ctx context.Context
+		ID  int64
 	}
-	mock.lockDownloadAndProcessCSV.RLock()
-	calls = mock.calls.DownloadAndProcessCSV
-	mock.lockDownloadAndProcessCSV.RUnlock()
+	mock.lockAcceptAnomaly.RLock()
+	calls = mock.calls.AcceptAnomaly
+	mock.lockAcceptAnomaly.RUnlock()
 	return calls
 }
 
-// GeneratePreSignedURL calls GeneratePreSignedURLFunc.
-func (mock *ServiceMock) GeneratePreSignedURL(ctx context.Context) (*data.GeneratePresignedURLResponse, error) {
-	if mock.GeneratePreSignedURLFunc == nil {
-		panic("ServiceMock.GeneratePreSignedURLFunc: method is nil but Service.GeneratePreSignedURL was just called")
+// ApplyRetentionPolicy calls ApplyRetentionPolicyFunc.
+func (mock *ServiceMock) ApplyRetentionPolicy(ctx context.Context) error {
+	if mock.ApplyRetentionPolicyFunc == nil {
+		panic("ServiceMock.ApplyRetentionPolicyFunc: method is nil but Service.ApplyRetentionPolicy was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
 	}{
 		Ctx: ctx,
 	}
-	mock.lockGeneratePreSignedURL.Lock()
-	mock.calls.GeneratePreSignedURL = append(mock.calls.GeneratePreSignedURL, callInfo)
-	mock.lockGeneratePreSignedURL.Unlock()
-	return mock.GeneratePreSignedURLFunc(ctx)
+	mock.lockApplyRetentionPolicy.Lock()
+	mock.calls.ApplyRetentionPolicy = append(mock.calls.ApplyRetentionPolicy, callInfo)
+	mock.lockApplyRetentionPolicy.Unlock()
+	return mock.ApplyRetentionPolicyFunc(ctx)
 }
 
-// GeneratePreSignedURLCalls gets all the calls that were made to GeneratePreSignedURL.
+// ApplyRetentionPolicyCalls gets all the calls that were made to ApplyRetentionPolicy.
 // Check the length with:
 //
-//	len(mockedService.GeneratePreSignedURLCalls())
-func (mock *ServiceMock) GeneratePreSignedURLCalls() []struct {
+//	len(mockedService.ApplyRetentionPolicyCalls())
+func (mock *ServiceMock) ApplyRetentionPolicyCalls() []struct {
 	Ctx context.Context
 } {
 	var calls []struct {
 		Ctx context.Context
 	}
-	mock.lockGeneratePreSignedURL.RLock()
-	calls = mock.calls.GeneratePreSignedURL
-	mock.lockGeneratePreSignedURL.RUnlock()
+	mock.lockApplyRetentionPolicy.RLock()
+	calls = mock.calls.ApplyRetentionPolicy
+	mock.lockApplyRetentionPolicy.RUnlock()
 	return calls
 }
 
-// GetAndProcessSQSMessage calls GetAndProcessSQSMessageFunc.
-func (mock *ServiceMock) GetAndProcessSQSMessage(ctx context.Context) error {
-	if mock.GetAndProcessSQSMessageFunc == nil {
-		panic("ServiceMock.GetAndProcessSQSMessageFunc: method is nil but Service.GetAndProcessSQSMessage was just called")
+// CancelUpload calls CancelUploadFunc.
+func (mock *ServiceMock) CancelUpload(ctx context.Context, filename string) error {
+	if mock.CancelUploadFunc == nil {
+		panic("ServiceMock.CancelUploadFunc: method is nil but Service.CancelUpload was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx      context.Context
+		Filename string
 	}{
-		Ctx: ctx,
+		Ctx:      ctx,
+		Filename: filename,
 	}
-	mock.lockGetAndProcessSQSMessage.Lock()
-	mock.calls.GetAndProcessSQSMessage = append(mock.calls.GetAndProcessSQSMessage, callInfo)
-	mock.lockGetAndProcessSQSMessage.Unlock()
-	return mock.GetAndProcessSQSMessageFunc(ctx)
+	mock.lockCancelUpload.Lock()
+	mock.calls.CancelUpload = append(mock.calls.CancelUpload, callInfo)
+	mock.lockCancelUpload.Unlock()
+	return mock.CancelUploadFunc(ctx, filename)
 }
 
-// GetAndProcessSQSMessageCalls gets all the calls that were made to GetAndProcessSQSMessage.
+// CancelUploadCalls gets all the calls that were made to CancelUpload.
 // Check the length with:
 //
-//	len(mockedService.GetAndProcessSQSMessageCalls())
-func (mock *ServiceMock) GetAndProcessSQSMessageCalls() []struct {
-	Ctx context.Context
+//	len(mockedService.CancelUploadCalls())
+func (mock *ServiceMock) CancelUploadCalls() []struct {
+	Ctx      context.Context
+	Filename string
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx      context.Context
+		Filename string
 	}
-	mock.lockGetAndProcessSQSMessage.RLock()
-	calls = mock.calls.GetAndProcessSQSMessage
-	mock.lockGetAndProcessSQSMessage.RUnlock()
+	mock.lockCancelUpload.RLock()
+	calls = mock.calls.CancelUpload
+	mock.lockCancelUpload.RUnlock()
 	return calls
 }
 
-// GetDataPoints calls GetDataPointsFunc.
-func (mock *ServiceMock) GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, *int, error) {
-	if mock.GetDataPointsFunc == nil {
-		panic("ServiceMock.GetDataPointsFunc: method is nil but Service.GetDataPoints was just called")
+// CompleteMultipartUpload calls CompleteMultipartUploadFunc.
+func (mock *ServiceMock) CompleteMultipartUpload(ctx context.Context, filename string, payload data.CompleteMultipartUploadRequest) error {
+	if mock.CompleteMultipartUploadFunc == nil {
+		panic("ServiceMock.CompleteMultipartUploadFunc: method is nil but Service.CompleteMultipartUpload was just called")
 	}
 	callInfo := struct {
-		Ctx     context.Context
-		Payload data.GetOHLCRequest
+		Ctx      context.Context
+		Filename string
+		Payload  data.CompleteMultipartUploadRequest
 	}{
-		Ctx:     ctx,
-		Payload: payload,
+		Ctx:      ctx,
+		Filename: filename,
+		Payload:  payload,
 	}
-	mock.lockGetDataPoints.Lock()
-	mock.calls.GetDataPoints = append(mock.calls.GetDataPoints, callInfo)
-	mock.lockGetDataPoints.Unlock()
+	mock.lockCompleteMultipartUpload.Lock()
+	mock.calls.CompleteMultipartUpload = append(mock.calls.CompleteMultipartUpload, callInfo)
+	mock.lockCompleteMultipartUpload.Unlock()
+	return mock.CompleteMultipartUploadFunc(ctx, filename, payload)
+}
+
+// CompleteMultipartUploadCalls gets all the calls that were made to CompleteMultipartUpload.
+// Check the length with:
+//
+//	len(mockedService.CompleteMultipartUploadCalls())
+func (mock *ServiceMock) CompleteMultipartUploadCalls() []struct {
+	Ctx      context.Context
+	Filename string
+	Payload  data.CompleteMultipartUploadRequest
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Filename string
+		Payload  data.CompleteMultipartUploadRequest
+	}
+	mock.lockCompleteMultipartUpload.RLock()
+	calls = mock.calls.CompleteMultipartUpload
+	mock.lockCompleteMultipartUpload.RUnlock()
+	return calls
+}
+
+// ConsumeSQSMessages calls ConsumeSQSMessagesFunc.
+func (mock *ServiceMock) ConsumeSQSMessages(ctx context.Context) error {
+	if mock.ConsumeSQSMessagesFunc == nil {
+		panic("ServiceMock.ConsumeSQSMessagesFunc: method is nil but Service.ConsumeSQSMessages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockConsumeSQSMessages.Lock()
+	mock.calls.ConsumeSQSMessages = append(mock.calls.ConsumeSQSMessages, callInfo)
+	mock.lockConsumeSQSMessages.Unlock()
+	return mock.ConsumeSQSMessagesFunc(ctx)
+}
+
+// ConsumeSQSMessagesCalls gets all the calls that were made to ConsumeSQSMessages.
+// Check the length with:
+//
+//	len(mockedService.ConsumeSQSMessagesCalls())
+func (mock *ServiceMock) ConsumeSQSMessagesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockConsumeSQSMessages.RLock()
+	calls = mock.calls.ConsumeSQSMessages
+	mock.lockConsumeSQSMessages.RUnlock()
+	return calls
+}
+
+// CreateDataPoints calls CreateDataPointsFunc.
+func (mock *ServiceMock) CreateDataPoints(ctx context.Context, filename string, dataPoints [][]string) error {
+	if mock.CreateDataPointsFunc == nil {
+		panic("ServiceMock.CreateDataPointsFunc: method is nil but Service.CreateDataPoints was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Filename   string
+		DataPoints [][]string
+	}{
+		Ctx:        ctx,
+		Filename:   filename,
+		DataPoints: dataPoints,
+	}
+	mock.lockCreateDataPoints.Lock()
+	mock.calls.CreateDataPoints = append(mock.calls.CreateDataPoints, callInfo)
+	mock.lockCreateDataPoints.Unlock()
+	return mock.CreateDataPointsFunc(ctx, filename, dataPoints)
+}
+
+// CreateDataPointsCalls gets all the calls that were made to CreateDataPoints.
+// Check the length with:
+//
+//	len(mockedService.CreateDataPointsCalls())
+func (mock *ServiceMock) CreateDataPointsCalls() []struct {
+	Ctx        context.Context
+	Filename   string
+	DataPoints [][]string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Filename   string
+		DataPoints [][]string
+	}
+	mock.lockCreateDataPoints.RLock()
+	calls = mock.calls.CreateDataPoints
+	mock.lockCreateDataPoints.RUnlock()
+	return calls
+}
+
+// DeleteAnomaly calls DeleteAnomalyFunc.
+func (mock *ServiceMock) DeleteAnomaly(ctx context.Context, id int64) error {
+	if mock.DeleteAnomalyFunc == nil {
+		panic("ServiceMock.DeleteAnomalyFunc: method is nil but Service.DeleteAnomaly was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteAnomaly.Lock()
+	mock.calls.DeleteAnomaly = append(mock.calls.DeleteAnomaly, callInfo)
+	mock.lockDeleteAnomaly.Unlock()
+	return mock.DeleteAnomalyFunc(ctx, id)
+}
+
+// DeleteAnomalyCalls gets all the calls that were made to DeleteAnomaly.
+// Check the length with:
+//
+//	len(mockedService.DeleteAnomalyCalls())
+func (mock *ServiceMock) DeleteAnomalyCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	mock.lockDeleteAnomaly.RLock()
+	calls = mock.calls.DeleteAnomaly
+	mock.lockDeleteAnomaly.RUnlock()
+	return calls
+}
+
+// DeleteStaleProcessingStatus calls DeleteStaleProcessingStatusFunc.
+func (mock *ServiceMock) DeleteStaleProcessingStatus(ctx context.Context, days int) error {
+	if mock.DeleteStaleProcessingStatusFunc == nil {
+		panic("ServiceMock.DeleteStaleProcessingStatusFunc: method is nil but Service.DeleteStaleProcessingStatus was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Days int
+	}{
+		Ctx:  ctx,
+		Days: days,
+	}
+	mock.lockDeleteStaleProcessingStatus.Lock()
+	mock.calls.DeleteStaleProcessingStatus = append(mock.calls.DeleteStaleProcessingStatus, callInfo)
+	mock.lockDeleteStaleProcessingStatus.Unlock()
+	return mock.DeleteStaleProcessingStatusFunc(ctx, days)
+}
+
+// DeleteStaleProcessingStatusCalls gets all the calls that were made to DeleteStaleProcessingStatus.
+// Check the length with:
+//
+//	len(mockedService.DeleteStaleProcessingStatusCalls())
+func (mock *ServiceMock) DeleteStaleProcessingStatusCalls() []struct {
+	Ctx  context.Context
+	Days int
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Days int
+	}
+	mock.lockDeleteStaleProcessingStatus.RLock()
+	calls = mock.calls.DeleteStaleProcessingStatus
+	mock.lockDeleteStaleProcessingStatus.RUnlock()
+	return calls
+}
+
+// DownloadAndProcessCSV calls DownloadAndProcessCSVFunc.
+func (mock *ServiceMock) DownloadAndProcessCSV(ctx context.Context, filename string) error {
+	if mock.DownloadAndProcessCSVFunc == nil {
+		panic("ServiceMock.DownloadAndProcessCSVFunc: method is nil but Service.DownloadAndProcessCSV was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Filename string
+	}{
+		Ctx:      ctx,
+		Filename: filename,
+	}
+	mock.lockDownloadAndProcessCSV.Lock()
+	mock.calls.DownloadAndProcessCSV = append(mock.calls.DownloadAndProcessCSV, callInfo)
+	mock.lockDownloadAndProcessCSV.Unlock()
+	return mock.DownloadAndProcessCSVFunc(ctx, filename)
+}
+
+// DownloadAndProcessCSVCalls gets all the calls that were made to DownloadAndProcessCSV.
+// Check the length with:
+//
+//	len(mockedService.DownloadAndProcessCSVCalls())
+func (mock *ServiceMock) DownloadAndProcessCSVCalls() []struct {
+	Ctx      context.Context
+	Filename string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Filename string
+	}
+	mock.lockDownloadAndProcessCSV.RLock()
+	calls = mock.calls.DownloadAndProcessCSV
+	mock.lockDownloadAndProcessCSV.RUnlock()
+	return calls
+}
+
+// GenerateDataQualityReports calls GenerateDataQualityReportsFunc.
+func (mock *ServiceMock) GenerateDataQualityReports(ctx context.Context, payload data.DataQualityRequest) ([]data.DataQualityEntity, error) {
+	if mock.GenerateDataQualityReportsFunc == nil {
+		panic("ServiceMock.GenerateDataQualityReportsFunc: method is nil but Service.GenerateDataQualityReports was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.DataQualityRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGenerateDataQualityReports.Lock()
+	mock.calls.GenerateDataQualityReports = append(mock.calls.GenerateDataQualityReports, callInfo)
+	mock.lockGenerateDataQualityReports.Unlock()
+	return mock.GenerateDataQualityReportsFunc(ctx, payload)
+}
+
+// GenerateDataQualityReportsCalls gets all the calls that were made to GenerateDataQualityReports.
+// Check the length with:
+//
+//	len(mockedService.GenerateDataQualityReportsCalls())
+func (mock *ServiceMock) GenerateDataQualityReportsCalls() []struct {
+	Ctx     context.Context
+	Payload data.DataQualityRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.DataQualityRequest
+	}
+	mock.lockGenerateDataQualityReports.RLock()
+	calls = mock.calls.GenerateDataQualityReports
+	mock.lockGenerateDataQualityReports.RUnlock()
+	return calls
+}
+
+// GeneratePreSignedURL calls GeneratePreSignedURLFunc.
+func (mock *ServiceMock) GeneratePreSignedURL(ctx context.Context, payload data.GeneratePresignedURLRequest) (*data.GeneratePresignedURLResponse, error) {
+	if mock.GeneratePreSignedURLFunc == nil {
+		panic("ServiceMock.GeneratePreSignedURLFunc: method is nil but Service.GeneratePreSignedURL was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.GeneratePresignedURLRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGeneratePreSignedURL.Lock()
+	mock.calls.GeneratePreSignedURL = append(mock.calls.GeneratePreSignedURL, callInfo)
+	mock.lockGeneratePreSignedURL.Unlock()
+	return mock.GeneratePreSignedURLFunc(ctx, payload)
+}
+
+// GeneratePreSignedURLCalls gets all the calls that were made to GeneratePreSignedURL.
+// Check the length with:
+//
+//	len(mockedService.GeneratePreSignedURLCalls())
+func (mock *ServiceMock) GeneratePreSignedURLCalls() []struct {
+	Ctx     context.Context
+	Payload data.GeneratePresignedURLRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.GeneratePresignedURLRequest
+	}
+	mock.lockGeneratePreSignedURL.RLock()
+	calls = mock.calls.GeneratePreSignedURL
+	mock.lockGeneratePreSignedURL.RUnlock()
+	return calls
+}
+
+// GetAnomalies calls GetAnomaliesFunc.
+func (mock *ServiceMock) GetAnomalies(ctx context.Context, payload data.GetAnomaliesRequest) ([]data.AnomalyEntity, *int, error) {
+	if mock.GetAnomaliesFunc == nil {
+		panic("ServiceMock.GetAnomaliesFunc: method is nil but Service.GetAnomalies was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.GetAnomaliesRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGetAnomalies.Lock()
+	mock.calls.GetAnomalies = append(mock.calls.GetAnomalies, callInfo)
+	mock.lockGetAnomalies.Unlock()
+	return mock.GetAnomaliesFunc(ctx, payload)
+}
+
+// GetAnomaliesCalls gets all the calls that were made to GetAnomalies.
+// Check the length with:
+//
+//	len(mockedService.GetAnomaliesCalls())
+func (mock *ServiceMock) GetAnomaliesCalls() []struct {
+	Ctx     context.Context
+	Payload data.GetAnomaliesRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.GetAnomaliesRequest
+	}
+	mock.lockGetAnomalies.RLock()
+	calls = mock.calls.GetAnomalies
+	mock.lockGetAnomalies.RUnlock()
+	return calls
+}
+
+// GetDataPoints calls GetDataPointsFunc.
+func (mock *ServiceMock) GetDataPoints(ctx context.Context, payload data.GetOHLCRequest) ([]data.OHLCEntity, *int, error) {
+	if mock.GetDataPointsFunc == nil {
+		panic("ServiceMock.GetDataPointsFunc: method is nil but Service.GetDataPoints was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.GetOHLCRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGetDataPoints.Lock()
+	mock.calls.GetDataPoints = append(mock.calls.GetDataPoints, callInfo)
+	mock.lockGetDataPoints.Unlock()
 	return mock.GetDataPointsFunc(ctx, payload)
 }
 
@@ -351,6 +1020,82 @@ func (mock *ServiceMock) GetDataPointsCalls() []struct {
 	return calls
 }
 
+// GetDataQualityReports calls GetDataQualityReportsFunc.
+func (mock *ServiceMock) GetDataQualityReports(ctx context.Context, payload data.DataQualityRequest) ([]data.DataQualityEntity, error) {
+	if mock.GetDataQualityReportsFunc == nil {
+		panic("ServiceMock.GetDataQualityReportsFunc: method is nil but Service.GetDataQualityReports was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.DataQualityRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGetDataQualityReports.Lock()
+	mock.calls.GetDataQualityReports = append(mock.calls.GetDataQualityReports, callInfo)
+	mock.lockGetDataQualityReports.Unlock()
+	return mock.GetDataQualityReportsFunc(ctx, payload)
+}
+
+// GetDataQualityReportsCalls gets all the calls that were made to GetDataQualityReports.
+// Check the length with:
+//
+//	len(mockedService.GetDataQualityReportsCalls())
+func (mock *ServiceMock) GetDataQualityReportsCalls() []struct {
+	Ctx     context.Context
+	Payload data.DataQualityRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.DataQualityRequest
+	}
+	mock.lockGetDataQualityReports.RLock()
+	calls = mock.calls.GetDataQualityReports
+	mock.lockGetDataQualityReports.RUnlock()
+	return calls
+}
+
+// GetPartURLs calls GetPartURLsFunc.
+func (mock *ServiceMock) GetPartURLs(ctx context.Context, filename string, payload data.GetPartURLsRequest) (*data.MultipartUploadResponse, error) {
+	if mock.GetPartURLsFunc == nil {
+		panic("ServiceMock.GetPartURLsFunc: method is nil but Service.GetPartURLs was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Filename string
+		Payload  data.GetPartURLsRequest
+	}{
+		Ctx:      ctx,
+		Filename: filename,
+		Payload:  payload,
+	}
+	mock.lockGetPartURLs.Lock()
+	mock.calls.GetPartURLs = append(mock.calls.GetPartURLs, callInfo)
+	mock.lockGetPartURLs.Unlock()
+	return mock.GetPartURLsFunc(ctx, filename, payload)
+}
+
+// GetPartURLsCalls gets all the calls that were made to GetPartURLs.
+// Check the length with:
+//
+//	len(mockedService.GetPartURLsCalls())
+func (mock *ServiceMock) GetPartURLsCalls() []struct {
+	Ctx      context.Context
+	Filename string
+	Payload  data.GetPartURLsRequest
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Filename string
+		Payload  data.GetPartURLsRequest
+	}
+	mock.lockGetPartURLs.RLock()
+	calls = mock.calls.GetPartURLs
+	mock.lockGetPartURLs.RUnlock()
+	return calls
+}
+
 // GetProcessingStatus calls GetProcessingStatusFunc.
 func (mock *ServiceMock) GetProcessingStatus(ctx context.Context, filename string) (*data.ProcessingStatusEntity, error) {
 	if mock.GetProcessingStatusFunc == nil {
@@ -387,6 +1132,374 @@ func (mock *ServiceMock) GetProcessingStatusCalls() []struct {
 	return calls
 }
 
+// GetRejectedMessages calls GetRejectedMessagesFunc.
+func (mock *ServiceMock) GetRejectedMessages(ctx context.Context, payload data.GetRejectedMessagesRequest) ([]data.RejectedMessageEntity, *int, error) {
+	if mock.GetRejectedMessagesFunc == nil {
+		panic("ServiceMock.GetRejectedMessagesFunc: method is nil but Service.GetRejectedMessages was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.GetRejectedMessagesRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGetRejectedMessages.Lock()
+	mock.calls.GetRejectedMessages = append(mock.calls.GetRejectedMessages, callInfo)
+	mock.lockGetRejectedMessages.Unlock()
+	return mock.GetRejectedMessagesFunc(ctx, payload)
+}
+
+// GetRejectedMessagesCalls gets all the calls that were made to GetRejectedMessages.
+// Check the length with:
+//
+//	len(mockedService.GetRejectedMessagesCalls())
+func (mock *ServiceMock) GetRejectedMessagesCalls() []struct {
+	Ctx     context.Context
+	Payload data.GetRejectedMessagesRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.GetRejectedMessagesRequest
+	}
+	mock.lockGetRejectedMessages.RLock()
+	calls = mock.calls.GetRejectedMessages
+	mock.lockGetRejectedMessages.RUnlock()
+	return calls
+}
+
+// GetUploads calls GetUploadsFunc.
+func (mock *ServiceMock) GetUploads(ctx context.Context, payload data.GetUploadsRequest) ([]data.ProcessingStatusEntity, *int, error) {
+	if mock.GetUploadsFunc == nil {
+		panic("ServiceMock.GetUploadsFunc: method is nil but Service.GetUploads was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.GetUploadsRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockGetUploads.Lock()
+	mock.calls.GetUploads = append(mock.calls.GetUploads, callInfo)
+	mock.lockGetUploads.Unlock()
+	return mock.GetUploadsFunc(ctx, payload)
+}
+
+// GetUploadsCalls gets all the calls that were made to GetUploads.
+// Check the length with:
+//
+//	len(mockedService.GetUploadsCalls())
+func (mock *ServiceMock) GetUploadsCalls() []struct {
+	Ctx     context.Context
+	Payload data.GetUploadsRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.GetUploadsRequest
+	}
+	mock.lockGetUploads.RLock()
+	calls = mock.calls.GetUploads
+	mock.lockGetUploads.RUnlock()
+	return calls
+}
+
+// GetWebhookDeliveries calls GetWebhookDeliveriesFunc.
+func (mock *ServiceMock) GetWebhookDeliveries(ctx context.Context, filename string) ([]data.WebhookDeliveryEntity, error) {
+	if mock.GetWebhookDeliveriesFunc == nil {
+		panic("ServiceMock.GetWebhookDeliveriesFunc: method is nil but Service.GetWebhookDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Filename string
+	}{
+		Ctx:      ctx,
+		Filename: filename,
+	}
+	mock.lockGetWebhookDeliveries.Lock()
+	mock.calls.GetWebhookDeliveries = append(mock.calls.GetWebhookDeliveries, callInfo)
+	mock.lockGetWebhookDeliveries.Unlock()
+	return mock.GetWebhookDeliveriesFunc(ctx, filename)
+}
+
+// GetWebhookDeliveriesCalls gets all the calls that were made to GetWebhookDeliveries.
+// Check the length with:
+//
+//	len(mockedService.GetWebhookDeliveriesCalls())
+func (mock *ServiceMock) GetWebhookDeliveriesCalls() []struct {
+	Ctx      context.Context
+	Filename string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Filename string
+	}
+	mock.lockGetWebhookDeliveries.RLock()
+	calls = mock.calls.GetWebhookDeliveries
+	mock.lockGetWebhookDeliveries.RUnlock()
+	return calls
+}
+
+// InitiateMultipartUpload calls InitiateMultipartUploadFunc.
+func (mock *ServiceMock) InitiateMultipartUpload(ctx context.Context, payload data.InitiateMultipartUploadRequest) (*data.MultipartUploadResponse, error) {
+	if mock.InitiateMultipartUploadFunc == nil {
+		panic("ServiceMock.InitiateMultipartUploadFunc: method is nil but Service.InitiateMultipartUpload was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.InitiateMultipartUploadRequest
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+	}
+	mock.lockInitiateMultipartUpload.Lock()
+	mock.calls.InitiateMultipartUpload = append(mock.calls.InitiateMultipartUpload, callInfo)
+	mock.lockInitiateMultipartUpload.Unlock()
+	return mock.InitiateMultipartUploadFunc(ctx, payload)
+}
+
+// InitiateMultipartUploadCalls gets all the calls that were made to InitiateMultipartUpload.
+// Check the length with:
+//
+//	len(mockedService.InitiateMultipartUploadCalls())
+func (mock *ServiceMock) InitiateMultipartUploadCalls() []struct {
+	Ctx     context.Context
+	Payload data.InitiateMultipartUploadRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.InitiateMultipartUploadRequest
+	}
+	mock.lockInitiateMultipartUpload.RLock()
+	calls = mock.calls.InitiateMultipartUpload
+	mock.lockInitiateMultipartUpload.RUnlock()
+	return calls
+}
+
+// MaintainPartitions calls MaintainPartitionsFunc.
+func (mock *ServiceMock) MaintainPartitions(ctx context.Context, monthsAhead int, retentionInMonths int) error {
+	if mock.MaintainPartitionsFunc == nil {
+		panic("ServiceMock.MaintainPartitionsFunc: method is nil but Service.MaintainPartitions was just called")
+	}
+	callInfo := struct {
+		Ctx               context.Context
+		MonthsAhead       int
+		RetentionInMonths int
+	}{
+		Ctx:               ctx,
+		MonthsAhead:       monthsAhead,
+		RetentionInMonths: retentionInMonths,
+	}
+	mock.lockMaintainPartitions.Lock()
+	mock.calls.MaintainPartitions = append(mock.calls.MaintainPartitions, callInfo)
+	mock.lockMaintainPartitions.Unlock()
+	return mock.MaintainPartitionsFunc(ctx, monthsAhead, retentionInMonths)
+}
+
+// MaintainPartitionsCalls gets all the calls that were made to MaintainPartitions.
+// Check the length with:
+//
+//	len(mockedService.MaintainPartitionsCalls())
+func (mock *ServiceMock) MaintainPartitionsCalls() []struct {
+	Ctx               context.Context
+	MonthsAhead       int
+	RetentionInMonths int
+} {
+	var calls []struct {
+		Ctx               context.Context
+		MonthsAhead       int
+		RetentionInMonths int
+	}
+	mock.lockMaintainPartitions.RLock()
+	calls = mock.calls.MaintainPartitions
+	mock.lockMaintainPartitions.RUnlock()
+	return calls
+}
+
+// ProcessCSV calls ProcessCSVFunc.
+func (mock *ServiceMock) ProcessCSV(ctx context.Context, payload data.UploadRequest, r io.Reader, size int64) (*data.UploadResponse, error) {
+	if mock.ProcessCSVFunc == nil {
+		panic("ServiceMock.ProcessCSVFunc: method is nil but Service.ProcessCSV was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload data.UploadRequest
+		R       io.Reader
+		Size    int64
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+		R:       r,
+		Size:    size,
+	}
+	mock.lockProcessCSV.Lock()
+	mock.calls.ProcessCSV = append(mock.calls.ProcessCSV, callInfo)
+	mock.lockProcessCSV.Unlock()
+	return mock.ProcessCSVFunc(ctx, payload, r, size)
+}
+
+// ProcessCSVCalls gets all the calls that were made to ProcessCSV.
+// Check the length with:
+//
+//	len(mockedService.ProcessCSVCalls())
+func (mock *ServiceMock) ProcessCSVCalls() []struct {
+	Ctx     context.Context
+	Payload data.UploadRequest
+	R       io.Reader
+	Size    int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload data.UploadRequest
+		R       io.Reader
+		Size    int64
+	}
+	mock.lockProcessCSV.RLock()
+	calls = mock.calls.ProcessCSV
+	mock.lockProcessCSV.RUnlock()
+	return calls
+}
+
+// RebuildRollups calls RebuildRollupsFunc.
+func (mock *ServiceMock) RebuildRollups(ctx context.Context) error {
+	if mock.RebuildRollupsFunc == nil {
+		panic("ServiceMock.RebuildRollupsFunc: method is nil but Service.RebuildRollups was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRebuildRollups.Lock()
+	mock.calls.RebuildRollups = append(mock.calls.RebuildRollups, callInfo)
+	mock.lockRebuildRollups.Unlock()
+	return mock.RebuildRollupsFunc(ctx)
+}
+
+// RebuildRollupsCalls gets all the calls that were made to RebuildRollups.
+// Check the length with:
+//
+//	len(mockedService.RebuildRollupsCalls())
+func (mock *ServiceMock) RebuildRollupsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRebuildRollups.RLock()
+	calls = mock.calls.RebuildRollups
+	mock.lockRebuildRollups.RUnlock()
+	return calls
+}
+
+// RetryUpload calls RetryUploadFunc.
+func (mock *ServiceMock) RetryUpload(ctx context.Context, filename string) error {
+	if mock.RetryUploadFunc == nil {
+		panic("ServiceMock.RetryUploadFunc: method is nil but Service.RetryUpload was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Filename string
+	}{
+		Ctx:      ctx,
+		Filename: filename,
+	}
+	mock.lockRetryUpload.Lock()
+	mock.calls.RetryUpload = append(mock.calls.RetryUpload, callInfo)
+	mock.lockRetryUpload.Unlock()
+	return mock.RetryUploadFunc(ctx, filename)
+}
+
+// RetryUploadCalls gets all the calls that were made to RetryUpload.
+// Check the length with:
+//
+//	len(mockedService.RetryUploadCalls())
+func (mock *ServiceMock) RetryUploadCalls() []struct {
+	Ctx      context.Context
+	Filename string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Filename string
+	}
+	mock.lockRetryUpload.RLock()
+	calls = mock.calls.RetryUpload
+	mock.lockRetryUpload.RUnlock()
+	return calls
+}
+
+// RollbackUpload calls RollbackUploadFunc.
+func (mock *ServiceMock) RollbackUpload(ctx context.Context, filename string, dryRun bool) (*data.RollbackUploadResponse, error) {
+	if mock.RollbackUploadFunc == nil {
+		panic("ServiceMock.RollbackUploadFunc: method is nil but Service.RollbackUpload was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Filename string
+		DryRun   bool
+	}{
+		Ctx:      ctx,
+		Filename: filename,
+		DryRun:   dryRun,
+	}
+	mock.lockRollbackUpload.Lock()
+	mock.calls.RollbackUpload = append(mock.calls.RollbackUpload, callInfo)
+	mock.lockRollbackUpload.Unlock()
+	return mock.RollbackUploadFunc(ctx, filename, dryRun)
+}
+
+// RollbackUploadCalls gets all the calls that were made to RollbackUpload.
+// Check the length with:
+//
+//	len(mockedService.RollbackUploadCalls())
+func (mock *ServiceMock) RollbackUploadCalls() []struct {
+	Ctx      context.Context
+	Filename string
+	DryRun   bool
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Filename string
+		DryRun   bool
+	}
+	mock.lockRollbackUpload.RLock()
+	calls = mock.calls.RollbackUpload
+	mock.lockRollbackUpload.RUnlock()
+	return calls
+}
+
+// RunDataQualityCheck calls RunDataQualityCheckFunc.
+func (mock *ServiceMock) RunDataQualityCheck(ctx context.Context) error {
+	if mock.RunDataQualityCheckFunc == nil {
+		panic("ServiceMock.RunDataQualityCheckFunc: method is nil but Service.RunDataQualityCheck was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRunDataQualityCheck.Lock()
+	mock.calls.RunDataQualityCheck = append(mock.calls.RunDataQualityCheck, callInfo)
+	mock.lockRunDataQualityCheck.Unlock()
+	return mock.RunDataQualityCheckFunc(ctx)
+}
+
+// RunDataQualityCheckCalls gets all the calls that were made to RunDataQualityCheck.
+// Check the length with:
+//
+//	len(mockedService.RunDataQualityCheckCalls())
+func (mock *ServiceMock) RunDataQualityCheckCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRunDataQualityCheck.RLock()
+	calls = mock.calls.RunDataQualityCheck
+	mock.lockRunDataQualityCheck.RUnlock()
+	return calls
+}
+
 // UpdateProcessingStatus calls UpdateProcessingStatusFunc.
 func (mock *ServiceMock) UpdateProcessingStatus(ctx context.Context, filename string, status data.ProcessingStatus, err error) error {
 	if mock.UpdateProcessingStatusFunc == nil {
@@ -430,3 +1543,71 @@ func (mock *ServiceMock) UpdateProcessingStatusCalls() []struct {
 	mock.lockUpdateProcessingStatus.RUnlock()
 	return calls
 }
+
+// WaitForWebhookDeliveries calls WaitForWebhookDeliveriesFunc.
+func (mock *ServiceMock) WaitForWebhookDeliveries(ctx context.Context) error {
+	if mock.WaitForWebhookDeliveriesFunc == nil {
+		panic("ServiceMock.WaitForWebhookDeliveriesFunc: method is nil but Service.WaitForWebhookDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockWaitForWebhookDeliveries.Lock()
+	mock.calls.WaitForWebhookDeliveries = append(mock.calls.WaitForWebhookDeliveries, callInfo)
+	mock.lockWaitForWebhookDeliveries.Unlock()
+	return mock.WaitForWebhookDeliveriesFunc(ctx)
+}
+
+// WaitForWebhookDeliveriesCalls gets all the calls that were made to WaitForWebhookDeliveries.
+// Check the length with:
+//
+//	len(mockedService.WaitForWebhookDeliveriesCalls())
+func (mock *ServiceMock) WaitForWebhookDeliveriesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockWaitForWebhookDeliveries.RLock()
+	calls = mock.calls.WaitForWebhookDeliveries
+	mock.lockWaitForWebhookDeliveries.RUnlock()
+	return calls
+}
+
+// WatchUploads calls WatchUploadsFunc.
+func (mock *ServiceMock) WatchUploads(filename string, uploader string) (<-chan data.UploadEvent, func()) {
+	if mock.WatchUploadsFunc == nil {
+		panic("ServiceMock.WatchUploadsFunc: method is nil but Service.WatchUploads was just called")
+	}
+	callInfo := struct {
+		Filename string
+		Uploader string
+	}{
+		Filename: filename,
+		Uploader: uploader,
+	}
+	mock.lockWatchUploads.Lock()
+	mock.calls.WatchUploads = append(mock.calls.WatchUploads, callInfo)
+	mock.lockWatchUploads.Unlock()
+	return mock.WatchUploadsFunc(filename, uploader)
+}
+
+// WatchUploadsCalls gets all the calls that were made to WatchUploads.
+// Check the length with:
+//
+//	len(mockedService.WatchUploadsCalls())
+func (mock *ServiceMock) WatchUploadsCalls() []struct {
+	Filename string
+	Uploader string
+} {
+	var calls []struct {
+		Filename string
+		Uploader string
+	}
+	mock.lockWatchUploads.RLock()
+	calls = mock.calls.WatchUploads
+	mock.lockWatchUploads.RUnlock()
+	return calls
+}
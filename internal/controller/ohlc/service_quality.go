@@ -0,0 +1,167 @@
+package ohlc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	E "github.com/teezzan/candles/internal/errors"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+// oneDay is the period covered by a single data quality report.
+const oneDay = 24 * time.Hour
+
+// maxDataQualityDays is the maximum number of days covered by a single data quality request.
+const maxDataQualityDays = 366
+
+// GenerateDataQualityReports scans the stored data points of a symbol and reports, for every day between
+// `from` and `to`, the missing buckets, duplicate buckets and coverage percentage for the expected interval.
+// The end time is optional and defaults to the current time. At most `maxDataQualityDays` days are reported at once.
+// The reports are persisted before being returned.
+func (s *DefaultService) GenerateDataQualityReports(ctx context.Context, payload data.DataQualityRequest) ([]data.DataQualityEntity, error) {
+	if payload.Symbol == "" {
+		return nil, E.NewErrInvalidArgument("symbol is required")
+	}
+	if payload.StartTime <= 0 {
+		return nil, E.NewErrInvalidArgument("from is required")
+	}
+	if payload.EndTime.Valid && payload.EndTime.Int64 < payload.StartTime {
+		return nil, E.NewErrInvalidArgument("to must be greater than from")
+	}
+	interval, err := data.ParseInterval(payload.Interval)
+	if err != nil {
+		return nil, err
+	}
+	if oneDay%interval != 0 {
+		return nil, E.NewErrInvalidArgument("interval must evenly divide a day")
+	}
+
+	now := time.Now().UTC()
+	endTime := now
+	if payload.EndTime.Valid && payload.EndTime.Int64 < now.Unix() {
+		endTime = time.Unix(payload.EndTime.Int64, 0).UTC()
+	}
+	startDay := time.Unix(payload.StartTime, 0).UTC().Truncate(oneDay)
+	if endTime.Sub(startDay) >= maxDataQualityDays*oneDay {
+		return nil, E.NewErrInvalidArgument(fmt.Sprintf("from and to must be at most %d days apart", maxDataQualityDays))
+	}
+
+	reports := []data.DataQualityEntity{}
+	for d := startDay; !d.After(endTime); d = d.Add(oneDay) {
+		dayEnd := d.Add(oneDay)
+		if dayEnd.After(now) {
+			dayEnd = now
+		}
+		points, err := s.repository.GetDataPointsInRange(ctx, payload.Symbol, d, d.Add(oneDay-time.Second))
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, buildDataQualityReport(payload.Symbol, d, dayEnd, interval, points))
+	}
+
+	err = s.repository.UpsertDataQualityReports(ctx, reports)
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// buildDataQualityReport computes the completeness of the data points of the day starting at `start`.
+// Only the buckets starting before `end` are expected, so that the current day is not reported as incomplete.
+// Data points falling in an already filled bucket are counted as duplicates.
+func buildDataQualityReport(symbol string, start time.Time, end time.Time, interval time.Duration, points []data.OHLCEntity) data.DataQualityEntity {
+	step := int64(interval / time.Second)
+	buckets := make(map[int64]int64, len(points))
+	for _, p := range points {
+		t := p.Time.Unix()
+		buckets[t-t%step]++
+	}
+
+	report := data.DataQualityEntity{
+		Symbol:          symbol,
+		Day:             start,
+		IntervalSeconds: step,
+		Gaps:            data.Gaps{},
+	}
+	var gap *data.Gap
+	for b := start.Unix(); b < end.Unix(); b += step {
+		report.ExpectedCount++
+		n, ok := buckets[b]
+		if ok {
+			report.PresentCount++
+			report.DuplicateCount += n - 1
+			gap = nil
+			continue
+		}
+
+		report.MissingCount++
+		if gap == nil {
+			report.Gaps = append(report.Gaps, data.Gap{From: b})
+			gap = &report.Gaps[len(report.Gaps)-1]
+		}
+		gap.To = b
+		gap.Count++
+	}
+
+	report.Coverage = 100
+	if report.ExpectedCount > 0 {
+		report.Coverage = math.Round(float64(report.PresentCount)/float64(report.ExpectedCount)*10000) / 100
+	}
+	return report
+}
+
+// GetDataQualityReports returns the persisted data quality reports of a symbol between `from` and `to`.
+// The end time is optional and defaults to the current time.
+func (s *DefaultService) GetDataQualityReports(ctx context.Context, payload data.DataQualityRequest) ([]data.DataQualityEntity, error) {
+	if payload.Symbol == "" {
+		return nil, E.NewErrInvalidArgument("symbol is required")
+	}
+	if payload.StartTime <= 0 {
+		return nil, E.NewErrInvalidArgument("from is required")
+	}
+	if payload.EndTime.Valid && payload.EndTime.Int64 < payload.StartTime {
+		return nil, E.NewErrInvalidArgument("to must be greater than from")
+	}
+
+	endTime := time.Now()
+	if payload.EndTime.Valid {
+		endTime = time.Unix(payload.EndTime.Int64, 0)
+	}
+	return s.repository.GetDataQualityReports(ctx, payload.Symbol, time.Unix(payload.StartTime, 0).UTC(), endTime.UTC())
+}
+
+// RunDataQualityCheck generates the data quality reports of the previous day for the configured symbols,
+// or for every stored symbol if none is configured. A failing symbol does not prevent the others from being checked,
+// the first error encountered is returned.
+func (s *DefaultService) RunDataQualityCheck(ctx context.Context) error {
+	symbols := s.dataQualitySymbols
+	if len(symbols) == 0 {
+		var err error
+		symbols, err = s.repository.GetSymbols(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	yesterday := time.Now().UTC().Truncate(oneDay).Add(-oneDay)
+	var firstErr error
+	for _, symbol := range symbols {
+		_, err := s.GenerateDataQualityReports(ctx, data.DataQualityRequest{
+			Symbol:    symbol,
+			Interval:  s.dataQualityInterval,
+			StartTime: yesterday.Unix(),
+			EndTime:   null.NewInt64(yesterday.Add(oneDay - time.Second).Unix()),
+		})
+		if err != nil {
+			s.logger.Error("data quality check failed", zap.String("symbol", symbol), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,123 @@
+package ohlc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	E "github.com/teezzan/candles/internal/errors"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+func Test_buildDataQualityReport(t *testing.T) {
+	start := time.Date(2021, 1, 7, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) data.OHLCEntity {
+		return data.OHLCEntity{Symbol: "BTC", Time: start.Add(d)}
+	}
+
+	tests := []struct {
+		name   string
+		end    time.Time
+		points []data.OHLCEntity
+		want   data.DataQualityEntity
+	}{
+		{
+			name:   "complete series",
+			end:    start.Add(3 * time.Hour),
+			points: []data.OHLCEntity{at(0), at(time.Hour), at(2 * time.Hour)},
+			want: data.DataQualityEntity{
+				Symbol:          "BTC",
+				Day:             start,
+				IntervalSeconds: 3600,
+				ExpectedCount:   3,
+				PresentCount:    3,
+				Coverage:        100,
+				Gaps:            data.Gaps{},
+			},
+		},
+		{
+			name:   "series with gaps and duplicates",
+			end:    start.Add(6 * time.Hour),
+			points: []data.OHLCEntity{at(0), at(30 * time.Minute), at(3 * time.Hour), at(5 * time.Hour)},
+			want: data.DataQualityEntity{
+				Symbol:          "BTC",
+				Day:             start,
+				IntervalSeconds: 3600,
+				ExpectedCount:   6,
+				PresentCount:    3,
+				MissingCount:    3,
+				DuplicateCount:  1,
+				Coverage:        50,
+				Gaps: data.Gaps{
+					{From: start.Add(time.Hour).Unix(), To: start.Add(2 * time.Hour).Unix(), Count: 2},
+					{From: start.Add(4 * time.Hour).Unix(), To: start.Add(4 * time.Hour).Unix(), Count: 1},
+				},
+			},
+		},
+		{
+			name: "empty series",
+			end:  start.Add(2 * time.Hour),
+			want: data.DataQualityEntity{
+				Symbol:          "BTC",
+				Day:             start,
+				IntervalSeconds: 3600,
+				ExpectedCount:   2,
+				MissingCount:    2,
+				Coverage:        0,
+				Gaps: data.Gaps{
+					{From: start.Unix(), To: start.Add(time.Hour).Unix(), Count: 2},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildDataQualityReport("BTC", start, tt.end, time.Hour, tt.points)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefaultService_GenerateDataQualityReports_range(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		mockRepository = &repository.RepositoryMock{
+			GetDataPointsInRangeFunc: func(ctx context.Context, symbol string, start, end time.Time) ([]data.OHLCEntity, error) {
+				return nil, nil
+			},
+			UpsertDataQualityReportsFunc: func(ctx context.Context, reports []data.DataQualityEntity) error {
+				return nil
+			},
+		}
+		start = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+	conf := config.Init()
+	s := newService(t, zap.NewNop(), mockRepository, &s3.ClientMock{}, &sqs.ClientMock{}, &webhook.ClientMock{}, conf.OHLCConfig)
+
+	reports, err := s.GenerateDataQualityReports(ctx, data.DataQualityRequest{
+		Symbol:    "BTC",
+		Interval:  "1h",
+		StartTime: start.Unix(),
+		EndTime:   null.NewInt64(start.Add(maxDataQualityDays*oneDay - time.Second).Unix()),
+	})
+	require.NoError(t, err)
+	assert.Len(t, reports, maxDataQualityDays)
+
+	_, err = s.GenerateDataQualityReports(ctx, data.DataQualityRequest{
+		Symbol:    "BTC",
+		Interval:  "1h",
+		StartTime: start.Unix(),
+		EndTime:   null.NewInt64(start.Add(maxDataQualityDays * oneDay).Unix()),
+	})
+	assert.True(t, E.IsErrInvalidArgument(err))
+	assert.Len(t, mockRepository.GetDataPointsInRangeCalls(), maxDataQualityDays)
+}
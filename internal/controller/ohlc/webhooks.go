@@ -0,0 +1,132 @@
+package ohlc
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	E "github.com/teezzan/candles/internal/errors"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+// callbackURLOf validates and returns the callback URL to record, an empty callback URL being recorded as unknown.
+func callbackURLOf(callbackURL string) (null.String, error) {
+	callbackURL = strings.TrimSpace(callbackURL)
+	if callbackURL == "" {
+		return null.NewInvalidString(), nil
+	}
+	u, err := url.ParseRequestURI(callbackURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return null.NewInvalidString(), E.NewErrInvalidArgument("callback_url must be an absolute http or https URL")
+	}
+	return null.NewString(callbackURL), nil
+}
+
+// notifyCompletion delivers the final processing status of a file to the configured webhooks and to the callback URL
+// of the file in the background.
+func (s *DefaultService) notifyCompletion(ctx context.Context, filename string) {
+	urls := append([]string{}, s.webhookURLs...)
+	status, err := s.repository.GetProcessingStatus(uncancelled(ctx), filename)
+	if err != nil {
+		s.logger.Warn("failed to read processing status for webhooks", zap.String("filename", filename), zap.Error(err))
+		return
+	}
+	if status.CallbackURL.Valid {
+		urls = append(urls, status.CallbackURL.String)
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(&data.UploadWebhookPayload{
+		Event:  data.WebhookEventUploadFinished,
+		Upload: *status,
+	})
+	if err != nil {
+		s.logger.Error("failed to encode webhook payload", zap.String("filename", filename), zap.Error(err))
+		return
+	}
+	for _, u := range urls {
+		s.webhookDeliveries.Add(1)
+		go func(u string) {
+			defer s.webhookDeliveries.Done()
+			s.deliverWebhook(context.Background(), filename, u, payload)
+		}(u)
+	}
+}
+
+// deliverWebhook posts a payload to a URL until it is acknowledged, at most `webhookMaxAttempts` times.
+// The delay between two attempts starts at `webhookBackoff` and doubles after every failed attempt.
+// Every attempt is recorded in the delivery log of the file.
+func (s *DefaultService) deliverWebhook(ctx context.Context, filename string, url string, payload []byte) error {
+	backoff := s.webhookBackoff
+	for attempt := 1; ; attempt++ {
+		statusCode, err := s.webhookClient.Send(ctx, url, payload)
+
+		delivery := data.WebhookDeliveryEntity{
+			FileName:  filename,
+			URL:       url,
+			Attempt:   attempt,
+			Delivered: err == nil,
+		}
+		if statusCode != 0 {
+			delivery.StatusCode = null.NewInt(statusCode)
+		}
+		if err != nil {
+			delivery.Error = null.NewString(err.Error())
+		}
+		if logErr := s.repository.InsertWebhookDelivery(ctx, delivery); logErr != nil {
+			s.logger.Warn("failed to record webhook delivery", zap.String("filename", filename), zap.Error(logErr))
+		}
+
+		if err == nil {
+			return nil
+		}
+		if attempt >= s.webhookMaxAttempts {
+			s.logger.Error("webhook delivery failed", zap.String("filename", filename), zap.String("url", url), zap.Error(err))
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// WaitForWebhookDeliveries waits for the webhooks being delivered in the background, such as on shutdown.
+// It returns the error of ctx if ctx is done first, in which case the remaining deliveries are abandoned.
+func (s *DefaultService) WaitForWebhookDeliveries(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.webhookDeliveries.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// GetWebhookDeliveries returns the webhook delivery attempts of an uploaded file, oldest first.
+func (s *DefaultService) GetWebhookDeliveries(ctx context.Context, filename string) ([]data.WebhookDeliveryEntity, error) {
+	_, err := s.repository.GetProcessingStatus(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.repository.GetWebhookDeliveries(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []data.WebhookDeliveryEntity{}
+	}
+	return deliveries, nil
+}
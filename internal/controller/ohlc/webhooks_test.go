@@ -0,0 +1,179 @@
+package ohlc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teezzan/candles/internal/client/s3"
+	"github.com/teezzan/candles/internal/client/sqs"
+	"github.com/teezzan/candles/internal/client/webhook"
+	"github.com/teezzan/candles/internal/config"
+	"github.com/teezzan/candles/internal/controller/ohlc/data"
+	"github.com/teezzan/candles/internal/controller/ohlc/repository"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+func Test_callbackURLOf(t *testing.T) {
+	tests := []struct {
+		name        string
+		callbackURL string
+		want        null.String
+		wantErr     bool
+	}{
+		{
+			name: "no callback URL",
+			want: null.NewInvalidString(),
+		},
+		{
+			name:        "https callback URL",
+			callbackURL: " https://etl.example.com/hooks ",
+			want:        null.NewString("https://etl.example.com/hooks"),
+		},
+		{
+			name:        "relative callback URL",
+			callbackURL: "/hooks",
+			want:        null.NewInvalidString(),
+			wantErr:     true,
+		},
+		{
+			name:        "unsupported scheme",
+			callbackURL: "ftp://etl.example.com/hooks",
+			want:        null.NewInvalidString(),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callbackURLOf(tt.callbackURL)
+			require.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefaultService_deliverWebhook(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		wantAttempts  int
+		wantDelivered bool
+	}{
+		{
+			name:          "delivered at once",
+			wantAttempts:  1,
+			wantDelivered: true,
+		},
+		{
+			name:          "delivered after retries",
+			failures:      2,
+			wantAttempts:  3,
+			wantDelivered: true,
+		},
+		{
+			name:         "attempts exhausted",
+			failures:     5,
+			wantAttempts: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx               = context.Background()
+				logger            = zap.NewNop()
+				mockS3Client      = &s3.ClientMock{}
+				mockSQSClient     = &sqs.ClientMock{}
+				mockWebhookClient = &webhook.ClientMock{}
+				mockRepository    = &repository.RepositoryMock{
+					InsertWebhookDeliveryFunc: func(ctx context.Context, delivery data.WebhookDeliveryEntity) error {
+						return nil
+					},
+				}
+			)
+			mockWebhookClient.SendFunc = func(ctx context.Context, url string, payload []byte) (int, error) {
+				if len(mockWebhookClient.SendCalls()) <= tt.failures {
+					return http.StatusServiceUnavailable, errors.New("unexpected status code 503")
+				}
+				return http.StatusOK, nil
+			}
+			conf := config.Init()
+			conf.OHLCConfig.WebhookMaxAttempts = 3
+
+			s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, mockWebhookClient, conf.OHLCConfig)
+			s.webhookBackoff = time.Millisecond
+			err := s.deliverWebhook(ctx, "test.csv", "https://etl.example.com/hooks", []byte(`{}`))
+			require.Equal(t, !tt.wantDelivered, err != nil)
+
+			calls := mockRepository.InsertWebhookDeliveryCalls()
+			require.Len(t, calls, tt.wantAttempts)
+			for i, call := range calls {
+				assert.Equal(t, i+1, call.Delivery.Attempt)
+				assert.True(t, call.Delivery.StatusCode.Valid)
+			}
+			last := calls[len(calls)-1].Delivery
+			assert.Equal(t, tt.wantDelivered, last.Delivered)
+			assert.Equal(t, !tt.wantDelivered, last.Error.Valid)
+		})
+	}
+}
+
+func TestDefaultService_notifyCompletion(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received = map[string]data.UploadWebhookPayload{}
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload data.UploadWebhookPayload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.NotEmpty(t, r.Header.Get(webhook.SignatureHeader))
+		mu.Lock()
+		received[r.URL.Path] = payload
+		mu.Unlock()
+	}))
+	defer receiver.Close()
+
+	var (
+		ctx            = context.Background()
+		logger         = zap.NewNop()
+		mockS3Client   = &s3.ClientMock{}
+		mockSQSClient  = &sqs.ClientMock{}
+		mockRepository = &repository.RepositoryMock{
+			GetProcessingStatusFunc: func(ctx context.Context, fileName string) (*data.ProcessingStatusEntity, error) {
+				return &data.ProcessingStatusEntity{
+					FileName:     fileName,
+					Status:       data.ProcessingStatusCompleted,
+					CallbackURL:  null.NewString(receiver.URL + "/callback"),
+					RowsInserted: 42,
+				}, nil
+			},
+			InsertWebhookDeliveryFunc: func(ctx context.Context, delivery data.WebhookDeliveryEntity) error {
+				return nil
+			},
+		}
+	)
+	conf := config.Init()
+	conf.OHLCConfig.WebhookURLs = []string{receiver.URL + "/global"}
+	webhookClient := webhook.NewClient(logger, config.WebhookConfig{Secret: "secret", TimeoutInSeconds: 5, AllowPrivateNetworks: true})
+
+	s := newService(t, logger, mockRepository, mockS3Client, mockSQSClient, webhookClient, conf.OHLCConfig)
+	s.notifyCompletion(ctx, "test.csv")
+	require.NoError(t, s.WaitForWebhookDeliveries(ctx))
+
+	require.Len(t, received, 2)
+	for _, path := range []string{"/global", "/callback"} {
+		payload := received[path]
+		assert.Equal(t, data.WebhookEventUploadFinished, payload.Event)
+		assert.Equal(t, "test.csv", payload.Upload.FileName)
+		assert.Equal(t, data.ProcessingStatusCompleted, payload.Upload.Status)
+		assert.Equal(t, int64(42), payload.Upload.RowsInserted)
+	}
+	assert.Len(t, mockRepository.InsertWebhookDeliveryCalls(), 2)
+}
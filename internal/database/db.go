@@ -3,24 +3,72 @@ package database
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
+	"sync/atomic"
 
+	_ "github.com/glebarez/go-sqlite"  // load SQLite driver
 	_ "github.com/go-sql-driver/mysql" // load MySQL driver
 	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // load PostgreSQL driver
+	"github.com/teezzan/candles/internal/config"
 )
 
+const (
+	// DriverMySQL is the driver of MySQL databases.
+	DriverMySQL = "mysql"
+	// DriverPostgres is the driver of PostgreSQL databases, including TimescaleDB.
+	DriverPostgres = "postgres"
+	// DriverSQLite is the driver of SQLite databases. The database name is the path of the database file,
+	// or MemoryDatabase to keep the database in memory.
+	DriverSQLite = "sqlite"
+	// DriverMemory keeps the data in the memory of the server, without any database. It is meant for tests and demos.
+	DriverMemory = "memory"
+
+	// MemoryDatabase is the name of an in-memory SQLite database.
+	MemoryDatabase = ":memory:"
+)
+
+// memoryDatabases counts the in-memory SQLite databases opened, to name them uniquely.
+var memoryDatabases int64
+
+func init() {
+	sqlx.BindDriver(DriverSQLite, sqlx.QUESTION)
+}
+
 // DB is the default database structure holding the SQLX connection.
+// The connection is nil if the driver is DriverMemory.
 type DB struct {
-	SQL *sqlx.DB
+	SQL    *sqlx.DB
+	Driver string
 }
 
-// New initializes a new (SQLX) database connection.
-func New(
-	username string,
-	password string,
-	host string,
-	database string,
-) (*DB, error) {
+// New initializes a new (SQLX) database connection using the driver of the configuration.
+func New(conf config.DatabaseConfig) (*DB, error) {
+	var uri string
+	switch conf.Driver {
+	case DriverMySQL:
+		uri = mysqlURI(conf)
+	case DriverPostgres:
+		uri = postgresURI(conf)
+	case DriverSQLite:
+		uri = sqliteURI(conf)
+	case DriverMemory:
+		return &DB{Driver: conf.Driver}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", conf.Driver)
+	}
+
+	db, err := open(conf.Driver, uri)
+	if err != nil {
+		return nil, fmt.Errorf("db initialization failed for main: %w", err)
+	}
+
+	return &DB{SQL: db, Driver: conf.Driver}, nil
+}
+
+// mysqlURI returns the DSN of a MySQL database.
+func mysqlURI(conf config.DatabaseConfig) string {
 	params := []string{
 		"parseTime=true",
 		"timeout=30s",      // Note: this is the connection timeout, not the query timeout
@@ -28,17 +76,43 @@ func New(
 		"readTimeout=30s",  // Note: this the timeout for awaiting a block to be read, not the entire result set.
 	}
 
-	uri := fmt.Sprintf("%s:%s@tcp(%s)/%s?%s", username, password, host, database, strings.Join(params, "&"))
-	db, err := open(uri)
-	if err != nil {
-		return nil, fmt.Errorf("db initialization failed for main: %w", err)
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?%s", conf.Username, conf.Password, conf.Host, conf.Database, strings.Join(params, "&"))
+}
+
+// postgresURI returns the connection URL of a PostgreSQL database.
+func postgresURI(conf config.DatabaseConfig) string {
+	params := url.Values{}
+	params.Set("sslmode", conf.SSLMode)
+	params.Set("connect_timeout", "30") // Note: this is the connection timeout, not the query timeout
+
+	uri := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(conf.Username, conf.Password),
+		Host:     fmt.Sprintf("%s:%d", conf.Host, conf.Port),
+		Path:     "/" + conf.Database,
+		RawQuery: params.Encode(),
+	}
+	return uri.String()
+}
+
+// sqliteURI returns the DSN of a SQLite database.
+func sqliteURI(conf config.DatabaseConfig) string {
+	params := []string{
+		"_txlock=immediate", // Note: a transaction locks the database upfront, so that it never fails to upgrade its lock later on.
+		"_pragma=foreign_keys(1)",
 	}
 
-	return &DB{db}, nil
+	if conf.Database == MemoryDatabase {
+		// Note: the memdb VFS lets every connection of the pool share the same in-memory database.
+		name := fmt.Sprintf("/candles-%d.db", atomic.AddInt64(&memoryDatabases, 1))
+		return fmt.Sprintf("file:%s?vfs=memdb&%s", name, strings.Join(params, "&"))
+	}
+	params = append(params, "_pragma=journal_mode(wal)") // Note: the database can be read while it is written.
+	return fmt.Sprintf("%s?%s", conf.Database, strings.Join(params, "&"))
 }
 
-func open(uri string) (*sqlx.DB, error) {
-	db, err := sqlx.Connect("mysql", uri)
+func open(driver string, uri string) (*sqlx.DB, error) {
+	db, err := sqlx.Connect(driver, uri)
 	if err != nil {
 		return nil, err
 	}
@@ -48,5 +122,8 @@ func open(uri string) (*sqlx.DB, error) {
 
 // Close implements the io.Closer interface.
 func (db *DB) Close() error {
+	if db.SQL == nil {
+		return nil
+	}
 	return db.SQL.Close()
 }
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// migration is an up migration, named `<version>_<title>.up.sql`.
+type migration struct {
+	version uint64
+	file    string
+}
+
+// Migrate applies the up migrations of `migrations` that are newer than the version of the database, in order.
+// The version of the database is recorded in the schema_migrations table the same way as the migrate CLI does,
+// so that both can be used on the same database.
+func (db *DB) Migrate(ctx context.Context, migrations fs.FS) error {
+	stmt := `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint NOT NULL PRIMARY KEY,
+		dirty boolean NOT NULL
+	)
+	`
+	_, err := db.SQL.ExecContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+
+	var current struct {
+		Version uint64 `db:"version"`
+		Dirty   bool   `db:"dirty"`
+	}
+	err = db.SQL.GetContext(ctx, &current, `SELECT version, dirty FROM schema_migrations LIMIT 1`)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if current.Dirty {
+		return fmt.Errorf("database is dirty at version %d", current.Version)
+	}
+
+	pending, err := pendingMigrations(migrations, current.Version)
+	if err != nil {
+		return err
+	}
+	for _, m := range pending {
+		err = db.migrate(ctx, migrations, m)
+		if err != nil {
+			return fmt.Errorf("migration %s failed: %w", m.file, err)
+		}
+	}
+	return nil
+}
+
+// migrate applies a migration and records its version within a single transaction.
+func (db *DB) migrate(ctx context.Context, migrations fs.FS, m migration) error {
+	query, err := fs.ReadFile(migrations, m.file)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.SQL.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, string(query))
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(`INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)`), m.version, false)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// pendingMigrations returns the up migrations of `migrations` newer than `version`, ordered by version.
+func pendingMigrations(migrations fs.FS, version uint64) ([]migration, error) {
+	files, err := fs.Glob(migrations, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []migration{}
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(path.Base(file), "_")
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version of %s: %w", file, err)
+		}
+		if v > version {
+			pending = append(pending, migration{version: v, file: file})
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].version < pending[j].version
+	})
+	return pending, nil
+}
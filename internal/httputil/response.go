@@ -58,6 +58,11 @@ func OK(c *gin.Context, data interface{}) error {
 	return respond(c, http.StatusOK, data)
 }
 
+// Accepted responds with a 202 Accepted status code and JSON payload if provided.
+func Accepted(c *gin.Context, data interface{}) error {
+	return respond(c, http.StatusAccepted, data)
+}
+
 // BadRequest responds with a 400 Bad Request status code and JSON payload if provided.
 func BadRequest(c *gin.Context, err error) error {
 	errData := NewErrorResponseFromError(err, http.StatusBadRequest)
@@ -76,6 +81,12 @@ func Forbidden(c *gin.Context, err error) error {
 	return respond(c, http.StatusForbidden, errData)
 }
 
+// RequestEntityTooLarge responds with a 413 Request Entity Too Large status code and JSON payload if provided.
+func RequestEntityTooLarge(c *gin.Context, err error) error {
+	errData := NewErrorResponseFromError(err, http.StatusRequestEntityTooLarge)
+	return respond(c, http.StatusRequestEntityTooLarge, errData)
+}
+
 // NotFound responds with a 404 Not Found status code and JSON payload if provided.
 func NotFound(c *gin.Context, err error) error {
 	errData := NewErrorResponseFromError(err, http.StatusNotFound)
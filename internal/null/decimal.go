@@ -0,0 +1,93 @@
+package null
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	_ json.Marshaler   = Decimal{}
+	_ json.Unmarshaler = (*Decimal)(nil)
+	_ sql.Scanner      = (*Decimal)(nil)
+	_ driver.Valuer    = Decimal{}
+)
+
+// Decimal defines a NULL-able decimal type. It is marshalled to a JSON number carrying every digit of the value.
+type Decimal struct {
+	decimal.NullDecimal
+}
+
+// NewDecimal instantiates a new valid Decimal.
+func NewDecimal(d decimal.Decimal) Decimal {
+	return Decimal{
+		decimal.NullDecimal{
+			Valid:   true,
+			Decimal: d,
+		},
+	}
+}
+
+// NewDecimalFromRef sets the value from a pointer if not nil, otherwise
+// invalidates.
+func NewDecimalFromRef(d *decimal.Decimal) Decimal {
+	if d == nil {
+		return NewInvalidDecimal()
+	}
+	return NewDecimal(*d)
+}
+
+// NewInvalidDecimal instantiates a new invalid Decimal.
+func NewInvalidDecimal() Decimal {
+	return Decimal{}
+}
+
+// MarshalJSON implements the Marshaler interface.
+func (x Decimal) MarshalJSON() ([]byte, error) {
+	if !x.Valid {
+		return []byte("null"), nil
+	}
+	return []byte(x.Decimal.String()), nil
+}
+
+// UnmarshalJSON implements the Unmarshaler interface. Both JSON numbers and strings are accepted.
+func (x *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*x = NewInvalidDecimal()
+		return nil
+	}
+	var d decimal.Decimal
+	if err := d.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*x = NewDecimal(d)
+	return nil
+}
+
+// Value implements driver.Valuer, will be invoked automatically when written to the db
+func (x Decimal) Value() (driver.Value, error) {
+	return x.NullDecimal.Value()
+}
+
+// Scan implements sql.Scanner, will be invoked automatically when read from the db
+func (x *Decimal) Scan(value interface{}) error {
+	return x.NullDecimal.Scan(value)
+}
+
+// ValueOr returns the value if valid, otherwise a fallback.
+func (x *Decimal) ValueOr(fallback decimal.Decimal) decimal.Decimal {
+	if !x.Valid {
+		return fallback
+	}
+	return x.Decimal
+}
+
+// AsRef returns the value as pointer if valid, otherwise nil.
+func (x *Decimal) AsRef() *decimal.Decimal {
+	if !x.Valid {
+		return nil
+	}
+	return &x.Decimal
+}
@@ -0,0 +1,71 @@
+// Package pubsub provides an in-process publish/subscribe broker.
+package pubsub
+
+import "sync"
+
+// subscription holds the channel and the filter of a subscriber.
+type subscription[T any] struct {
+	ch     chan T
+	filter func(T) bool
+}
+
+// Broker fans out the published messages to the matching subscribers.
+// A subscriber that does not keep up misses messages rather than blocking the publishers.
+type Broker[T any] struct {
+	mu            sync.RWMutex
+	subscriptions map[*subscription[T]]struct{}
+	bufferSize    int
+}
+
+// NewBroker initializes a new broker, every subscriber buffering up to `bufferSize` messages.
+func NewBroker[T any](bufferSize int) *Broker[T] {
+	return &Broker[T]{
+		subscriptions: map[*subscription[T]]struct{}{},
+		bufferSize:    bufferSize,
+	}
+}
+
+// Subscribe registers a subscriber receiving the published messages accepted by the filter.
+// A nil filter accepts every message. The returned function unsubscribes and closes the channel.
+func (b *Broker[T]) Subscribe(filter func(T) bool) (<-chan T, func()) {
+	sub := &subscription[T]{
+		ch:     make(chan T, b.bufferSize),
+		filter: filter,
+	}
+
+	b.mu.Lock()
+	b.subscriptions[sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscriptions, sub)
+			b.mu.Unlock()
+			close(sub.ch)
+		})
+	}
+}
+
+// Publish sends a message to the matching subscribers without blocking.
+func (b *Broker[T]) Publish(msg T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subscriptions {
+		if sub.filter != nil && !sub.filter(msg) {
+			continue
+		}
+		select {
+		case sub.ch <- msg:
+		default:
+		}
+	}
+}
+
+// HasSubscribers reports whether anyone is subscribed.
+func (b *Broker[T]) HasSubscribers() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscriptions) > 0
+}
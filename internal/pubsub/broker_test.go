@@ -0,0 +1,34 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker(t *testing.T) {
+	b := NewBroker[int](2)
+	assert.False(t, b.HasSubscribers())
+
+	all, unsubscribeAll := b.Subscribe(nil)
+	even, unsubscribeEven := b.Subscribe(func(i int) bool { return i%2 == 0 })
+	assert.True(t, b.HasSubscribers())
+
+	b.Publish(1)
+	b.Publish(2)
+	b.Publish(3)
+
+	assert.Equal(t, 1, <-all)
+	assert.Equal(t, 2, <-all)
+	assert.Equal(t, 2, <-even)
+	assert.Len(t, all, 0, "messages beyond the buffer are dropped")
+
+	unsubscribeEven()
+	unsubscribeEven()
+	_, ok := <-even
+	assert.False(t, ok)
+
+	unsubscribeAll()
+	assert.False(t, b.HasSubscribers())
+	b.Publish(4)
+}
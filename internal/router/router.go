@@ -3,24 +3,32 @@ package router
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/teezzan/candles/docs"
+	"github.com/teezzan/candles/internal/cache"
 	ohlc "github.com/teezzan/candles/internal/controller/ohlc"
+	"github.com/teezzan/candles/internal/scheduler"
 )
 
 type Router struct {
 	router *gin.Engine
 
-	healthHandler   gin.HandlerFunc
-	ohlcHttpHandler *ohlc.HTTPHandler
+	healthHandler        gin.HandlerFunc
+	ohlcHttpHandler      *ohlc.HTTPHandler
+	schedulerHttpHandler *scheduler.HTTPHandler
+	cacheHttpHandler     *cache.HTTPHandler
 }
 
 // New initializes a new router
 func New(
 	healthHandler gin.HandlerFunc,
 	ohlcHttpHandler *ohlc.HTTPHandler,
+	schedulerHttpHandler *scheduler.HTTPHandler,
+	cacheHttpHandler *cache.HTTPHandler,
 ) *Router {
 	return &Router{
-		healthHandler:   healthHandler,
-		ohlcHttpHandler: ohlcHttpHandler,
+		healthHandler:        healthHandler,
+		ohlcHttpHandler:      ohlcHttpHandler,
+		schedulerHttpHandler: schedulerHttpHandler,
+		cacheHttpHandler:     cacheHttpHandler,
 	}
 }
 
@@ -4,4 +4,6 @@ func (r *Router) setupRoutes() {
 	r.router.GET("/health", r.healthHandler)
 
 	r.ohlcHttpHandler.SetupRouter(r.router.Group("/"))
+	r.schedulerHttpHandler.SetupRouter(r.router.Group("/"))
+	r.cacheHttpHandler.SetupRouter(r.router.Group("/"))
 }
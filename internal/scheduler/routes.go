@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/teezzan/candles/internal/httputil"
+	"go.uber.org/zap"
+)
+
+// HTTPHandler is the HTTP handler for the scheduler.
+type HTTPHandler struct {
+	logger    *zap.Logger
+	scheduler *Scheduler
+}
+
+// NewHTTPHandler initializes a new HTTP Handler.
+func NewHTTPHandler(
+	logger *zap.Logger,
+	scheduler *Scheduler,
+) *HTTPHandler {
+	return &HTTPHandler{
+		logger:    logger,
+		scheduler: scheduler,
+	}
+}
+
+// SetupRouter sets up the router for the scheduler.
+func (h *HTTPHandler) SetupRouter(r *gin.RouterGroup) error {
+	handler := httputil.NewHandlerWrapper(h.logger)
+
+	r.GET("/admin/jobs", handler(h.getJobsHandler))
+	r.POST("/admin/jobs/:name/run", handler(h.runJobHandler))
+
+	return nil
+}
+
+// getJobsHandler lists the background jobs.
+//
+//	@Summary		Lists the background jobs
+//	@Description	The endpoint lists the background jobs along with their schedule, whether they are running, the outcome of their last run and their next scheduled run.
+//	@Produce		json
+//	@Success		200	{object}	scheduler.GetJobsResponse
+//	@Failure		500	{object}	httputil.ErrorResponse
+//	@Router			/admin/jobs [get]
+func (h *HTTPHandler) getJobsHandler(c *gin.Context) error {
+	return httputil.OK(c, GetJobsResponse{Jobs: h.scheduler.Jobs()})
+}
+
+// runJobHandler triggers a run of a background job.
+//
+//	@Summary		Triggers a run of a background job
+//	@Description	The endpoint runs a background job outside of its schedule. The job runs in the background, and cannot be triggered while it is running.
+//	@Produce		json
+//	@Param			name	path		string	true	"This is the job name"	example("retention")
+//	@Success		202		{object}	scheduler.JobState
+//	@Failure		400		{object}	httputil.ErrorResponse
+//	@Failure		404		{object}	httputil.ErrorResponse
+//	@Failure		500		{object}	httputil.ErrorResponse
+//	@Router			/admin/jobs/{name}/run [post]
+func (h *HTTPHandler) runJobHandler(c *gin.Context) error {
+	name := c.Param("name")
+	err := h.scheduler.Trigger(name)
+	if err != nil {
+		return err
+	}
+	state, err := h.scheduler.Job(name)
+	if err != nil {
+		return err
+	}
+	return httputil.Accepted(c, state)
+}
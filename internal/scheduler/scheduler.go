@@ -0,0 +1,265 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	E "github.com/teezzan/candles/internal/errors"
+	"github.com/teezzan/candles/internal/null"
+	"go.uber.org/zap"
+)
+
+// JobFunc is a background job. Its context is cancelled once the timeout of the job elapses.
+type JobFunc func(ctx context.Context) error
+
+// JobState describes a registered job and the outcome of its last run.
+type JobState struct {
+	Name                string      `json:"name"`
+	Schedule            string      `json:"schedule"`
+	TimeoutInSeconds    int64       `json:"timeout_in_seconds"`
+	Running             bool        `json:"running"`
+	SkippedRuns         int64       `json:"skipped_runs"`
+	LastRunAt           null.Time   `json:"last_run_at" swaggertype:"string"`
+	LastRunDurationInMS null.Int64  `json:"last_run_duration_in_ms" swaggertype:"integer"`
+	LastError           null.String `json:"last_error" swaggertype:"string"`
+	NextRunAt           null.Time   `json:"next_run_at" swaggertype:"string"`
+}
+
+// GetJobsResponse defines the get jobs response.
+type GetJobsResponse struct {
+	Jobs []JobState `json:"jobs"`
+}
+
+// job is a registered job along with the outcome of its last run.
+type job struct {
+	name     string
+	schedule string
+	timeout  time.Duration
+	fn       JobFunc
+	entryID  cron.EntryID
+
+	mu              sync.Mutex
+	running         bool
+	skippedRuns     int64
+	lastRunAt       time.Time
+	lastRunDuration time.Duration
+	lastErr         error
+}
+
+// begin marks the job as running. It returns false if the job is already running.
+func (j *job) begin() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.running {
+		return false
+	}
+	j.running = true
+	return true
+}
+
+// skip records a scheduled run skipped because the job was still running.
+func (j *job) skip() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.skippedRuns++
+}
+
+// finish records the outcome of a run started at `start` and marks the job as idle.
+func (j *job) finish(start time.Time, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.running = false
+	j.lastRunAt = start
+	j.lastRunDuration = time.Since(start)
+	j.lastErr = err
+}
+
+// state returns the state of the job, given its next scheduled run.
+func (j *job) state(next time.Time) JobState {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	state := JobState{
+		Name:             j.name,
+		Schedule:         j.schedule,
+		TimeoutInSeconds: int64(j.timeout / time.Second),
+		Running:          j.running,
+		SkippedRuns:      j.skippedRuns,
+	}
+	if !j.lastRunAt.IsZero() {
+		state.LastRunAt = null.NewTime(j.lastRunAt)
+		state.LastRunDurationInMS = null.NewInt64(j.lastRunDuration.Milliseconds())
+	}
+	if j.lastErr != nil {
+		state.LastError = null.NewString(j.lastErr.Error())
+	}
+	if !next.IsZero() {
+		state.NextRunAt = null.NewTime(next)
+	}
+	return state
+}
+
+// Scheduler runs background jobs registered by name on cron schedules.
+// A job never overlaps itself: a scheduled run is skipped while the previous run is still going.
+type Scheduler struct {
+	logger *zap.Logger
+	cron   *cron.Cron
+
+	mu    sync.RWMutex
+	jobs  map[string]*job
+	names []string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	runs   sync.WaitGroup
+}
+
+// New initializes a new Scheduler.
+func New(logger *zap.Logger) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		logger: logger,
+		cron:   cron.New(),
+		jobs:   map[string]*job{},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Register registers a job under a unique name to run on a cron schedule, such as "*/5 * * * *" or "@every 2m".
+// A run of the job is cancelled once `timeout` elapses, unless it is zero.
+func (s *Scheduler) Register(name string, schedule string, timeout time.Duration, fn JobFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("job %s is already registered", name)
+	}
+
+	j := &job{
+		name:     name,
+		schedule: schedule,
+		timeout:  timeout,
+		fn:       fn,
+	}
+	entryID, err := s.cron.AddFunc(schedule, func() {
+		if !j.begin() {
+			j.skip()
+			s.logger.Warn("skipping job still running", zap.String("job", name))
+			return
+		}
+		s.runs.Add(1)
+		s.execute(j)
+	})
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q of job %s: %w", schedule, name, err)
+	}
+	j.entryID = entryID
+	s.jobs[name] = j
+	s.names = append(s.names, name)
+	return nil
+}
+
+// Start starts running the jobs on their schedules.
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop stops scheduling the jobs and waits for the running ones to return.
+// The running jobs are cancelled if `ctx` is done before they return.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	cronDone := s.cron.Stop().Done()
+
+	done := make(chan struct{})
+	go func() {
+		// The runs dispatched by the cron are only all counted once it has stopped.
+		<-cronDone
+		s.runs.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// Trigger runs a job in the background outside of its schedule.
+// It returns an ErrInvalidArgument if the job is still running.
+func (s *Scheduler) Trigger(name string) error {
+	j, err := s.job(name)
+	if err != nil {
+		return err
+	}
+	if !j.begin() {
+		return E.NewErrInvalidArgument(fmt.Sprintf("job %s is already running", name))
+	}
+	s.runs.Add(1)
+	go s.execute(j)
+	return nil
+}
+
+// Jobs returns the state of the registered jobs in registration order.
+func (s *Scheduler) Jobs() []JobState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	states := make([]JobState, 0, len(s.names))
+	for _, name := range s.names {
+		j := s.jobs[name]
+		states = append(states, j.state(s.cron.Entry(j.entryID).Next))
+	}
+	return states
+}
+
+// Job returns the state of a registered job.
+func (s *Scheduler) Job(name string) (*JobState, error) {
+	j, err := s.job(name)
+	if err != nil {
+		return nil, err
+	}
+	state := j.state(s.cron.Entry(j.entryID).Next)
+	return &state, nil
+}
+
+// job returns a registered job.
+func (s *Scheduler) job(name string) (*job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	j, ok := s.jobs[name]
+	if !ok {
+		return nil, E.NewErrNotFound(fmt.Sprintf("job %s not found", name))
+	}
+	return j, nil
+}
+
+// execute runs a job marked as running and records its outcome. A panic of the job is recorded as its error.
+func (s *Scheduler) execute(j *job) {
+	defer s.runs.Done()
+
+	ctx, cancel := s.ctx, context.CancelFunc(func() {})
+	if j.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, j.timeout)
+	}
+	defer cancel()
+
+	start := time.Now()
+	s.logger.Info("running job", zap.String("job", j.name))
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		return j.fn(ctx)
+	}()
+	j.finish(start, err)
+	if err != nil {
+		s.logger.Error("job failed", zap.String("job", j.name), zap.Error(err))
+		return
+	}
+	s.logger.Info("job finished", zap.String("job", j.name), zap.Duration("duration", time.Since(start)))
+}
@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	E "github.com/teezzan/candles/internal/errors"
+	"go.uber.org/zap"
+)
+
+func noop(ctx context.Context) error {
+	return nil
+}
+
+func TestScheduler_Register(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      string
+		schedule string
+		wantErr  bool
+	}{
+		{
+			name:     "interval schedule",
+			job:      "other",
+			schedule: "@every 2m",
+		},
+		{
+			name:     "cron expression",
+			job:      "other",
+			schedule: "*/5 * * * *",
+		},
+		{
+			name:     "invalid schedule",
+			job:      "other",
+			schedule: "@every 1d",
+			wantErr:  true,
+		},
+		{
+			name:     "duplicate name",
+			job:      "test",
+			schedule: "@every 2m",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(zap.NewNop())
+			require.NoError(t, s.Register("test", "@every 1h", 0, noop))
+
+			err := s.Register(tt.job, tt.schedule, 0, noop)
+			require.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Len(t, s.Jobs(), 1)
+				return
+			}
+			jobs := s.Jobs()
+			require.Len(t, jobs, 2)
+			assert.Equal(t, tt.job, jobs[1].Name)
+			assert.Equal(t, tt.schedule, jobs[1].Schedule)
+		})
+	}
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	s := New(zap.NewNop())
+	release := make(chan struct{})
+	require.NoError(t, s.Register("test", "@every 1h", time.Minute, func(ctx context.Context) error {
+		<-release
+		return errors.New("test error")
+	}))
+
+	err := s.Trigger("unknown")
+	assert.True(t, E.IsErrNotFound(err))
+
+	require.NoError(t, s.Trigger("test"))
+	state, err := s.Job("test")
+	require.NoError(t, err)
+	assert.True(t, state.Running)
+	assert.Equal(t, int64(60), state.TimeoutInSeconds)
+
+	err = s.Trigger("test")
+	assert.True(t, E.IsErrInvalidArgument(err))
+
+	close(release)
+	require.NoError(t, s.Stop(context.Background()))
+
+	state, err = s.Job("test")
+	require.NoError(t, err)
+	assert.False(t, state.Running)
+	assert.True(t, state.LastRunAt.Valid)
+	assert.True(t, state.LastRunDurationInMS.Valid)
+	assert.Equal(t, "test error", state.LastError.String)
+}
+
+func TestScheduler_skipIfRunning(t *testing.T) {
+	s := New(zap.NewNop())
+	release := make(chan struct{})
+	runs := 0
+	require.NoError(t, s.Register("test", "@every 1h", 0, func(ctx context.Context) error {
+		runs++
+		<-release
+		return nil
+	}))
+
+	require.NoError(t, s.Trigger("test"))
+	scheduled := s.cron.Entry(s.jobs["test"].entryID).Job
+	scheduled.Run()
+	scheduled.Run()
+	close(release)
+	require.NoError(t, s.Stop(context.Background()))
+
+	state, err := s.Job("test")
+	require.NoError(t, err)
+	assert.Equal(t, 1, runs)
+	assert.Equal(t, int64(2), state.SkippedRuns)
+	assert.False(t, state.LastError.Valid)
+}
+
+func TestScheduler_timeout(t *testing.T) {
+	s := New(zap.NewNop())
+	require.NoError(t, s.Register("test", "@every 1h", 10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	require.NoError(t, s.Trigger("test"))
+	require.NoError(t, s.Stop(context.Background()))
+
+	state, err := s.Job("test")
+	require.NoError(t, err)
+	assert.Equal(t, context.DeadlineExceeded.Error(), state.LastError.String)
+}
+
+func TestScheduler_panic(t *testing.T) {
+	s := New(zap.NewNop())
+	require.NoError(t, s.Register("test", "@every 1h", 0, func(ctx context.Context) error {
+		panic("test panic")
+	}))
+
+	require.NoError(t, s.Trigger("test"))
+	require.NoError(t, s.Stop(context.Background()))
+
+	state, err := s.Job("test")
+	require.NoError(t, err)
+	assert.False(t, state.Running)
+	assert.Contains(t, state.LastError.String, "test panic")
+}
+
+func TestScheduler_Stop(t *testing.T) {
+	s := New(zap.NewNop())
+	require.NoError(t, s.Register("test", "@every 1h", 0, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	require.NoError(t, s.Trigger("test"))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := s.Stop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	state, err := s.Job("test")
+	require.NoError(t, err)
+	assert.False(t, state.Running)
+	assert.Equal(t, context.Canceled.Error(), state.LastError.String)
+}
+
+func TestScheduler_Stop_scheduledRun(t *testing.T) {
+	s := New(zap.NewNop())
+	started := make(chan struct{})
+	var finished atomic.Bool
+	require.NoError(t, s.Register("test", "@every 1s", 0, func(ctx context.Context) error {
+		if finished.Load() {
+			return nil
+		}
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		finished.Store(true)
+		return nil
+	}))
+
+	s.Start()
+	<-started
+	require.NoError(t, s.Stop(context.Background()))
+	assert.True(t, finished.Load())
+}
@@ -30,6 +30,18 @@ func GetInt(key string, fallback int) int {
 	return fallback
 }
 
+// GetFloat64 retrieves an environment variable and parses it as float64.
+func GetFloat64(key string, fallback float64) float64 {
+	Keys = append(Keys, key)
+	if v, ok := os.LookupEnv(key); ok {
+		f, err := strconv.ParseFloat(v, 64)
+		if err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
 // GetBool retrieves an environment variable and parses it as bool.
 //
 // Note, only `true` is a valid true value.
@@ -40,3 +52,19 @@ func GetBool(key string, fallback bool) bool {
 	}
 	return fallback
 }
+
+// GetStringSlice retrieves an environment variable and parses it as a comma
+// separated list of strings. Empty items are ignored.
+func GetStringSlice(key string, fallback []string) []string {
+	Keys = append(Keys, key)
+	if v, ok := os.LookupEnv(key); ok {
+		items := []string{}
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return fallback
+}